@@ -0,0 +1,51 @@
+package bot
+
+import (
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/rest"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// ApplicationEmojis manages the discord.Emoji(s) owned by the application of the Client.
+// It resolves the application id from the Client, so it doesn't need to be passed to every call.
+// Application emojis can be used in messages of the application in every guild without requiring the emoji to be in the guild.
+type ApplicationEmojis interface {
+	// GetAll returns all discord.Emoji(s) of the application.
+	GetAll(opts ...rest.RequestOpt) ([]discord.Emoji, error)
+
+	// Get returns the discord.Emoji of the application with the given id.
+	Get(emojiID snowflake.ID, opts ...rest.RequestOpt) (*discord.Emoji, error)
+
+	// Create creates a new discord.Emoji for the application.
+	Create(emojiCreate discord.ApplicationEmojiCreate, opts ...rest.RequestOpt) (*discord.Emoji, error)
+
+	// Update updates the discord.Emoji of the application with the given id.
+	Update(emojiID snowflake.ID, emojiUpdate discord.ApplicationEmojiUpdate, opts ...rest.RequestOpt) (*discord.Emoji, error)
+
+	// Delete deletes the discord.Emoji of the application with the given id.
+	Delete(emojiID snowflake.ID, opts ...rest.RequestOpt) error
+}
+
+type applicationEmojisImpl struct {
+	client Client
+}
+
+func (e *applicationEmojisImpl) GetAll(opts ...rest.RequestOpt) ([]discord.Emoji, error) {
+	return e.client.Rest().GetApplicationEmojis(e.client.ApplicationID(), opts...)
+}
+
+func (e *applicationEmojisImpl) Get(emojiID snowflake.ID, opts ...rest.RequestOpt) (*discord.Emoji, error) {
+	return e.client.Rest().GetApplicationEmoji(e.client.ApplicationID(), emojiID, opts...)
+}
+
+func (e *applicationEmojisImpl) Create(emojiCreate discord.ApplicationEmojiCreate, opts ...rest.RequestOpt) (*discord.Emoji, error) {
+	return e.client.Rest().CreateApplicationEmoji(e.client.ApplicationID(), emojiCreate, opts...)
+}
+
+func (e *applicationEmojisImpl) Update(emojiID snowflake.ID, emojiUpdate discord.ApplicationEmojiUpdate, opts ...rest.RequestOpt) (*discord.Emoji, error) {
+	return e.client.Rest().UpdateApplicationEmoji(e.client.ApplicationID(), emojiID, emojiUpdate, opts...)
+}
+
+func (e *applicationEmojisImpl) Delete(emojiID snowflake.ID, opts ...rest.RequestOpt) error {
+	return e.client.Rest().DeleteApplicationEmoji(e.client.ApplicationID(), emojiID, opts...)
+}
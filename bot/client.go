@@ -2,7 +2,9 @@ package bot
 
 import (
 	"context"
+	"errors"
 	"log/slog"
+	"net/http"
 
 	"github.com/disgoorg/disgo/cache"
 	"github.com/disgoorg/disgo/discord"
@@ -92,6 +94,22 @@ type Client interface {
 	//  limit    : The number of discord.Member(s) to return.
 	RequestMembersWithQuery(ctx context.Context, guildID snowflake.ID, presence bool, nonce string, query string, limit int) error
 
+	// RequestSoundboardSounds sends a gateway.MessageDataRequestSoundboardSounds to the specific gateway.Gateway(s) and requests the discord.SoundboardSound(s) of the specified guilds.
+	// The sounds are returned in the events.SoundboardSounds event.
+	RequestSoundboardSounds(ctx context.Context, guildIDs ...snowflake.ID) error
+
+	// ReconcileVoiceStates refreshes the cached discord.VoiceState(s) of the specified guild over REST.
+	// Voice states of users which are no longer in a voice channel are removed from the cache.
+	// This is useful after a gateway.Gateway resumed or was disconnected for a while & voice state updates might have been missed.
+	// Users which joined a voice channel while no update was received can't be discovered this way.
+	ReconcileVoiceStates(ctx context.Context, guildID snowflake.ID) error
+
+	// LoadActiveThreads fetches all active discord.GuildThread(s) of the specified guild over REST and loads them into the cache.
+	// Like a gateway.EventThreadListSync for the whole guild, cached threads of the guild which are no longer active are removed together with their discord.ThreadMember(s).
+	// If withMembers is true, all discord.ThreadMember(s) of each thread are fetched as well, which requires one request per thread & the gateway.IntentGuildMembers intent.
+	// Otherwise only the discord.ThreadMember(s) of the bot are loaded.
+	LoadActiveThreads(ctx context.Context, guildID snowflake.ID, withMembers bool) error
+
 	// SetPresence sends new presence data to the gateway.Gateway.
 	SetPresence(ctx context.Context, opts ...gateway.PresenceOpt) error
 
@@ -101,6 +119,9 @@ type Client interface {
 	// MemberChunkingManager returns the MemberChunkingManager used by the Client.
 	MemberChunkingManager() MemberChunkingManager
 
+	// ApplicationEmojis returns the ApplicationEmojis of the application of the Client.
+	ApplicationEmojis() ApplicationEmojis
+
 	// OpenHTTPServer starts the configured HTTPServer used for interactions over webhooks.
 	OpenHTTPServer() error
 
@@ -277,6 +298,111 @@ func (c *clientImpl) RequestMembersWithQuery(ctx context.Context, guildID snowfl
 	})
 }
 
+func (c *clientImpl) RequestSoundboardSounds(ctx context.Context, guildIDs ...snowflake.ID) error {
+	shards := map[gateway.Gateway][]snowflake.ID{}
+	for _, guildID := range guildIDs {
+		shard, err := c.Shard(guildID)
+		if err != nil {
+			return err
+		}
+		shards[shard] = append(shards[shard], guildID)
+	}
+	for shard, shardGuildIDs := range shards {
+		if err := shard.Send(ctx, gateway.OpcodeRequestSoundboardSounds, gateway.MessageDataRequestSoundboardSounds{
+			GuildIDs: shardGuildIDs,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *clientImpl) ReconcileVoiceStates(ctx context.Context, guildID snowflake.ID) error {
+	userIDs := []snowflake.ID{c.ID()}
+	c.Caches().VoiceStatesForEach(guildID, func(voiceState discord.VoiceState) {
+		if voiceState.UserID != c.ID() {
+			userIDs = append(userIDs, voiceState.UserID)
+		}
+	})
+
+	for _, userID := range userIDs {
+		var (
+			voiceState *discord.VoiceState
+			err        error
+		)
+		if userID == c.ID() {
+			voiceState, err = c.Rest().GetCurrentUserVoiceState(guildID, rest.WithCtx(ctx))
+		} else {
+			voiceState, err = c.Rest().GetUserVoiceState(guildID, userID, rest.WithCtx(ctx))
+		}
+		if err != nil {
+			var restErr rest.Error
+			if errors.As(err, &restErr) && restErr.Response != nil && restErr.Response.StatusCode == http.StatusNotFound {
+				c.Caches().RemoveVoiceState(guildID, userID)
+				continue
+			}
+			return err
+		}
+		voiceState.GuildID = guildID
+		if voiceState.ChannelID == nil {
+			c.Caches().RemoveVoiceState(guildID, userID)
+			continue
+		}
+		c.Caches().AddVoiceState(*voiceState)
+	}
+	return nil
+}
+
+func (c *clientImpl) LoadActiveThreads(ctx context.Context, guildID snowflake.ID, withMembers bool) error {
+	activeThreads, err := c.Rest().GetActiveGuildThreads(guildID, rest.WithCtx(ctx))
+	if err != nil {
+		return err
+	}
+
+	threadMembers := make(map[snowflake.ID][]discord.ThreadMember, len(activeThreads.Threads))
+	for _, member := range activeThreads.Members {
+		threadMembers[member.ThreadID] = append(threadMembers[member.ThreadID], member)
+	}
+	if withMembers {
+		for _, thread := range activeThreads.Threads {
+			members, err := c.Rest().GetThreadMembers(thread.ID(), rest.WithCtx(ctx))
+			if err != nil {
+				return err
+			}
+			threadMembers[thread.ID()] = members
+		}
+	}
+
+	active := make(map[snowflake.ID]struct{}, len(activeThreads.Threads))
+	for _, thread := range activeThreads.Threads {
+		active[thread.ID()] = struct{}{}
+	}
+
+	var staleThreadIDs []snowflake.ID
+	c.Caches().ChannelsForEach(func(channel discord.GuildChannel) {
+		thread, ok := channel.(discord.GuildThread)
+		if !ok || thread.GuildID() != guildID {
+			return
+		}
+		if _, ok = active[thread.ID()]; !ok {
+			staleThreadIDs = append(staleThreadIDs, thread.ID())
+		}
+	})
+	for _, threadID := range staleThreadIDs {
+		c.Caches().RemoveChannel(threadID)
+		c.Caches().RemoveThreadMembersByThreadID(threadID)
+	}
+
+	for _, thread := range activeThreads.Threads {
+		c.Caches().AddChannel(thread)
+		c.Caches().RemoveThreadMembersByThreadID(thread.ID())
+		for _, member := range threadMembers[thread.ID()] {
+			c.Caches().AddThreadMember(member)
+		}
+	}
+	return nil
+}
+
 func (c *clientImpl) SetPresence(ctx context.Context, opts ...gateway.PresenceOpt) error {
 	if !c.HasGateway() {
 		return discord.ErrNoGateway
@@ -311,6 +437,10 @@ func (c *clientImpl) MemberChunkingManager() MemberChunkingManager {
 	return c.memberChunkingManager
 }
 
+func (c *clientImpl) ApplicationEmojis() ApplicationEmojis {
+	return &applicationEmojisImpl{client: c}
+}
+
 func (c *clientImpl) OpenHTTPServer() error {
 	if c.httpServer == nil {
 		return discord.ErrNoHTTPServer
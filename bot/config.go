@@ -15,10 +15,10 @@ import (
 )
 
 // DefaultConfig returns a Config with sensible defaults.
-func DefaultConfig(gatewayHandlers map[gateway.EventType]GatewayEventHandler, httpHandler HTTPServerEventHandler) *Config {
+func DefaultConfig(gatewayHandlers map[gateway.EventType]GatewayEventHandler, httpHandler HTTPServerEventHandler, webhookEventHandler WebhookEventHandler) *Config {
 	return &Config{
 		Logger:                 slog.Default(),
-		EventManagerConfigOpts: []EventManagerConfigOpt{WithGatewayHandlers(gatewayHandlers), WithHTTPServerHandler(httpHandler)},
+		EventManagerConfigOpts: []EventManagerConfigOpt{WithGatewayHandlers(gatewayHandlers), WithHTTPServerHandler(httpHandler), WithWebhookEventHandler(webhookEventHandler)},
 		MemberChunkingFilter:   MemberChunkingFilterNone,
 	}
 }
@@ -312,6 +312,7 @@ func BuildClient(token string, cfg *Config, gatewayEventHandlerFunc func(client
 	if cfg.HTTPServer == nil && cfg.PublicKey != "" {
 		cfg.HTTPServerConfigOpts = append([]httpserver.ConfigOpt{
 			httpserver.WithLogger(cfg.Logger),
+			httpserver.WithWebhookEventHandlerFunc(client.eventManager.HandleWebhookEvent),
 		}, cfg.HTTPServerConfigOpts...)
 
 		cfg.HTTPServer = httpserver.New(cfg.PublicKey, httpServerEventHandlerFunc(client), cfg.HTTPServerConfigOpts...)
@@ -330,3 +331,14 @@ func BuildClient(token string, cfg *Config, gatewayEventHandlerFunc func(client
 
 	return client, nil
 }
+
+// NewHTTPServerApplication returns a httpserver.Application which passes the interactions & webhook events to the EventManager of the given Client.
+// Use it to serve multiple Client(s) with a single httpserver.MultiServer.
+func NewHTTPServerApplication(client Client, publicKey string) httpserver.Application {
+	return httpserver.Application{
+		ID:                      client.ApplicationID(),
+		PublicKey:               publicKey,
+		EventHandlerFunc:        client.EventManager().HandleHTTPEvent,
+		WebhookEventHandlerFunc: client.EventManager().HandleWebhookEvent,
+	}
+}
@@ -24,6 +24,7 @@ func NewEventManager(client Client, opts ...EventManagerConfigOpt) EventManager
 		asyncEventsEnabled: cfg.AsyncEventsEnabled,
 		gatewayHandlers:    cfg.GatewayHandlers,
 		httpServerHandler:  cfg.HTTPServerHandler,
+		webhookHandler:     cfg.WebhookEventHandler,
 	}
 }
 
@@ -41,6 +42,9 @@ type EventManager interface {
 	// HandleHTTPEvent calls the HTTPServerEventHandler for the payload
 	HandleHTTPEvent(respondFunc httpserver.RespondFunc, event httpserver.EventInteractionCreate)
 
+	// HandleWebhookEvent calls the WebhookEventHandler for the payload
+	HandleWebhookEvent(event httpserver.WebhookEvent)
+
 	// DispatchEvent dispatches a new Event to the Client's EventListener(s)
 	DispatchEvent(event Event)
 }
@@ -117,6 +121,11 @@ type HTTPServerEventHandler interface {
 	HandleHTTPEvent(client Client, respondFunc httpserver.RespondFunc, event httpserver.EventInteractionCreate)
 }
 
+// WebhookEventHandler is used to handle webhook events received over the httpserver.Server
+type WebhookEventHandler interface {
+	HandleWebhookEvent(client Client, event httpserver.WebhookEvent)
+}
+
 type eventManagerImpl struct {
 	mu sync.Mutex
 
@@ -127,6 +136,7 @@ type eventManagerImpl struct {
 	asyncEventsEnabled bool
 	gatewayHandlers    map[gateway.EventType]GatewayEventHandler
 	httpServerHandler  HTTPServerEventHandler
+	webhookHandler     WebhookEventHandler
 }
 
 func (e *eventManagerImpl) HandleGatewayEvent(gatewayEventType gateway.EventType, sequenceNumber int, shardID int, event gateway.EventData) {
@@ -145,6 +155,16 @@ func (e *eventManagerImpl) HandleHTTPEvent(respondFunc httpserver.RespondFunc, e
 	e.httpServerHandler.HandleHTTPEvent(e.client, respondFunc, event)
 }
 
+func (e *eventManagerImpl) HandleWebhookEvent(event httpserver.WebhookEvent) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.webhookHandler == nil {
+		e.logger.Warn("no handler for webhook event found", slog.Any("event_type", event.Type))
+		return
+	}
+	e.webhookHandler.HandleWebhookEvent(e.client, event)
+}
+
 func (e *eventManagerImpl) DispatchEvent(event Event) {
 	defer func() {
 		if r := recover(); r != nil {
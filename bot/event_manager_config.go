@@ -19,8 +19,9 @@ type EventManagerConfig struct {
 	EventListeners     []EventListener
 	AsyncEventsEnabled bool
 
-	GatewayHandlers   map[gateway.EventType]GatewayEventHandler
-	HTTPServerHandler HTTPServerEventHandler
+	GatewayHandlers     map[gateway.EventType]GatewayEventHandler
+	HTTPServerHandler   HTTPServerEventHandler
+	WebhookEventHandler WebhookEventHandler
 }
 
 // EventManagerConfigOpt is a functional option for configuring an EventManager.
@@ -77,3 +78,10 @@ func WithHTTPServerHandler(handler HTTPServerEventHandler) EventManagerConfigOpt
 		config.HTTPServerHandler = handler
 	}
 }
+
+// WithWebhookEventHandler overrides the given WebhookEventHandler in the EventManagerConfig.
+func WithWebhookEventHandler(handler WebhookEventHandler) EventManagerConfigOpt {
+	return func(config *EventManagerConfig) {
+		config.WebhookEventHandler = handler
+	}
+}
@@ -21,6 +21,7 @@ func DefaultConfig() *Config {
 		MessageCachePolicy:             PolicyAll[discord.Message],
 		EmojiCachePolicy:               PolicyAll[discord.Emoji],
 		StickerCachePolicy:             PolicyAll[discord.Sticker],
+		SoundboardSoundCachePolicy:     PolicyAll[discord.SoundboardSound],
 	}
 }
 
@@ -65,6 +66,9 @@ type Config struct {
 
 	StickerCache       StickerCache
 	StickerCachePolicy Policy[discord.Sticker]
+
+	SoundboardSoundCache       SoundboardSoundCache
+	SoundboardSoundCachePolicy Policy[discord.SoundboardSound]
 }
 
 // ConfigOpt is a type alias for a function that takes a Config and is used to configure your Caches.
@@ -114,6 +118,9 @@ func (c *Config) Apply(opts []ConfigOpt) {
 	if c.StickerCache == nil {
 		c.StickerCache = NewStickerCache(NewGroupedCache[discord.Sticker](c.CacheFlags, FlagStickers, c.StickerCachePolicy))
 	}
+	if c.SoundboardSoundCache == nil {
+		c.SoundboardSoundCache = NewSoundboardSoundCache(NewGroupedCache[discord.SoundboardSound](c.CacheFlags, FlagSoundboardSounds, c.SoundboardSoundCachePolicy))
+	}
 }
 
 // WithCaches sets the Flags of the Config.
@@ -290,3 +297,17 @@ func WithStickerCache(stickerCache StickerCache) ConfigOpt {
 		config.StickerCache = stickerCache
 	}
 }
+
+// WithSoundboardSoundCachePolicy sets the Policy[discord.SoundboardSound] of the Config.
+func WithSoundboardSoundCachePolicy(policy Policy[discord.SoundboardSound]) ConfigOpt {
+	return func(config *Config) {
+		config.SoundboardSoundCachePolicy = policy
+	}
+}
+
+// WithSoundboardSoundCache sets the SoundboardSoundCache of the Config.
+func WithSoundboardSoundCache(soundboardSoundCache SoundboardSoundCache) ConfigOpt {
+	return func(config *Config) {
+		config.SoundboardSoundCache = soundboardSoundCache
+	}
+}
@@ -671,6 +671,57 @@ func (c *stickerCacheImpl) RemoveStickersByGuildID(guildID snowflake.ID) {
 	c.cache.GroupRemove(guildID)
 }
 
+type SoundboardSoundCache interface {
+	SoundboardSound(guildID snowflake.ID, soundID snowflake.ID) (discord.SoundboardSound, bool)
+	SoundboardSoundsForEach(guildID snowflake.ID, fn func(sound discord.SoundboardSound))
+	SoundboardSoundsAllLen() int
+	SoundboardSoundsLen(guildID snowflake.ID) int
+	AddSoundboardSound(sound discord.SoundboardSound)
+	RemoveSoundboardSound(guildID snowflake.ID, soundID snowflake.ID) (discord.SoundboardSound, bool)
+	RemoveSoundboardSoundsByGuildID(guildID snowflake.ID)
+}
+
+func NewSoundboardSoundCache(cache GroupedCache[discord.SoundboardSound]) SoundboardSoundCache {
+	return &soundboardSoundCacheImpl{
+		cache: cache,
+	}
+}
+
+type soundboardSoundCacheImpl struct {
+	cache GroupedCache[discord.SoundboardSound]
+}
+
+func (c *soundboardSoundCacheImpl) SoundboardSound(guildID snowflake.ID, soundID snowflake.ID) (discord.SoundboardSound, bool) {
+	return c.cache.Get(guildID, soundID)
+}
+
+func (c *soundboardSoundCacheImpl) SoundboardSoundsForEach(guildID snowflake.ID, fn func(sound discord.SoundboardSound)) {
+	c.cache.GroupForEach(guildID, fn)
+}
+
+func (c *soundboardSoundCacheImpl) SoundboardSoundsAllLen() int {
+	return c.cache.Len()
+}
+
+func (c *soundboardSoundCacheImpl) SoundboardSoundsLen(guildID snowflake.ID) int {
+	return c.cache.GroupLen(guildID)
+}
+
+func (c *soundboardSoundCacheImpl) AddSoundboardSound(sound discord.SoundboardSound) {
+	if sound.GuildID == nil {
+		return
+	}
+	c.cache.Put(*sound.GuildID, sound.SoundID, sound)
+}
+
+func (c *soundboardSoundCacheImpl) RemoveSoundboardSound(guildID snowflake.ID, soundID snowflake.ID) (discord.SoundboardSound, bool) {
+	return c.cache.Remove(guildID, soundID)
+}
+
+func (c *soundboardSoundCacheImpl) RemoveSoundboardSoundsByGuildID(guildID snowflake.ID) {
+	c.cache.GroupRemove(guildID)
+}
+
 // Caches combines all different entity caches into one with some utility methods.
 type Caches interface {
 	SelfUserCache
@@ -686,6 +737,7 @@ type Caches interface {
 	MessageCache
 	EmojiCache
 	StickerCache
+	SoundboardSoundCache
 
 	// CacheFlags returns the current configured FLags of the caches.
 	CacheFlags() Flags
@@ -773,6 +825,7 @@ func New(opts ...ConfigOpt) Caches {
 		MessageCache:             config.MessageCache,
 		EmojiCache:               config.EmojiCache,
 		StickerCache:             config.StickerCache,
+		SoundboardSoundCache:     config.SoundboardSoundCache,
 	}
 }
 
@@ -791,6 +844,7 @@ type cachesImpl struct {
 	MessageCache
 	EmojiCache
 	StickerCache
+	SoundboardSoundCache
 	SelfUserCache
 }
 
@@ -57,9 +57,25 @@ type GrantType string
 const (
 	GrantTypeAuthorizationCode GrantType = "authorization_code"
 	GrantTypeRefreshToken      GrantType = "refresh_token"
+	GrantTypeClientCredentials GrantType = "client_credentials"
 )
 
 // String returns the GrantType as a string.
 func (t GrantType) String() string {
 	return string(t)
 }
+
+// TokenTypeHint hints Discord which type of token is revoked.
+// See https://discord.com/developers/docs/topics/oauth2#authorization-code-grant-token-revocation-example for more information.
+type TokenTypeHint string
+
+// Discord's supported TokenTypeHint(s).
+const (
+	TokenTypeHintAccessToken  TokenTypeHint = "access_token"
+	TokenTypeHintRefreshToken TokenTypeHint = "refresh_token"
+)
+
+// String returns the TokenTypeHint as a string.
+func (t TokenTypeHint) String() string {
+	return string(t)
+}
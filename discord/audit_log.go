@@ -95,6 +95,12 @@ const (
 	AuditLogApplicationCommandPermissionUpdate AuditLogEvent = 121
 )
 
+const (
+	AuditLogSoundboardSoundCreate AuditLogEvent = iota + 130
+	AuditLogSoundboardSoundUpdate
+	AuditLogSoundboardSoundDelete
+)
+
 const (
 	AuditLogAutoModerationRuleCreate AuditLogEvent = iota + 140
 	AuditLogAutoModerationRuleUpdate
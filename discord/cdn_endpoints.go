@@ -45,6 +45,8 @@ var (
 	CustomSticker     = NewCDN("/stickers/{sticker.id}", FileFormatPNG, FileFormatLottie, FileFormatGIF)
 
 	AttachmentFile = NewCDN("/attachments/{channel.id}/{attachment.id}/{file.name}", FileFormatNone)
+
+	SoundboardSoundFile = NewCDN("/soundboard-sounds/{sound.id}", FileFormatNone)
 )
 
 // FileFormat is the type of file on Discord's CDN (https://discord.com/developers/docs/reference#image-formatting-image-formats)
@@ -98,6 +100,10 @@ func (e CDNEndpoint) URL(format FileFormat, values QueryValues, params ...any) s
 		return urlPrint(CDNMedia+e.Route+"."+format.String(), params...) + query
 	}
 
+	if format == FileFormatNone {
+		return urlPrint(CDN+e.Route, params...) + query
+	}
+
 	return urlPrint(CDN+e.Route+"."+format.String(), params...) + query
 }
 
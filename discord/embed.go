@@ -1,6 +1,9 @@
 package discord
 
-import "time"
+import (
+	"time"
+	"unicode/utf8"
+)
 
 // EmbedType is the type of Embed
 type EmbedType string
@@ -32,6 +35,22 @@ type Embed struct {
 	Fields      []EmbedField   `json:"fields,omitempty"`
 }
 
+// Length returns the amount of characters of the Embed which count towards the 6000 characters limit of all Embed(s) in a Message.
+// See https://discord.com/developers/docs/resources/message#embed-object-embed-limits for more information.
+func (e Embed) Length() int {
+	length := utf8.RuneCountInString(e.Title) + utf8.RuneCountInString(e.Description)
+	if e.Footer != nil {
+		length += utf8.RuneCountInString(e.Footer.Text)
+	}
+	if e.Author != nil {
+		length += utf8.RuneCountInString(e.Author.Name)
+	}
+	for _, field := range e.Fields {
+		length += utf8.RuneCountInString(field.Name) + utf8.RuneCountInString(field.Value)
+	}
+	return length
+}
+
 // The EmbedResource of an Embed.Image/Embed.Thumbnail/Embed.Video
 type EmbedResource struct {
 	URL      string `json:"url,omitempty"`
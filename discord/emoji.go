@@ -65,6 +65,22 @@ type EmojiUpdate struct {
 	Roles *[]snowflake.ID `json:"roles,omitempty"`
 }
 
+// ApplicationEmojis is the response of the list application emojis endpoint
+type ApplicationEmojis struct {
+	Items []Emoji `json:"items"`
+}
+
+// ApplicationEmojiCreate is used to create an application emoji
+type ApplicationEmojiCreate struct {
+	Name  string `json:"name"`
+	Image Icon   `json:"image"`
+}
+
+// ApplicationEmojiUpdate is used to update an application emoji
+type ApplicationEmojiUpdate struct {
+	Name *string `json:"name,omitempty"`
+}
+
 type PartialEmoji struct {
 	ID       *snowflake.ID `json:"id,omitempty"`
 	Name     *string       `json:"name,omitempty"`
@@ -1,6 +1,7 @@
 package discord
 
 import (
+	"slices"
 	"time"
 
 	"github.com/disgoorg/json"
@@ -164,6 +165,7 @@ type Guild struct {
 	PremiumProgressBarEnabled   bool                       `json:"premium_progress_bar_enabled"`
 	JoinedAt                    time.Time                  `json:"joined_at"`
 	SafetyAlertsChannelID       *snowflake.ID              `json:"safety_alerts_channel_id"`
+	IncidentsData               *GuildIncidentsData        `json:"incidents_data"`
 
 	// only over GET /guilds/{guild.id}
 	ApproximateMemberCount   int `json:"approximate_member_count"`
@@ -206,6 +208,44 @@ func (g Guild) CreatedAt() time.Time {
 	return g.ID.Time()
 }
 
+// WidgetImageURL returns the url of the PNG widget image of the Guild in the given GuildWidgetImageStyle
+func (g Guild) WidgetImageURL(style GuildWidgetImageStyle) string {
+	return WidgetImageURL(g.ID, style)
+}
+
+// InvitesPaused returns true if invites to the Guild are currently paused through incident actions or the INVITES_DISABLED GuildFeature
+func (g Guild) InvitesPaused() bool {
+	if g.IncidentsData != nil && g.IncidentsData.InvitesDisabledUntil != nil && g.IncidentsData.InvitesDisabledUntil.After(time.Now()) {
+		return true
+	}
+	return slices.Contains(g.Features, GuildFeatureInvitesDisabled)
+}
+
+// DMsPaused returns true if DMs between members of the Guild are currently paused through incident actions
+func (g Guild) DMsPaused() bool {
+	return g.IncidentsData != nil && g.IncidentsData.DMsDisabledUntil != nil && g.IncidentsData.DMsDisabledUntil.After(time.Now())
+}
+
+// GuildIncidentsData holds the incident actions & detected incidents of a Guild (https://discord.com/developers/docs/resources/guild#incidents-data-object)
+type GuildIncidentsData struct {
+	InvitesDisabledUntil *time.Time `json:"invites_disabled_until"`
+	DMsDisabledUntil     *time.Time `json:"dms_disabled_until"`
+	DMSpamDetectedAt     *time.Time `json:"dm_spam_detected_at"`
+	RaidDetectedAt       *time.Time `json:"raid_detected_at"`
+}
+
+// GuildIncidentActionsUpdate is used to pause or resume invites & DMs of a Guild. The pause can be lifted by setting the field to null.
+// Discord allows pausing for at most 24 hours.
+type GuildIncidentActionsUpdate struct {
+	InvitesDisabledUntil *json.Nullable[time.Time] `json:"invites_disabled_until,omitempty"`
+	DMsDisabledUntil     *json.Nullable[time.Time] `json:"dms_disabled_until,omitempty"`
+}
+
+// GuildMFALevelUpdate is used to update the MFALevel of a Guild
+type GuildMFALevelUpdate struct {
+	Level MFALevel `json:"level"`
+}
+
 type RestGuild struct {
 	Guild
 	Stickers []Sticker `json:"stickers"`
@@ -224,6 +264,7 @@ type GatewayGuild struct {
 	Presences            []Presence            `json:"presences"`
 	StageInstances       []StageInstance       `json:"stage_instances"`
 	GuildScheduledEvents []GuildScheduledEvent `json:"guild_scheduled_events"`
+	SoundboardSounds     []SoundboardSound     `json:"soundboard_sounds"`
 }
 
 func (g *GatewayGuild) UnmarshalJSON(data []byte) error {
@@ -0,0 +1,58 @@
+package discord
+
+import (
+	"github.com/disgoorg/json"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// GuildWidgetSettings are the widget settings of a Guild (https://discord.com/developers/docs/resources/guild#guild-widget-settings-object)
+type GuildWidgetSettings struct {
+	Enabled   bool          `json:"enabled"`
+	ChannelID *snowflake.ID `json:"channel_id"`
+}
+
+// GuildWidgetSettingsUpdate is used to update the GuildWidgetSettings of a Guild
+type GuildWidgetSettingsUpdate struct {
+	Enabled   *bool                        `json:"enabled,omitempty"`
+	ChannelID *json.Nullable[snowflake.ID] `json:"channel_id,omitempty"`
+}
+
+// GuildWidget is the public widget of a Guild (https://discord.com/developers/docs/resources/guild#guild-widget-object)
+type GuildWidget struct {
+	ID            snowflake.ID         `json:"id"`
+	Name          string               `json:"name"`
+	InstantInvite *string              `json:"instant_invite"`
+	Channels      []GuildWidgetChannel `json:"channels"`
+	Members       []GuildWidgetMember  `json:"members"`
+	PresenceCount int                  `json:"presence_count"`
+}
+
+// GuildWidgetChannel is a voice channel shown in a GuildWidget
+type GuildWidgetChannel struct {
+	ID       snowflake.ID `json:"id"`
+	Name     string       `json:"name"`
+	Position int          `json:"position"`
+}
+
+// GuildWidgetMember is an online member shown in a GuildWidget.
+// The ID is an anonymized index & not the id of the User.
+type GuildWidgetMember struct {
+	ID            string        `json:"id"`
+	Username      string        `json:"username"`
+	Discriminator string        `json:"discriminator"`
+	Avatar        *string       `json:"avatar"`
+	Status        OnlineStatus  `json:"status"`
+	AvatarURL     string        `json:"avatar_url"`
+	ChannelID     *snowflake.ID `json:"channel_id,omitempty"`
+}
+
+// GuildWidgetImageStyle is the style of the widget image (https://discord.com/developers/docs/resources/guild#get-guild-widget-image-widget-style-options)
+type GuildWidgetImageStyle string
+
+const (
+	GuildWidgetImageStyleShield  GuildWidgetImageStyle = "shield"
+	GuildWidgetImageStyleBanner1 GuildWidgetImageStyle = "banner1"
+	GuildWidgetImageStyleBanner2 GuildWidgetImageStyle = "banner2"
+	GuildWidgetImageStyleBanner3 GuildWidgetImageStyle = "banner3"
+	GuildWidgetImageStyleBanner4 GuildWidgetImageStyle = "banner4"
+)
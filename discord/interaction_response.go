@@ -1,5 +1,7 @@
 package discord
 
+import "github.com/disgoorg/snowflake/v2"
+
 // InteractionResponseType indicates the type of slash command response, whether it's responding immediately or deferring to edit your response later
 type InteractionResponseType int
 
@@ -15,6 +17,8 @@ const (
 	InteractionResponseTypeAutocompleteResult
 	InteractionResponseTypeModal
 	InteractionResponseTypePremiumRequired
+	_
+	InteractionResponseTypeLaunchActivity
 )
 
 // InteractionResponse is how you answer interactions. If an answer is not sent within 3 seconds of receiving it, the interaction is failed, and you will be unable to respond to it.
@@ -31,6 +35,34 @@ func (r InteractionResponse) ToBody() (any, error) {
 	return r, nil
 }
 
+// InteractionCallbackResponse is returned when responding to an interaction with the with_response query param set to true
+type InteractionCallbackResponse struct {
+	Interaction InteractionCallback          `json:"interaction"`
+	Resource    *InteractionCallbackResource `json:"resource"`
+}
+
+// InteractionCallback is the interaction an InteractionCallbackResponse belongs to
+type InteractionCallback struct {
+	ID                       snowflake.ID    `json:"id"`
+	Type                     InteractionType `json:"type"`
+	ActivityInstanceID       *string         `json:"activity_instance_id"`
+	ResponseMessageID        *snowflake.ID   `json:"response_message_id"`
+	ResponseMessageLoading   *bool           `json:"response_message_loading"`
+	ResponseMessageEphemeral *bool           `json:"response_message_ephemeral"`
+}
+
+// InteractionCallbackResource is the resource which was created by responding to an interaction
+type InteractionCallbackResource struct {
+	Type             InteractionResponseType              `json:"type"`
+	ActivityInstance *InteractionCallbackActivityInstance `json:"activity_instance"`
+	Message          *Message                             `json:"message"`
+}
+
+// InteractionCallbackActivityInstance is the activity instance which was launched by responding with InteractionResponseTypeLaunchActivity
+type InteractionCallbackActivityInstance struct {
+	ID string `json:"id"`
+}
+
 type InteractionResponseData interface {
 	interactionCallbackData()
 }
@@ -117,6 +117,7 @@ type Message struct {
 	Resolved             *ResolvedData         `json:"resolved,omitempty"`
 	Poll                 *Poll                 `json:"poll,omitempty"`
 	Call                 *MessageCall          `json:"call,omitempty"`
+	MessageSnapshots     []MessageSnapshot     `json:"message_snapshots,omitempty"`
 }
 
 func (m *Message) UnmarshalJSON(data []byte) error {
@@ -405,12 +406,66 @@ type MessageApplication struct {
 	Name        string       `json:"name"`
 }
 
+// MessageReferenceType is the type of MessageReference
+type MessageReferenceType int
+
+const (
+	// MessageReferenceTypeDefault is a standard reference used by replies
+	MessageReferenceTypeDefault MessageReferenceType = iota
+	// MessageReferenceTypeForward is a reference used to point to a message at a point in time
+	MessageReferenceTypeForward
+)
+
 // MessageReference is a reference to another message
 type MessageReference struct {
-	MessageID       *snowflake.ID `json:"message_id"`
-	ChannelID       *snowflake.ID `json:"channel_id,omitempty"`
-	GuildID         *snowflake.ID `json:"guild_id,omitempty"`
-	FailIfNotExists bool          `json:"fail_if_not_exists,omitempty"`
+	Type            MessageReferenceType `json:"type,omitempty"`
+	MessageID       *snowflake.ID        `json:"message_id"`
+	ChannelID       *snowflake.ID        `json:"channel_id,omitempty"`
+	GuildID         *snowflake.ID        `json:"guild_id,omitempty"`
+	FailIfNotExists bool                 `json:"fail_if_not_exists,omitempty"`
+}
+
+// MessageSnapshot is a snapshot of a forwarded Message
+type MessageSnapshot struct {
+	Message PartialMessage `json:"message"`
+}
+
+// PartialMessage is a subset of Message fields which are included in a MessageSnapshot
+type PartialMessage struct {
+	Type            MessageType          `json:"type"`
+	Content         string               `json:"content,omitempty"`
+	Embeds          []Embed              `json:"embeds,omitempty"`
+	Attachments     []Attachment         `json:"attachments"`
+	CreatedAt       time.Time            `json:"timestamp"`
+	EditedTimestamp *time.Time           `json:"edited_timestamp"`
+	Flags           MessageFlags         `json:"flags"`
+	Mentions        []User               `json:"mentions"`
+	MentionRoles    []snowflake.ID       `json:"mention_roles"`
+	StickerItems    []MessageSticker     `json:"sticker_items,omitempty"`
+	Components      []ContainerComponent `json:"components,omitempty"`
+}
+
+func (m *PartialMessage) UnmarshalJSON(data []byte) error {
+	type partialMessage PartialMessage
+	var v struct {
+		Components []UnmarshalComponent `json:"components"`
+		partialMessage
+	}
+
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	*m = PartialMessage(v.partialMessage)
+
+	if len(v.Components) > 0 {
+		m.Components = make([]ContainerComponent, len(v.Components))
+		for i := range v.Components {
+			m.Components[i] = v.Components[i].Component.(ContainerComponent)
+		}
+	}
+
+	return nil
 }
 
 // MessageInteraction is sent on the Message object when the message is a response to an interaction
@@ -444,6 +499,7 @@ const (
 	_
 	MessageFlagSuppressNotifications
 	MessageFlagIsVoiceMessage
+	MessageFlagHasSnapshot
 	MessageFlagsNone MessageFlags = 0
 )
 
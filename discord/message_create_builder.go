@@ -208,6 +208,17 @@ func (b *MessageCreateBuilder) SetMessageReferenceByID(messageID snowflake.ID) *
 	return b
 }
 
+// SetForwardedMessage allows you to forward the Message with the given ID from the given channel.
+// A forwarded Message can not contain any content, embeds, files, stickers, components or polls
+func (b *MessageCreateBuilder) SetForwardedMessage(channelID snowflake.ID, messageID snowflake.ID) *MessageCreateBuilder {
+	b.MessageReference = &MessageReference{
+		Type:      MessageReferenceTypeForward,
+		MessageID: &messageID,
+		ChannelID: &channelID,
+	}
+	return b
+}
+
 // SetFlags sets the message flags of the Message
 func (b *MessageCreateBuilder) SetFlags(flags MessageFlags) *MessageCreateBuilder {
 	b.Flags = flags
@@ -0,0 +1,48 @@
+package discord
+
+import (
+	"testing"
+
+	"github.com/disgoorg/json"
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessage_UnmarshalJSONSnapshots(t *testing.T) {
+	data := []byte(`{
+		"id": "2",
+		"channel_id": "1",
+		"type": 0,
+		"flags": 16384,
+		"message_reference": {"type": 1, "message_id": "3", "channel_id": "4"},
+		"message_snapshots": [{
+			"message": {
+				"type": 0,
+				"content": "hello",
+				"attachments": [],
+				"timestamp": "2024-01-01T00:00:00Z",
+				"edited_timestamp": null,
+				"flags": 0,
+				"mentions": [],
+				"mention_roles": [],
+				"components": [{"type": 1, "components": [{"type": 2, "style": 5, "label": "link", "url": "https://example.com"}]}]
+			}
+		}]
+	}`)
+
+	var message Message
+	assert.NoError(t, json.Unmarshal(data, &message))
+
+	assert.True(t, message.Flags.Has(MessageFlagHasSnapshot))
+	if assert.NotNil(t, message.MessageReference) {
+		assert.Equal(t, MessageReferenceTypeForward, message.MessageReference.Type)
+		assert.Equal(t, snowflake.ID(3), *message.MessageReference.MessageID)
+	}
+	if assert.Len(t, message.MessageSnapshots, 1) {
+		snapshot := message.MessageSnapshots[0].Message
+		assert.Equal(t, "hello", snapshot.Content)
+		if assert.Len(t, snapshot.Components, 1) {
+			assert.IsType(t, ActionRowComponent{}, snapshot.Components[0])
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package discord
+
+import (
+	"encoding/base64"
+	"io"
+	"time"
+
+	"github.com/disgoorg/json"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// SoundboardSound is a sound which can be played in voice channels (https://discord.com/developers/docs/resources/soundboard#soundboard-sound-object)
+type SoundboardSound struct {
+	SoundID   snowflake.ID  `json:"sound_id"`
+	Name      string        `json:"name"`
+	Volume    float64       `json:"volume"`
+	EmojiID   *snowflake.ID `json:"emoji_id"`
+	EmojiName *string       `json:"emoji_name"`
+	GuildID   *snowflake.ID `json:"guild_id,omitempty"`
+	Available bool          `json:"available"`
+	User      *User         `json:"user,omitempty"`
+}
+
+// URL returns the URL of the sound file
+func (s SoundboardSound) URL(opts ...CDNOpt) string {
+	return formatAssetURL(SoundboardSoundFile, append([]CDNOpt{WithFormat(FileFormatNone)}, opts...), s.SoundID)
+}
+
+func (s SoundboardSound) CreatedAt() time.Time {
+	return s.SoundID.Time()
+}
+
+// SoundboardSounds is the response of the list guild soundboard sounds endpoint
+type SoundboardSounds struct {
+	Items []SoundboardSound `json:"items"`
+}
+
+type SoundboardSoundCreate struct {
+	Name      string        `json:"name"`
+	Sound     Sound         `json:"sound"`
+	Volume    *float64      `json:"volume,omitempty"`
+	EmojiID   *snowflake.ID `json:"emoji_id,omitempty"`
+	EmojiName *string       `json:"emoji_name,omitempty"`
+}
+
+type SoundboardSoundUpdate struct {
+	Name      *string                      `json:"name,omitempty"`
+	Volume    *json.Nullable[float64]      `json:"volume,omitempty"`
+	EmojiID   *json.Nullable[snowflake.ID] `json:"emoji_id,omitempty"`
+	EmojiName *json.Nullable[string]       `json:"emoji_name,omitempty"`
+}
+
+// SendSoundboardSound is used to play a SoundboardSound in a voice channel the bot is connected to
+type SendSoundboardSound struct {
+	SoundID snowflake.ID `json:"sound_id"`
+	// SourceGuildID is required to play sounds from other guilds
+	SourceGuildID *snowflake.ID `json:"source_guild_id,omitempty"`
+}
+
+type SoundType string
+
+const (
+	SoundTypeMP3     SoundType = "audio/mpeg"
+	SoundTypeOGG     SoundType = "audio/ogg"
+	SoundTypeUnknown           = SoundTypeMP3
+)
+
+func (t SoundType) GetMIME() string {
+	return string(t)
+}
+
+func (t SoundType) GetHeader() string {
+	return "data:" + string(t) + ";base64"
+}
+
+var _ json.Marshaler = (*Sound)(nil)
+
+func NewSound(soundType SoundType, reader io.Reader) (*Sound, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	return NewSoundRaw(soundType, data), nil
+}
+
+func NewSoundRaw(soundType SoundType, src []byte) *Sound {
+	data := make([]byte, base64.StdEncoding.EncodedLen(len(src)))
+	base64.StdEncoding.Encode(data, src)
+	return &Sound{Type: soundType, Data: data}
+}
+
+// Sound is a base64 encoded sound file used to create a SoundboardSound
+type Sound struct {
+	Type SoundType
+	Data []byte
+}
+
+func (s Sound) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+func (s Sound) String() string {
+	if len(s.Data) == 0 {
+		return ""
+	}
+	return s.Type.GetHeader() + "," + string(s.Data)
+}
+
+// VoiceChannelEffectAnimationType is the type of animation of a VoiceChannelEffect
+type VoiceChannelEffectAnimationType int
+
+const (
+	VoiceChannelEffectAnimationTypePremium VoiceChannelEffectAnimationType = iota
+	VoiceChannelEffectAnimationTypeBasic
+)
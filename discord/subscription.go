@@ -0,0 +1,32 @@
+package discord
+
+import (
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+)
+
+type Subscription struct {
+	ID                 snowflake.ID       `json:"id"`
+	UserID             snowflake.ID       `json:"user_id"`
+	SkuIDs             []snowflake.ID     `json:"sku_ids"`
+	EntitlementIDs     []snowflake.ID     `json:"entitlement_ids"`
+	RenewalSkuIDs      []snowflake.ID     `json:"renewal_sku_ids"`
+	CurrentPeriodStart time.Time          `json:"current_period_start"`
+	CurrentPeriodEnd   time.Time          `json:"current_period_end"`
+	Status             SubscriptionStatus `json:"status"`
+	CanceledAt         *time.Time         `json:"canceled_at"`
+	Country            *string            `json:"country"`
+}
+
+func (s Subscription) CreatedAt() time.Time {
+	return s.ID.Time()
+}
+
+type SubscriptionStatus int
+
+const (
+	SubscriptionStatusActive SubscriptionStatus = iota
+	SubscriptionStatusEnding
+	SubscriptionStatusInactive
+)
@@ -42,6 +42,15 @@ func WebhookURL(webhookID snowflake.ID, webhookToken string) string {
 	return urlPrint("https://discord.com/api/webhooks/{webhook.id}/{webhook.token}", webhookID, webhookToken)
 }
 
+// WidgetImageURL returns the url of the PNG widget image of a Guild in the given GuildWidgetImageStyle
+func WidgetImageURL(guildID snowflake.ID, style GuildWidgetImageStyle) string {
+	url := urlPrint("https://discord.com/api/guilds/{guild.id}/widget.png", guildID)
+	if style != "" {
+		url += "?style=" + string(style)
+	}
+	return url
+}
+
 // AuthorizeURL returns the OAuth2 authorize url with the given query params
 func AuthorizeURL(values QueryValues) string {
 	query := values.Encode()
@@ -40,6 +40,10 @@
 //
 // Package oauth2 provides a high level client interface for interacting with Discord oauth2.
 //
+// # LinkedRoles
+//
+// Package linkedroles provides a high level interface for managing linked roles metadata & attributes of users.
+//
 // # Voice
 //
 // Package voice provides a high level client interface for interacting with Discord voice.
@@ -98,7 +102,7 @@ func getOS() string {
 
 // New creates a new bot.Client with the provided token & bot.ConfigOpt(s)
 func New(token string, opts ...bot.ConfigOpt) (bot.Client, error) {
-	config := bot.DefaultConfig(handlers.GetGatewayHandlers(), handlers.GetHTTPServerHandler())
+	config := bot.DefaultConfig(handlers.GetGatewayHandlers(), handlers.GetHTTPServerHandler(), handlers.GetWebhookEventHandler())
 	config.Apply(opts)
 
 	return bot.BuildClient(token,
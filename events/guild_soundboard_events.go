@@ -0,0 +1,47 @@
+package events
+
+import (
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+// GenericGuildSoundboardSound is called upon receiving GuildSoundboardSoundCreate or GuildSoundboardSoundUpdate (requires gateway.IntentGuildEmojisAndStickers)
+type GenericGuildSoundboardSound struct {
+	*GenericEvent
+	discord.SoundboardSound
+}
+
+// GuildSoundboardSoundCreate indicates that a discord.SoundboardSound got created in a discord.Guild (requires gateway.IntentGuildEmojisAndStickers)
+type GuildSoundboardSoundCreate struct {
+	*GenericGuildSoundboardSound
+}
+
+// GuildSoundboardSoundUpdate indicates that a discord.SoundboardSound got updated in a discord.Guild (requires gateway.IntentGuildEmojisAndStickers)
+type GuildSoundboardSoundUpdate struct {
+	*GenericGuildSoundboardSound
+	OldGuildSoundboardSound discord.SoundboardSound
+}
+
+// GuildSoundboardSoundDelete indicates that a discord.SoundboardSound got deleted in a discord.Guild (requires gateway.IntentGuildEmojisAndStickers)
+type GuildSoundboardSoundDelete struct {
+	*GenericEvent
+	SoundID snowflake.ID
+	GuildID snowflake.ID
+	// OldGuildSoundboardSound is the deleted discord.SoundboardSound if it was cached
+	OldGuildSoundboardSound discord.SoundboardSound
+}
+
+// GuildSoundboardSoundsUpdate indicates that multiple discord.SoundboardSound(s) got updated in a discord.Guild (requires gateway.IntentGuildEmojisAndStickers)
+type GuildSoundboardSoundsUpdate struct {
+	*GenericEvent
+	SoundboardSounds []discord.SoundboardSound
+	GuildID          snowflake.ID
+}
+
+// SoundboardSounds is the response to bot.Client.RequestSoundboardSounds
+type SoundboardSounds struct {
+	*GenericEvent
+	SoundboardSounds []discord.SoundboardSound
+	GuildID          snowflake.ID
+}
@@ -40,3 +40,9 @@ type VoiceServerUpdate struct {
 	*GenericEvent
 	gateway.EventVoiceServerUpdate
 }
+
+// GuildVoiceChannelEffectSend indicates that someone sent an effect (emoji reaction or soundboard sound) in a voice channel the bot is connected to
+type GuildVoiceChannelEffectSend struct {
+	*GenericEvent
+	gateway.EventVoiceChannelEffectSend
+}
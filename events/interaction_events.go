@@ -8,11 +8,16 @@ import (
 // InteractionResponderFunc is a function that can be used to respond to a discord.Interaction.
 type InteractionResponderFunc func(responseType discord.InteractionResponseType, data discord.InteractionResponseData, opts ...rest.RequestOpt) error
 
+// InteractionCallbackResponderFunc is a function that can be used to respond to a discord.Interaction and receive the resource created by the response.
+// If the interaction was received over the httpserver, the response is sent in the http response body & the created message is fetched afterwards via rest.Interactions.GetInteractionResponse.
+type InteractionCallbackResponderFunc func(responseType discord.InteractionResponseType, data discord.InteractionResponseData, opts ...rest.RequestOpt) (*discord.InteractionCallbackResponse, error)
+
 // InteractionCreate indicates that a new interaction has been created.
 type InteractionCreate struct {
 	*GenericEvent
 	discord.Interaction
-	Respond InteractionResponderFunc
+	Respond             InteractionResponderFunc
+	RespondWithCallback InteractionCallbackResponderFunc
 }
 
 // Guild returns the guild that the interaction happened in if it happened in a guild.
@@ -29,7 +34,8 @@ func (e *InteractionCreate) Guild() (discord.Guild, bool) {
 type ApplicationCommandInteractionCreate struct {
 	*GenericEvent
 	discord.ApplicationCommandInteraction
-	Respond InteractionResponderFunc
+	Respond             InteractionResponderFunc
+	RespondWithCallback InteractionCallbackResponderFunc
 }
 
 // Guild returns the guild that the interaction happened in if it happened in a guild.
@@ -70,7 +76,8 @@ func (e *ApplicationCommandInteractionCreate) PremiumRequired(opts ...rest.Reque
 type ComponentInteractionCreate struct {
 	*GenericEvent
 	discord.ComponentInteraction
-	Respond InteractionResponderFunc
+	Respond             InteractionResponderFunc
+	RespondWithCallback InteractionCallbackResponderFunc
 }
 
 // Guild returns the guild that the interaction happened in if it happened in a guild.
@@ -121,7 +128,8 @@ func (e *ComponentInteractionCreate) PremiumRequired(opts ...rest.RequestOpt) er
 type AutocompleteInteractionCreate struct {
 	*GenericEvent
 	discord.AutocompleteInteraction
-	Respond InteractionResponderFunc
+	Respond             InteractionResponderFunc
+	RespondWithCallback InteractionCallbackResponderFunc
 }
 
 // Guild returns the guild that the interaction happened in if it happened in a guild.
@@ -143,7 +151,8 @@ func (e *AutocompleteInteractionCreate) AutocompleteResult(choices []discord.Aut
 type ModalSubmitInteractionCreate struct {
 	*GenericEvent
 	discord.ModalSubmitInteraction
-	Respond InteractionResponderFunc
+	Respond             InteractionResponderFunc
+	RespondWithCallback InteractionCallbackResponderFunc
 }
 
 // Guild returns the guild that the interaction happened in if it happened in a guild.
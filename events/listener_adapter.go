@@ -69,6 +69,16 @@ type ListenerAdapter struct {
 	OnEntitlementUpdate func(event *EntitlementUpdate)
 	OnEntitlementDelete func(event *EntitlementDelete)
 
+	// Webhook Events
+	OnApplicationAuthorized   func(event *ApplicationAuthorized)
+	OnApplicationDeauthorized func(event *ApplicationDeauthorized)
+	OnQuestUserEnrollment     func(event *QuestUserEnrollment)
+
+	// Subscription Events
+	OnSubscriptionCreate func(event *SubscriptionCreate)
+	OnSubscriptionUpdate func(event *SubscriptionUpdate)
+	OnSubscriptionDelete func(event *SubscriptionDelete)
+
 	// Sticker Events
 	OnStickersUpdate func(event *StickersUpdate)
 	OnStickerCreate  func(event *StickerCreate)
@@ -118,6 +128,15 @@ type ListenerAdapter struct {
 	OnGuildVoiceMove        func(event *GuildVoiceMove)
 	OnGuildVoiceLeave       func(event *GuildVoiceLeave)
 
+	OnGuildVoiceChannelEffectSend func(event *GuildVoiceChannelEffectSend)
+
+	// Guild Soundboard Events
+	OnGuildSoundboardSoundCreate  func(event *GuildSoundboardSoundCreate)
+	OnGuildSoundboardSoundUpdate  func(event *GuildSoundboardSoundUpdate)
+	OnGuildSoundboardSoundDelete  func(event *GuildSoundboardSoundDelete)
+	OnGuildSoundboardSoundsUpdate func(event *GuildSoundboardSoundsUpdate)
+	OnSoundboardSounds            func(event *SoundboardSounds)
+
 	// Guild StageInstance Events
 	OnStageInstanceCreate func(event *StageInstanceCreate)
 	OnStageInstanceUpdate func(event *StageInstanceUpdate)
@@ -352,6 +371,34 @@ func (l *ListenerAdapter) OnEvent(event bot.Event) {
 			listener(e)
 		}
 
+	// Webhook Events
+	case *ApplicationAuthorized:
+		if listener := l.OnApplicationAuthorized; listener != nil {
+			listener(e)
+		}
+	case *ApplicationDeauthorized:
+		if listener := l.OnApplicationDeauthorized; listener != nil {
+			listener(e)
+		}
+	case *QuestUserEnrollment:
+		if listener := l.OnQuestUserEnrollment; listener != nil {
+			listener(e)
+		}
+
+	// Subscription Events
+	case *SubscriptionCreate:
+		if listener := l.OnSubscriptionCreate; listener != nil {
+			listener(e)
+		}
+	case *SubscriptionUpdate:
+		if listener := l.OnSubscriptionUpdate; listener != nil {
+			listener(e)
+		}
+	case *SubscriptionDelete:
+		if listener := l.OnSubscriptionDelete; listener != nil {
+			listener(e)
+		}
+
 	// Sticker Events
 	case *StickersUpdate:
 		if listener := l.OnStickersUpdate; listener != nil {
@@ -500,6 +547,33 @@ func (l *ListenerAdapter) OnEvent(event bot.Event) {
 			listener(e)
 		}
 
+	case *GuildVoiceChannelEffectSend:
+		if listener := l.OnGuildVoiceChannelEffectSend; listener != nil {
+			listener(e)
+		}
+
+	// Guild Soundboard Events
+	case *GuildSoundboardSoundCreate:
+		if listener := l.OnGuildSoundboardSoundCreate; listener != nil {
+			listener(e)
+		}
+	case *GuildSoundboardSoundUpdate:
+		if listener := l.OnGuildSoundboardSoundUpdate; listener != nil {
+			listener(e)
+		}
+	case *GuildSoundboardSoundDelete:
+		if listener := l.OnGuildSoundboardSoundDelete; listener != nil {
+			listener(e)
+		}
+	case *GuildSoundboardSoundsUpdate:
+		if listener := l.OnGuildSoundboardSoundsUpdate; listener != nil {
+			listener(e)
+		}
+	case *SoundboardSounds:
+		if listener := l.OnSoundboardSounds; listener != nil {
+			listener(e)
+		}
+
 	// Guild StageInstance Events
 	case *StageInstanceCreate:
 		if listener := l.OnStageInstanceCreate; listener != nil {
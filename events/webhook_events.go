@@ -0,0 +1,38 @@
+package events
+
+import (
+	"time"
+
+	"github.com/disgoorg/json"
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+// GenericWebhookEvent is called upon receiving any event over the webhook events endpoint of the httpserver.Server
+type GenericWebhookEvent struct {
+	*GenericEvent
+	ApplicationID snowflake.ID
+	Timestamp     time.Time
+}
+
+// ApplicationAuthorized indicates that the application was added to a guild or user account
+type ApplicationAuthorized struct {
+	*GenericWebhookEvent
+	IntegrationType *discord.ApplicationIntegrationType
+	User            discord.User
+	Scopes          []discord.OAuth2Scope
+	Guild           *discord.Guild
+}
+
+// ApplicationDeauthorized indicates that the application was deauthorized by a user
+type ApplicationDeauthorized struct {
+	*GenericWebhookEvent
+	User discord.User
+}
+
+// QuestUserEnrollment indicates that a user enrolled in a quest. The Data is not documented by Discord yet.
+type QuestUserEnrollment struct {
+	*GenericWebhookEvent
+	Data json.RawMessage
+}
@@ -22,6 +22,9 @@ const (
 	EventTypeEntitlementCreate                   EventType = "ENTITLEMENT_CREATE"
 	EventTypeEntitlementUpdate                   EventType = "ENTITLEMENT_UPDATE"
 	EventTypeEntitlementDelete                   EventType = "ENTITLEMENT_DELETE"
+	EventTypeSubscriptionCreate                  EventType = "SUBSCRIPTION_CREATE"
+	EventTypeSubscriptionUpdate                  EventType = "SUBSCRIPTION_UPDATE"
+	EventTypeSubscriptionDelete                  EventType = "SUBSCRIPTION_DELETE"
 	EventTypeThreadCreate                        EventType = "THREAD_CREATE"
 	EventTypeThreadUpdate                        EventType = "THREAD_UPDATE"
 	EventTypeThreadDelete                        EventType = "THREAD_DELETE"
@@ -49,6 +52,11 @@ const (
 	EventTypeGuildScheduledEventDelete           EventType = "GUILD_SCHEDULED_EVENT_DELETE"
 	EventTypeGuildScheduledEventUserAdd          EventType = "GUILD_SCHEDULED_EVENT_USER_ADD"
 	EventTypeGuildScheduledEventUserRemove       EventType = "GUILD_SCHEDULED_EVENT_USER_REMOVE"
+	EventTypeGuildSoundboardSoundCreate          EventType = "GUILD_SOUNDBOARD_SOUND_CREATE"
+	EventTypeGuildSoundboardSoundUpdate          EventType = "GUILD_SOUNDBOARD_SOUND_UPDATE"
+	EventTypeGuildSoundboardSoundDelete          EventType = "GUILD_SOUNDBOARD_SOUND_DELETE"
+	EventTypeGuildSoundboardSoundsUpdate         EventType = "GUILD_SOUNDBOARD_SOUNDS_UPDATE"
+	EventTypeSoundboardSounds                    EventType = "SOUNDBOARD_SOUNDS"
 	EventTypeIntegrationCreate                   EventType = "INTEGRATION_CREATE"
 	EventTypeIntegrationUpdate                   EventType = "INTEGRATION_UPDATE"
 	EventTypeIntegrationDelete                   EventType = "INTEGRATION_DELETE"
@@ -73,5 +81,6 @@ const (
 	EventTypeUserUpdate                          EventType = "USER_UPDATE"
 	EventTypeVoiceStateUpdate                    EventType = "VOICE_STATE_UPDATE"
 	EventTypeVoiceServerUpdate                   EventType = "VOICE_SERVER_UPDATE"
+	EventTypeVoiceChannelEffectSend              EventType = "VOICE_CHANNEL_EFFECT_SEND"
 	EventTypeWebhooksUpdate                      EventType = "WEBHOOKS_UPDATE"
 )
@@ -2,6 +2,7 @@ package gateway
 
 import (
 	"io"
+	"strings"
 	"time"
 
 	"github.com/disgoorg/json"
@@ -441,6 +442,44 @@ type EventGuildScheduledEventUserRemove struct {
 func (EventGuildScheduledEventUserRemove) messageData() {}
 func (EventGuildScheduledEventUserRemove) eventData()   {}
 
+type EventGuildSoundboardSoundCreate struct {
+	discord.SoundboardSound
+}
+
+func (EventGuildSoundboardSoundCreate) messageData() {}
+func (EventGuildSoundboardSoundCreate) eventData()   {}
+
+type EventGuildSoundboardSoundUpdate struct {
+	discord.SoundboardSound
+}
+
+func (EventGuildSoundboardSoundUpdate) messageData() {}
+func (EventGuildSoundboardSoundUpdate) eventData()   {}
+
+type EventGuildSoundboardSoundDelete struct {
+	SoundID snowflake.ID `json:"sound_id"`
+	GuildID snowflake.ID `json:"guild_id"`
+}
+
+func (EventGuildSoundboardSoundDelete) messageData() {}
+func (EventGuildSoundboardSoundDelete) eventData()   {}
+
+type EventGuildSoundboardSoundsUpdate struct {
+	SoundboardSounds []discord.SoundboardSound `json:"soundboard_sounds"`
+	GuildID          snowflake.ID              `json:"guild_id"`
+}
+
+func (EventGuildSoundboardSoundsUpdate) messageData() {}
+func (EventGuildSoundboardSoundsUpdate) eventData()   {}
+
+type EventSoundboardSounds struct {
+	SoundboardSounds []discord.SoundboardSound `json:"soundboard_sounds"`
+	GuildID          snowflake.ID              `json:"guild_id"`
+}
+
+func (EventSoundboardSounds) messageData() {}
+func (EventSoundboardSounds) eventData()   {}
+
 type EventInteractionCreate struct {
 	discord.Interaction
 }
@@ -620,6 +659,42 @@ type EventVoiceServerUpdate struct {
 func (EventVoiceServerUpdate) messageData() {}
 func (EventVoiceServerUpdate) eventData()   {}
 
+type EventVoiceChannelEffectSend struct {
+	ChannelID     snowflake.ID                             `json:"channel_id"`
+	GuildID       snowflake.ID                             `json:"guild_id"`
+	UserID        snowflake.ID                             `json:"user_id"`
+	Emoji         *discord.Emoji                           `json:"emoji,omitempty"`
+	AnimationType *discord.VoiceChannelEffectAnimationType `json:"animation_type,omitempty"`
+	AnimationID   *int                                     `json:"animation_id,omitempty"`
+	SoundID       *snowflake.ID                            `json:"sound_id,omitempty"`
+	SoundVolume   *float64                                 `json:"sound_volume,omitempty"`
+}
+
+func (e *EventVoiceChannelEffectSend) UnmarshalJSON(data []byte) error {
+	type eventVoiceChannelEffectSend EventVoiceChannelEffectSend
+	var v struct {
+		SoundID json.RawMessage `json:"sound_id,omitempty"`
+		eventVoiceChannelEffectSend
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*e = EventVoiceChannelEffectSend(v.eventVoiceChannelEffectSend)
+
+	// default sounds use an integer instead of a snowflake string
+	if len(v.SoundID) > 0 && string(v.SoundID) != "null" {
+		soundID, err := snowflake.Parse(strings.Trim(string(v.SoundID), `"`))
+		if err != nil {
+			return err
+		}
+		e.SoundID = &soundID
+	}
+	return nil
+}
+
+func (EventVoiceChannelEffectSend) messageData() {}
+func (EventVoiceChannelEffectSend) eventData()   {}
+
 type EventWebhooksUpdate struct {
 	GuildID   snowflake.ID `json:"guild_id"`
 	ChannelID snowflake.ID `json:"channel_id"`
@@ -761,3 +836,24 @@ type EventEntitlementDelete struct {
 
 func (EventEntitlementDelete) messageData() {}
 func (EventEntitlementDelete) eventData()   {}
+
+type EventSubscriptionCreate struct {
+	discord.Subscription
+}
+
+func (EventSubscriptionCreate) messageData() {}
+func (EventSubscriptionCreate) eventData()   {}
+
+type EventSubscriptionUpdate struct {
+	discord.Subscription
+}
+
+func (EventSubscriptionUpdate) messageData() {}
+func (EventSubscriptionUpdate) eventData()   {}
+
+type EventSubscriptionDelete struct {
+	discord.Subscription
+}
+
+func (EventSubscriptionDelete) messageData() {}
+func (EventSubscriptionDelete) eventData()   {}
@@ -82,6 +82,11 @@ func (e *Message) UnmarshalJSON(data []byte) error {
 
 	case OpcodeHeartbeatACK:
 
+	case OpcodeRequestSoundboardSounds:
+		var d MessageDataRequestSoundboardSounds
+		err = json.Unmarshal(v.D, &d)
+		messageData = d
+
 	default:
 		var d MessageDataUnknown
 		err = json.Unmarshal(v.D, &d)
@@ -176,6 +181,21 @@ func UnmarshalEventData(data []byte, eventType EventType) (EventData, error) {
 		err = json.Unmarshal(data, &d)
 		eventData = d
 
+	case EventTypeSubscriptionCreate:
+		var d EventSubscriptionCreate
+		err = json.Unmarshal(data, &d)
+		eventData = d
+
+	case EventTypeSubscriptionUpdate:
+		var d EventSubscriptionUpdate
+		err = json.Unmarshal(data, &d)
+		eventData = d
+
+	case EventTypeSubscriptionDelete:
+		var d EventSubscriptionDelete
+		err = json.Unmarshal(data, &d)
+		eventData = d
+
 	case EventTypeThreadCreate:
 		var d EventThreadCreate
 		err = json.Unmarshal(data, &d)
@@ -311,6 +331,31 @@ func UnmarshalEventData(data []byte, eventType EventType) (EventData, error) {
 		err = json.Unmarshal(data, &d)
 		eventData = d
 
+	case EventTypeGuildSoundboardSoundCreate:
+		var d EventGuildSoundboardSoundCreate
+		err = json.Unmarshal(data, &d)
+		eventData = d
+
+	case EventTypeGuildSoundboardSoundUpdate:
+		var d EventGuildSoundboardSoundUpdate
+		err = json.Unmarshal(data, &d)
+		eventData = d
+
+	case EventTypeGuildSoundboardSoundDelete:
+		var d EventGuildSoundboardSoundDelete
+		err = json.Unmarshal(data, &d)
+		eventData = d
+
+	case EventTypeGuildSoundboardSoundsUpdate:
+		var d EventGuildSoundboardSoundsUpdate
+		err = json.Unmarshal(data, &d)
+		eventData = d
+
+	case EventTypeSoundboardSounds:
+		var d EventSoundboardSounds
+		err = json.Unmarshal(data, &d)
+		eventData = d
+
 	case EventTypeIntegrationCreate:
 		var d EventIntegrationCreate
 		err = json.Unmarshal(data, &d)
@@ -421,6 +466,11 @@ func UnmarshalEventData(data []byte, eventType EventType) (EventData, error) {
 		err = json.Unmarshal(data, &d)
 		eventData = d
 
+	case EventTypeVoiceChannelEffectSend:
+		var d EventVoiceChannelEffectSend
+		err = json.Unmarshal(data, &d)
+		eventData = d
+
 	case EventTypeWebhooksUpdate:
 		var d EventWebhooksUpdate
 		err = json.Unmarshal(data, &d)
@@ -607,6 +657,14 @@ type MessageDataRequestGuildMembers struct {
 
 func (MessageDataRequestGuildMembers) messageData() {}
 
+// MessageDataRequestSoundboardSounds is used to request the discord.SoundboardSound(s) of the given guilds.
+// Discord responds with an EventSoundboardSounds for each guild.
+type MessageDataRequestSoundboardSounds struct {
+	GuildIDs []snowflake.ID `json:"guild_ids"`
+}
+
+func (MessageDataRequestSoundboardSounds) messageData() {}
+
 type MessageDataInvalidSession bool
 
 func (MessageDataInvalidSession) messageData() {}
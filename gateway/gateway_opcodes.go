@@ -19,6 +19,10 @@ const (
 	OpcodeHeartbeatACK
 )
 
+const (
+	OpcodeRequestSoundboardSounds Opcode = 31
+)
+
 type CloseEventCode struct {
 	Code        int
 	Description string
@@ -83,6 +83,7 @@ func (h *handlerHolder[T]) Handle(path string, event *InteractionEvent) error {
 				GenericEvent:                  event.GenericEvent,
 				ApplicationCommandInteraction: event.Interaction.(discord.ApplicationCommandInteraction),
 				Respond:                       event.Respond,
+				RespondWithCallback:           event.RespondWithCallback,
 			},
 			Vars: event.Vars,
 			Ctx:  event.Ctx,
@@ -94,6 +95,7 @@ func (h *handlerHolder[T]) Handle(path string, event *InteractionEvent) error {
 				GenericEvent:                  event.GenericEvent,
 				ApplicationCommandInteraction: commandInteraction,
 				Respond:                       event.Respond,
+				RespondWithCallback:           event.RespondWithCallback,
 			},
 			Vars: event.Vars,
 			Ctx:  event.Ctx,
@@ -105,6 +107,7 @@ func (h *handlerHolder[T]) Handle(path string, event *InteractionEvent) error {
 				GenericEvent:                  event.GenericEvent,
 				ApplicationCommandInteraction: commandInteraction,
 				Respond:                       event.Respond,
+				RespondWithCallback:           event.RespondWithCallback,
 			},
 			Vars: event.Vars,
 			Ctx:  event.Ctx,
@@ -116,6 +119,7 @@ func (h *handlerHolder[T]) Handle(path string, event *InteractionEvent) error {
 				GenericEvent:                  event.GenericEvent,
 				ApplicationCommandInteraction: commandInteraction,
 				Respond:                       event.Respond,
+				RespondWithCallback:           event.RespondWithCallback,
 			},
 			Vars: event.Vars,
 			Ctx:  event.Ctx,
@@ -126,6 +130,7 @@ func (h *handlerHolder[T]) Handle(path string, event *InteractionEvent) error {
 				GenericEvent:            event.GenericEvent,
 				AutocompleteInteraction: event.Interaction.(discord.AutocompleteInteraction),
 				Respond:                 event.Respond,
+				RespondWithCallback:     event.RespondWithCallback,
 			},
 			Vars: event.Vars,
 			Ctx:  event.Ctx,
@@ -136,6 +141,7 @@ func (h *handlerHolder[T]) Handle(path string, event *InteractionEvent) error {
 				GenericEvent:         event.GenericEvent,
 				ComponentInteraction: event.Interaction.(discord.ComponentInteraction),
 				Respond:              event.Respond,
+				RespondWithCallback:  event.RespondWithCallback,
 			},
 			Vars: event.Vars,
 			Ctx:  event.Ctx,
@@ -147,6 +153,7 @@ func (h *handlerHolder[T]) Handle(path string, event *InteractionEvent) error {
 				GenericEvent:         event.GenericEvent,
 				ComponentInteraction: componentInteraction,
 				Respond:              event.Respond,
+				RespondWithCallback:  event.RespondWithCallback,
 			},
 			Vars: event.Vars,
 			Ctx:  event.Ctx,
@@ -158,6 +165,7 @@ func (h *handlerHolder[T]) Handle(path string, event *InteractionEvent) error {
 				GenericEvent:         event.GenericEvent,
 				ComponentInteraction: componentInteraction,
 				Respond:              event.Respond,
+				RespondWithCallback:  event.RespondWithCallback,
 			},
 			Vars: event.Vars,
 			Ctx:  event.Ctx,
@@ -168,6 +176,7 @@ func (h *handlerHolder[T]) Handle(path string, event *InteractionEvent) error {
 				GenericEvent:           event.GenericEvent,
 				ModalSubmitInteraction: event.Interaction.(discord.ModalSubmitInteraction),
 				Respond:                event.Respond,
+				RespondWithCallback:    event.RespondWithCallback,
 			},
 			Vars: event.Vars,
 			Ctx:  event.Ctx,
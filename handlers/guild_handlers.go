@@ -58,6 +58,11 @@ func gatewayHandlerGuildCreate(client bot.Client, sequenceNumber int, shardID in
 		client.Caches().AddGuildScheduledEvent(guildScheduledEvent)
 	}
 
+	for _, soundboardSound := range event.SoundboardSounds {
+		soundboardSound.GuildID = &event.ID // populate unset field
+		client.Caches().AddSoundboardSound(soundboardSound)
+	}
+
 	for _, presence := range event.Presences {
 		presence.GuildID = event.ID // populate unset field
 		client.Caches().AddPresence(presence)
@@ -130,6 +135,7 @@ func gatewayHandlerGuildDelete(client bot.Client, sequenceNumber int, shardID in
 	client.Caches().RemoveMembersByGuildID(event.ID)
 	client.Caches().RemoveStageInstancesByGuildID(event.ID)
 	client.Caches().RemoveGuildScheduledEventsByGuildID(event.ID)
+	client.Caches().RemoveSoundboardSoundsByGuildID(event.ID)
 	client.Caches().RemoveMessagesByGuildID(event.ID)
 
 	if event.Unavailable {
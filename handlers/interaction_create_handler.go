@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
 
@@ -29,13 +30,65 @@ func respond(client bot.Client, respondFunc httpserver.RespondFunc, interaction
 	}
 }
 
+func respondWithCallback(client bot.Client, respondFunc httpserver.RespondFunc, interaction discord.Interaction) events.InteractionCallbackResponderFunc {
+	return func(responseType discord.InteractionResponseType, data discord.InteractionResponseData, opts ...rest.RequestOpt) (*discord.InteractionCallbackResponse, error) {
+		response := discord.InteractionResponse{
+			Type: responseType,
+			Data: data,
+		}
+		if respondFunc != nil {
+			if err := respondFunc(response); err != nil {
+				return nil, err
+			}
+			return httpInteractionCallbackResponse(client, interaction, responseType, opts...)
+		}
+		return client.Rest().CreateInteractionResponseWithCallback(interaction.ID(), interaction.Token(), response, opts...)
+	}
+}
+
+// httpInteractionCallbackResponse builds the discord.InteractionCallbackResponse for interactions responded to via the httpserver.
+// Discord doesn't return it in this case, so the created message is fetched via rest.Interactions.GetInteractionResponse.
+func httpInteractionCallbackResponse(client bot.Client, interaction discord.Interaction, responseType discord.InteractionResponseType, opts ...rest.RequestOpt) (*discord.InteractionCallbackResponse, error) {
+	callbackResponse := &discord.InteractionCallbackResponse{
+		Interaction: discord.InteractionCallback{
+			ID:   interaction.ID(),
+			Type: interaction.Type(),
+		},
+	}
+
+	switch responseType {
+	case discord.InteractionResponseTypeCreateMessage, discord.InteractionResponseTypeDeferredCreateMessage, discord.InteractionResponseTypeDeferredUpdateMessage, discord.InteractionResponseTypeUpdateMessage:
+		message, err := client.Rest().GetInteractionResponse(interaction.ApplicationID(), interaction.Token(), opts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get interaction response: %w", err)
+		}
+		loading := message.Flags.Has(discord.MessageFlagLoading)
+		ephemeral := message.Flags.Has(discord.MessageFlagEphemeral)
+		callbackResponse.Interaction.ResponseMessageID = &message.ID
+		callbackResponse.Interaction.ResponseMessageLoading = &loading
+		callbackResponse.Interaction.ResponseMessageEphemeral = &ephemeral
+		callbackResponse.Resource = &discord.InteractionCallbackResource{
+			Type:    responseType,
+			Message: message,
+		}
+	case discord.InteractionResponseTypeLaunchActivity:
+		return nil, errors.New("the activity instance of an interaction responded to via the httpserver can't be retrieved")
+	default:
+		callbackResponse.Resource = &discord.InteractionCallbackResource{
+			Type: responseType,
+		}
+	}
+	return callbackResponse, nil
+}
+
 func handleInteraction(client bot.Client, sequenceNumber int, shardID int, respondFunc httpserver.RespondFunc, interaction discord.Interaction) {
 	genericEvent := events.NewGenericEvent(client, sequenceNumber, shardID)
 
 	client.EventManager().DispatchEvent(&events.InteractionCreate{
-		GenericEvent: genericEvent,
-		Interaction:  interaction,
-		Respond:      respond(client, respondFunc, interaction),
+		GenericEvent:        genericEvent,
+		Interaction:         interaction,
+		Respond:             respond(client, respondFunc, interaction),
+		RespondWithCallback: respondWithCallback(client, respondFunc, interaction),
 	})
 
 	switch i := interaction.(type) {
@@ -44,6 +97,7 @@ func handleInteraction(client bot.Client, sequenceNumber int, shardID int, respo
 			GenericEvent:                  genericEvent,
 			ApplicationCommandInteraction: i,
 			Respond:                       respond(client, respondFunc, interaction),
+			RespondWithCallback:           respondWithCallback(client, respondFunc, interaction),
 		})
 
 	case discord.ComponentInteraction:
@@ -51,6 +105,7 @@ func handleInteraction(client bot.Client, sequenceNumber int, shardID int, respo
 			GenericEvent:         genericEvent,
 			ComponentInteraction: i,
 			Respond:              respond(client, respondFunc, interaction),
+			RespondWithCallback:  respondWithCallback(client, respondFunc, interaction),
 		})
 
 	case discord.AutocompleteInteraction:
@@ -58,6 +113,7 @@ func handleInteraction(client bot.Client, sequenceNumber int, shardID int, respo
 			GenericEvent:            genericEvent,
 			AutocompleteInteraction: i,
 			Respond:                 respond(client, respondFunc, interaction),
+			RespondWithCallback:     respondWithCallback(client, respondFunc, interaction),
 		})
 
 	case discord.ModalSubmitInteraction:
@@ -65,6 +121,7 @@ func handleInteraction(client bot.Client, sequenceNumber int, shardID int, respo
 			GenericEvent:           genericEvent,
 			ModalSubmitInteraction: i,
 			Respond:                respond(client, respondFunc, interaction),
+			RespondWithCallback:    respondWithCallback(client, respondFunc, interaction),
 		})
 
 	default:
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/disgoorg/disgo/bot"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/rest"
+)
+
+func TestRespondWithCallback_HTTPServer(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"5","channel_id":"4","content":"pong","flags":64}`))
+	}))
+	defer server.Close()
+
+	cfg := bot.DefaultConfig(GetGatewayHandlers(), GetHTTPServerHandler(), GetWebhookEventHandler())
+	cfg.RestClientConfigOpts = []rest.ConfigOpt{rest.WithURL(server.URL)}
+	client, err := bot.BuildClient(base64.RawStdEncoding.EncodeToString([]byte("1"))+".token", cfg, nil, nil, "", "", "", "")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	interaction, err := discord.UnmarshalInteraction([]byte(`{"id":"2","application_id":"1","type":2,"token":"token","version":1,"channel_id":"4","user":{"id":"3","username":"test"},"data":{"id":"1","name":"test","type":1}}`))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	var responses []discord.InteractionResponse
+	respondFunc := func(response discord.InteractionResponse) error {
+		responses = append(responses, response)
+		return nil
+	}
+
+	callbackResponse, err := respondWithCallback(client, respondFunc, interaction)(discord.InteractionResponseTypeCreateMessage, discord.MessageCreate{Content: "pong"})
+	if !assert.NoError(t, err) || !assert.NotNil(t, callbackResponse) {
+		return
+	}
+	assert.Len(t, responses, 1)
+	assert.Equal(t, []string{http.MethodGet + " /webhooks/1/token/messages/@original"}, requests)
+	assert.Equal(t, snowflake.ID(2), callbackResponse.Interaction.ID)
+	assert.Equal(t, discord.InteractionTypeApplicationCommand, callbackResponse.Interaction.Type)
+	assert.Equal(t, snowflake.ID(5), *callbackResponse.Interaction.ResponseMessageID)
+	assert.True(t, *callbackResponse.Interaction.ResponseMessageEphemeral)
+	assert.False(t, *callbackResponse.Interaction.ResponseMessageLoading)
+	if assert.NotNil(t, callbackResponse.Resource) && assert.NotNil(t, callbackResponse.Resource.Message) {
+		assert.Equal(t, discord.InteractionResponseTypeCreateMessage, callbackResponse.Resource.Type)
+		assert.Equal(t, "pong", callbackResponse.Resource.Message.Content)
+	}
+
+	// responses without a message don't need to be fetched
+	requests = nil
+	callbackResponse, err = respondWithCallback(client, respondFunc, interaction)(discord.InteractionResponseTypeModal, discord.ModalCreate{CustomID: "modal"})
+	assert.NoError(t, err)
+	assert.Empty(t, requests)
+	if assert.NotNil(t, callbackResponse) && assert.NotNil(t, callbackResponse.Resource) {
+		assert.Equal(t, discord.InteractionResponseTypeModal, callbackResponse.Resource.Type)
+		assert.Nil(t, callbackResponse.Resource.Message)
+	}
+
+	// errors of the httpserver are returned as is
+	_, err = respondWithCallback(client, func(discord.InteractionResponse) error {
+		return discord.ErrInteractionAlreadyReplied
+	}, interaction)(discord.InteractionResponseTypeCreateMessage, discord.MessageCreate{Content: "pong"})
+	assert.ErrorIs(t, err, discord.ErrInteractionAlreadyReplied)
+	assert.Empty(t, requests)
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"github.com/disgoorg/disgo/bot"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/disgo/gateway"
+)
+
+func gatewayHandlerGuildSoundboardSoundCreate(client bot.Client, sequenceNumber int, shardID int, event gateway.EventGuildSoundboardSoundCreate) {
+	client.Caches().AddSoundboardSound(event.SoundboardSound)
+
+	client.EventManager().DispatchEvent(&events.GuildSoundboardSoundCreate{
+		GenericGuildSoundboardSound: &events.GenericGuildSoundboardSound{
+			GenericEvent:    events.NewGenericEvent(client, sequenceNumber, shardID),
+			SoundboardSound: event.SoundboardSound,
+		},
+	})
+}
+
+func gatewayHandlerGuildSoundboardSoundUpdate(client bot.Client, sequenceNumber int, shardID int, event gateway.EventGuildSoundboardSoundUpdate) {
+	var oldSound discord.SoundboardSound
+	if event.GuildID != nil {
+		oldSound, _ = client.Caches().SoundboardSound(*event.GuildID, event.SoundID)
+	}
+	client.Caches().AddSoundboardSound(event.SoundboardSound)
+
+	client.EventManager().DispatchEvent(&events.GuildSoundboardSoundUpdate{
+		GenericGuildSoundboardSound: &events.GenericGuildSoundboardSound{
+			GenericEvent:    events.NewGenericEvent(client, sequenceNumber, shardID),
+			SoundboardSound: event.SoundboardSound,
+		},
+		OldGuildSoundboardSound: oldSound,
+	})
+}
+
+func gatewayHandlerGuildSoundboardSoundDelete(client bot.Client, sequenceNumber int, shardID int, event gateway.EventGuildSoundboardSoundDelete) {
+	oldSound, _ := client.Caches().RemoveSoundboardSound(event.GuildID, event.SoundID)
+
+	client.EventManager().DispatchEvent(&events.GuildSoundboardSoundDelete{
+		GenericEvent:            events.NewGenericEvent(client, sequenceNumber, shardID),
+		SoundID:                 event.SoundID,
+		GuildID:                 event.GuildID,
+		OldGuildSoundboardSound: oldSound,
+	})
+}
+
+func gatewayHandlerGuildSoundboardSoundsUpdate(client bot.Client, sequenceNumber int, shardID int, event gateway.EventGuildSoundboardSoundsUpdate) {
+	for i := range event.SoundboardSounds {
+		event.SoundboardSounds[i].GuildID = &event.GuildID // populate unset field
+		client.Caches().AddSoundboardSound(event.SoundboardSounds[i])
+	}
+
+	client.EventManager().DispatchEvent(&events.GuildSoundboardSoundsUpdate{
+		GenericEvent:     events.NewGenericEvent(client, sequenceNumber, shardID),
+		SoundboardSounds: event.SoundboardSounds,
+		GuildID:          event.GuildID,
+	})
+}
+
+func gatewayHandlerSoundboardSounds(client bot.Client, sequenceNumber int, shardID int, event gateway.EventSoundboardSounds) {
+	client.Caches().RemoveSoundboardSoundsByGuildID(event.GuildID)
+	for i := range event.SoundboardSounds {
+		event.SoundboardSounds[i].GuildID = &event.GuildID // populate unset field
+		client.Caches().AddSoundboardSound(event.SoundboardSounds[i])
+	}
+
+	client.EventManager().DispatchEvent(&events.SoundboardSounds{
+		GenericEvent:     events.NewGenericEvent(client, sequenceNumber, shardID),
+		SoundboardSounds: event.SoundboardSounds,
+		GuildID:          event.GuildID,
+	})
+}
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/disgoorg/disgo/bot"
+	"github.com/disgoorg/disgo/cache"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/disgo/gateway"
+)
+
+func newTestClient(t *testing.T) bot.Client {
+	cfg := bot.DefaultConfig(GetGatewayHandlers(), nil, nil)
+	cfg.CacheConfigOpts = []cache.ConfigOpt{cache.WithCaches(cache.FlagsAll)}
+	client, err := bot.BuildClient(base64.RawStdEncoding.EncodeToString([]byte("1"))+".token", cfg, nil, nil, "", "", "", "")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	return client
+}
+
+func TestGatewayHandlerSoundboardSounds(t *testing.T) {
+	client := newTestClient(t)
+	guildID := snowflake.ID(2)
+
+	var received *events.SoundboardSounds
+	client.AddEventListeners(bot.NewListenerFunc(func(e *events.SoundboardSounds) {
+		received = e
+	}))
+
+	// sounds in the SOUNDBOARD_SOUNDS event don't include the guild id
+	client.Caches().AddSoundboardSound(discord.SoundboardSound{SoundID: 3, GuildID: &guildID})
+	gatewayHandlerSoundboardSounds(client, 0, 0, gateway.EventSoundboardSounds{
+		GuildID: guildID,
+		SoundboardSounds: []discord.SoundboardSound{
+			{SoundID: 4},
+			{SoundID: 5},
+		},
+	})
+
+	assert.Equal(t, 2, client.Caches().SoundboardSoundsLen(guildID))
+	_, ok := client.Caches().SoundboardSound(guildID, 3)
+	assert.False(t, ok)
+	sound, ok := client.Caches().SoundboardSound(guildID, 4)
+	if assert.True(t, ok) && assert.NotNil(t, sound.GuildID) {
+		assert.Equal(t, guildID, *sound.GuildID)
+	}
+	if assert.NotNil(t, received) {
+		assert.Equal(t, guildID, *received.SoundboardSounds[1].GuildID)
+	}
+}
+
+func TestGatewayHandlerGuildSoundboardSoundsUpdate(t *testing.T) {
+	client := newTestClient(t)
+	guildID := snowflake.ID(2)
+
+	gatewayHandlerGuildSoundboardSoundsUpdate(client, 0, 0, gateway.EventGuildSoundboardSoundsUpdate{
+		GuildID: guildID,
+		SoundboardSounds: []discord.SoundboardSound{
+			{SoundID: 4, Name: "updated"},
+		},
+	})
+
+	sound, ok := client.Caches().SoundboardSound(guildID, 4)
+	assert.True(t, ok)
+	assert.Equal(t, "updated", sound.Name)
+}
@@ -61,3 +61,10 @@ func gatewayHandlerVoiceServerUpdate(client bot.Client, sequenceNumber int, shar
 		EventVoiceServerUpdate: event,
 	})
 }
+
+func gatewayHandlerVoiceChannelEffectSend(client bot.Client, sequenceNumber int, shardID int, event gateway.EventVoiceChannelEffectSend) {
+	client.EventManager().DispatchEvent(&events.GuildVoiceChannelEffectSend{
+		GenericEvent:                events.NewGenericEvent(client, sequenceNumber, shardID),
+		EventVoiceChannelEffectSend: event,
+	})
+}
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"log/slog"
+
+	"github.com/disgoorg/disgo/bot"
+	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/disgo/httpserver"
+)
+
+var _ bot.WebhookEventHandler = (*webhookEventHandler)(nil)
+
+type webhookEventHandler struct{}
+
+func (h *webhookEventHandler) HandleWebhookEvent(client bot.Client, event httpserver.WebhookEvent) {
+	genericEvent := events.NewGenericEvent(client, -1, -1)
+	genericWebhookEvent := &events.GenericWebhookEvent{
+		GenericEvent:  genericEvent,
+		ApplicationID: event.ApplicationID,
+		Timestamp:     event.Timestamp,
+	}
+
+	switch data := event.Data.(type) {
+	case httpserver.WebhookEventApplicationAuthorized:
+		client.EventManager().DispatchEvent(&events.ApplicationAuthorized{
+			GenericWebhookEvent: genericWebhookEvent,
+			IntegrationType:     data.IntegrationType,
+			User:                data.User,
+			Scopes:              data.Scopes,
+			Guild:               data.Guild,
+		})
+
+	case httpserver.WebhookEventApplicationDeauthorized:
+		client.EventManager().DispatchEvent(&events.ApplicationDeauthorized{
+			GenericWebhookEvent: genericWebhookEvent,
+			User:                data.User,
+		})
+
+	case httpserver.WebhookEventEntitlementCreate:
+		client.EventManager().DispatchEvent(&events.EntitlementCreate{
+			GenericEntitlementEvent: &events.GenericEntitlementEvent{
+				GenericEvent: genericEvent,
+				Entitlement:  data.Entitlement,
+			},
+		})
+
+	case httpserver.WebhookEventQuestUserEnrollment:
+		client.EventManager().DispatchEvent(&events.QuestUserEnrollment{
+			GenericWebhookEvent: genericWebhookEvent,
+			Data:                data.Data,
+		})
+
+	default:
+		client.Logger().Debug("unknown webhook event", slog.String("type", string(event.Type)))
+	}
+}
@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/disgoorg/disgo/bot"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/disgo/httpserver"
+)
+
+func TestWebhookEventHandler_DefaultConfig(t *testing.T) {
+	cfg := bot.DefaultConfig(GetGatewayHandlers(), GetHTTPServerHandler(), GetWebhookEventHandler())
+	client, err := bot.BuildClient(base64.RawStdEncoding.EncodeToString([]byte("1"))+".token", cfg, nil, nil, "", "", "", "")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	var received *events.ApplicationDeauthorized
+	client.AddEventListeners(bot.NewListenerFunc(func(e *events.ApplicationDeauthorized) {
+		received = e
+	}))
+
+	client.EventManager().HandleWebhookEvent(httpserver.WebhookEvent{
+		Type:          httpserver.WebhookEventTypeApplicationDeauthorized,
+		Data:          httpserver.WebhookEventApplicationDeauthorized{User: discord.User{ID: 2}},
+		ApplicationID: 1,
+	})
+	if assert.NotNil(t, received) {
+		assert.Equal(t, discord.User{ID: 2}, received.User)
+		assert.EqualValues(t, 1, received.ApplicationID)
+	}
+}
@@ -25,6 +25,17 @@ type Config struct {
 	Address    string
 	CertFile   string
 	KeyFile    string
+
+	VerifierConfigOpts []VerifierConfigOpt
+
+	// WebhookEventsURL is the path webhook events are received on. Webhook events are disabled if it's empty.
+	WebhookEventsURL        string
+	WebhookEventHandlerFunc WebhookEventHandlerFunc
+
+	// HealthURL is the path of the health endpoint. It's disabled if empty.
+	HealthURL string
+	// ReadinessURL is the path of the readiness endpoint. It's disabled if empty.
+	ReadinessURL string
 }
 
 // ConfigOpt is a type alias for a function that takes a Config and is used to configure your Server.
@@ -79,3 +90,38 @@ func WithTLS(certFile string, keyFile string) ConfigOpt {
 		config.KeyFile = keyFile
 	}
 }
+
+// WithWebhookEventsURL enables webhook events & sets the WebhookEventsURL of the Config.
+func WithWebhookEventsURL(url string) ConfigOpt {
+	return func(config *Config) {
+		config.WebhookEventsURL = url
+	}
+}
+
+// WithWebhookEventHandlerFunc sets the WebhookEventHandlerFunc of the Config.
+func WithWebhookEventHandlerFunc(handlerFunc WebhookEventHandlerFunc) ConfigOpt {
+	return func(config *Config) {
+		config.WebhookEventHandlerFunc = handlerFunc
+	}
+}
+
+// WithVerifierConfigOpts sets the VerifierConfigOpt(s) used to verify requests.
+func WithVerifierConfigOpts(opts ...VerifierConfigOpt) ConfigOpt {
+	return func(config *Config) {
+		config.VerifierConfigOpts = append(config.VerifierConfigOpts, opts...)
+	}
+}
+
+// WithHealthURL enables the health endpoint & sets the HealthURL of the Config.
+func WithHealthURL(url string) ConfigOpt {
+	return func(config *Config) {
+		config.HealthURL = url
+	}
+}
+
+// WithReadinessURL enables the readiness endpoint & sets the ReadinessURL of the Config.
+func WithReadinessURL(url string) ConfigOpt {
+	return func(config *Config) {
+		config.ReadinessURL = url
+	}
+}
@@ -0,0 +1,42 @@
+package httpserver
+
+import (
+	"context"
+	"net/http"
+	"sync"
+)
+
+// HandleHealth returns a http.HandlerFunc which always responds with 200 OK as long as the process is able to serve requests.
+func HandleHealth() http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		_, _ = w.Write([]byte("OK\n"))
+	}
+}
+
+// HandleReadiness returns a http.HandlerFunc which responds with 200 OK if ready returns true and 503 Service Unavailable otherwise.
+func HandleReadiness(ready func() bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		if !ready() {
+			http.Error(w, "Service Unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		_, _ = w.Write([]byte("OK\n"))
+	}
+}
+
+// waitInFlight waits until all tracked handlers returned or the context.Context is done.
+func waitInFlight(ctx context.Context, inFlight *sync.WaitGroup) error {
+	done := make(chan struct{})
+	go func() {
+		inFlight.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,321 @@
+package httpserver
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"sync/atomic"
+
+	"github.com/disgoorg/json"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// ErrApplicationAlreadyRegistered is returned when an Application with the same ID or Path is already registered on the MultiServer
+var ErrApplicationAlreadyRegistered = errors.New("application already registered")
+
+// Application is a single Discord application served by a MultiServer.
+type Application struct {
+	// ID is the ID of the application. Requests on the shared Config.URL & Config.WebhookEventsURL are routed by it.
+	ID snowflake.ID
+	// PublicKey is the hex encoded public key of the application.
+	PublicKey string
+	// Path is an optional dedicated path interactions of this application are received on.
+	Path string
+	// WebhookEventsPath is an optional dedicated path webhook events of this application are received on.
+	WebhookEventsPath string
+	// EventHandlerFunc handles the interactions of this application. Use bot.Client.EventManager().HandleHTTPEvent to pass them to a bot.Client.
+	EventHandlerFunc EventHandlerFunc
+	// WebhookEventHandlerFunc handles the webhook events of this application. Webhook events are ignored if it's nil.
+	WebhookEventHandlerFunc WebhookEventHandlerFunc
+	// VerifierConfigOpts are applied after the Config.VerifierConfigOpts to configure the RequestVerifier of this application.
+	// They also apply to requests on the shared Config.URL & Config.WebhookEventsURL once they are routed to this application.
+	VerifierConfigOpts []VerifierConfigOpt
+}
+
+// MultiServer is used for receiving interactions & webhook events of multiple Discord applications on a single http.Server.
+// Requests are routed by the dedicated Application.Path or by the application ID in the payload when received on the shared Config.URL.
+type MultiServer interface {
+	Server
+
+	// AddApplication registers the Application. It can be called before & after Start.
+	AddApplication(app Application) error
+
+	// RemoveApplication unregisters the Application with the given ID.
+	RemoveApplication(applicationID snowflake.ID)
+
+	// Applications returns all registered Application(s).
+	Applications() []Application
+}
+
+// NewMultiServer creates a new MultiServer with the given ConfigOpt(s).
+// Config.URL & Config.WebhookEventsURL are shared by all Application(s) and route by application ID.
+func NewMultiServer(opts ...ConfigOpt) MultiServer {
+	config := DefaultConfig()
+	config.Apply(opts)
+	config.Logger = config.Logger.With(slog.String("name", "httpserver_multi"))
+
+	return &multiServerImpl{
+		config:     *config,
+		apps:       map[snowflake.ID]*multiServerApp{},
+		paths:      map[string]*multiServerApp{},
+		registered: map[string]struct{}{},
+	}
+}
+
+type multiServerApp struct {
+	app                 Application
+	verifier            *RequestVerifier
+	interactionHandler  http.HandlerFunc
+	webhookEventHandler http.HandlerFunc
+}
+
+type multiServerImpl struct {
+	config Config
+
+	mu         sync.RWMutex
+	apps       map[snowflake.ID]*multiServerApp
+	paths      map[string]*multiServerApp
+	registered map[string]struct{}
+	started    bool
+
+	ready    atomic.Bool
+	inFlight sync.WaitGroup
+}
+
+func (s *multiServerImpl) AddApplication(app Application) error {
+	publicKey, err := hex.DecodeString(app.PublicKey)
+	if err != nil {
+		return fmt.Errorf("failed to decode public key of application %s: %w", app.ID, err)
+	}
+	if app.EventHandlerFunc == nil {
+		return fmt.Errorf("application %s has no EventHandlerFunc", app.ID)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.apps[app.ID]; ok {
+		return fmt.Errorf("%w: %s", ErrApplicationAlreadyRegistered, app.ID)
+	}
+	if app.Path != "" && app.Path == app.WebhookEventsPath {
+		return fmt.Errorf("%w: %s", ErrApplicationAlreadyRegistered, app.Path)
+	}
+	for _, path := range []string{app.Path, app.WebhookEventsPath} {
+		if path == "" {
+			continue
+		}
+		if _, ok := s.paths[path]; ok || path == s.config.URL || path == s.config.WebhookEventsURL {
+			return fmt.Errorf("%w: %s", ErrApplicationAlreadyRegistered, path)
+		}
+	}
+
+	logger := s.config.Logger.With(slog.String("application_id", app.ID.String()))
+	verifier := NewRequestVerifier(publicKey, append(append([]VerifierConfigOpt{}, s.config.VerifierConfigOpts...), app.VerifierConfigOpts...)...)
+	msApp := &multiServerApp{
+		app:                app,
+		verifier:           verifier,
+		interactionHandler: handleInteraction(verifier, logger, app.EventHandlerFunc, &s.inFlight),
+	}
+	if app.WebhookEventHandlerFunc != nil {
+		msApp.webhookEventHandler = handleWebhookEvent(verifier, logger, app.WebhookEventHandlerFunc, &s.inFlight)
+	}
+
+	s.apps[app.ID] = msApp
+	if app.Path != "" {
+		s.paths[app.Path] = msApp
+		s.registerPath(app.Path, s.handlePath(app.Path))
+	}
+	if app.WebhookEventsPath != "" {
+		s.paths[app.WebhookEventsPath] = msApp
+		s.registerPath(app.WebhookEventsPath, s.handlePath(app.WebhookEventsPath))
+	}
+	return nil
+}
+
+func (s *multiServerImpl) RemoveApplication(applicationID snowflake.ID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	msApp, ok := s.apps[applicationID]
+	if !ok {
+		return
+	}
+	delete(s.apps, applicationID)
+	if msApp.app.Path != "" {
+		delete(s.paths, msApp.app.Path)
+	}
+	if msApp.app.WebhookEventsPath != "" {
+		delete(s.paths, msApp.app.WebhookEventsPath)
+	}
+}
+
+func (s *multiServerImpl) Applications() []Application {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	apps := make([]Application, 0, len(s.apps))
+	for _, msApp := range s.apps {
+		apps = append(apps, msApp.app)
+	}
+	return apps
+}
+
+func (s *multiServerImpl) Start() {
+	s.mu.Lock()
+	s.started = true
+	// paths can't be removed from a http.ServeMux, so they are only registered once & resolved on every request
+	s.registerPath(s.config.URL, s.handleShared(false))
+	if s.config.WebhookEventsURL != "" {
+		s.registerPath(s.config.WebhookEventsURL, s.handleShared(true))
+	}
+	for path := range s.paths {
+		s.registerPath(path, s.handlePath(path))
+	}
+	if s.config.HealthURL != "" {
+		s.registerPath(s.config.HealthURL, HandleHealth())
+	}
+	if s.config.ReadinessURL != "" {
+		s.registerPath(s.config.ReadinessURL, HandleReadiness(s.isReady))
+	}
+	s.mu.Unlock()
+
+	s.config.HTTPServer.Addr = s.config.Address
+	s.config.HTTPServer.Handler = s.config.ServeMux
+
+	s.ready.Store(true)
+	go func() {
+		var err error
+		if s.config.CertFile != "" && s.config.KeyFile != "" {
+			err = s.config.HTTPServer.ListenAndServeTLS(s.config.CertFile, s.config.KeyFile)
+		} else {
+			err = s.config.HTTPServer.ListenAndServe()
+		}
+		if !errors.Is(err, http.ErrServerClosed) {
+			s.config.Logger.Error("error while running http server", slog.Any("err", err))
+		}
+	}()
+}
+
+func (s *multiServerImpl) Close(ctx context.Context) {
+	s.ready.Store(false)
+	_ = s.config.HTTPServer.Shutdown(ctx)
+	if err := waitInFlight(ctx, &s.inFlight); err != nil {
+		s.config.Logger.Error("error while waiting for in-flight interactions", slog.Any("err", err))
+	}
+}
+
+func (s *multiServerImpl) isReady() bool {
+	if !s.ready.Load() {
+		return false
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.apps) > 0
+}
+
+// registerPath registers the http.Handler on the http.ServeMux once the MultiServer was started. s.mu must be held.
+func (s *multiServerImpl) registerPath(path string, handler http.Handler) {
+	if !s.started {
+		return
+	}
+	if _, ok := s.registered[path]; ok {
+		return
+	}
+	s.registered[path] = struct{}{}
+	s.config.ServeMux.Handle(path, handler)
+}
+
+func (s *multiServerImpl) handlePath(path string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.RLock()
+		msApp, ok := s.paths[path]
+		s.mu.RUnlock()
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		s.serveApp(w, r, msApp, path == msApp.app.WebhookEventsPath)
+	}
+}
+
+// sharedMaxBodySize returns the largest Application VerifierConfig.MaxBodySize as the application of requests on the shared paths is unknown before reading the body.
+// The RequestVerifier of the selected Application enforces its own limit afterward.
+func (s *multiServerImpl) sharedMaxBodySize() int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if len(s.apps) == 0 {
+		verifierConfig := DefaultVerifierConfig()
+		verifierConfig.Apply(s.config.VerifierConfigOpts)
+		return verifierConfig.MaxBodySize
+	}
+
+	var maxBodySize int64
+	for _, msApp := range s.apps {
+		if msApp.verifier.config.MaxBodySize <= 0 {
+			return 0
+		}
+		maxBodySize = max(maxBodySize, msApp.verifier.config.MaxBodySize)
+	}
+	return maxBodySize
+}
+
+func (s *multiServerImpl) handleShared(webhookEvents bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		maxBodySize := s.sharedMaxBodySize()
+		var reader io.Reader = r.Body
+		if maxBodySize > 0 {
+			reader = io.LimitReader(r.Body, maxBodySize+1)
+		}
+		body, err := io.ReadAll(reader)
+		_ = r.Body.Close()
+		if err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+		if maxBodySize > 0 && int64(len(body)) > maxBodySize {
+			http.Error(w, "Request Entity Too Large", http.StatusRequestEntityTooLarge)
+			return
+		}
+
+		// the application id is not trusted yet, but it only selects the public key the request is verified with
+		var v struct {
+			ApplicationID snowflake.ID `json:"application_id"`
+		}
+		if err = json.Unmarshal(body, &v); err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+
+		s.mu.RLock()
+		msApp, ok := s.apps[v.ApplicationID]
+		s.mu.RUnlock()
+		if !ok {
+			s.config.Logger.Debug("received request for unknown application", slog.String("application_id", v.ApplicationID.String()))
+			http.NotFound(w, r)
+			return
+		}
+
+		// the RequestVerifier of the application applies its VerifierConfig to the body again
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		s.serveApp(w, r, msApp, webhookEvents)
+	}
+}
+
+func (s *multiServerImpl) serveApp(w http.ResponseWriter, r *http.Request, msApp *multiServerApp, webhookEvents bool) {
+	if !webhookEvents {
+		msApp.interactionHandler(w, r)
+		return
+	}
+	if msApp.webhookEventHandler == nil {
+		http.NotFound(w, r)
+		return
+	}
+	msApp.webhookEventHandler(w, r)
+}
@@ -0,0 +1,130 @@
+package httpserver
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+func TestMultiServer(t *testing.T) {
+	type testApp struct {
+		id         snowflake.ID
+		privateKey ed25519.PrivateKey
+		called     chan struct{}
+	}
+	mux := http.NewServeMux()
+	server := NewMultiServer(WithServeMux(mux), WithAddress("127.0.0.1:0"), WithHealthURL("/health"), WithReadinessURL("/ready"))
+
+	newApp := func(id snowflake.ID, path string) testApp {
+		publicKey, privateKey, err := ed25519.GenerateKey(nil)
+		assert.NoError(t, err)
+		app := testApp{id: id, privateKey: privateKey, called: make(chan struct{}, 1)}
+		assert.NoError(t, server.AddApplication(Application{
+			ID:        id,
+			PublicKey: hex.EncodeToString(publicKey),
+			Path:      path,
+			EventHandlerFunc: func(respondFunc RespondFunc, event EventInteractionCreate) {
+				_ = respondFunc(discord.InteractionResponse{Type: discord.InteractionResponseTypePong})
+				app.called <- struct{}{}
+			},
+		}))
+		return app
+	}
+	app1 := newApp(1, "")
+	app2 := newApp(2, "/app2")
+	assert.ErrorIs(t, server.AddApplication(Application{ID: 1, EventHandlerFunc: func(RespondFunc, EventInteractionCreate) {}}), ErrApplicationAlreadyRegistered)
+
+	serve := func(path string, app testApp, applicationID snowflake.ID) int {
+		body := `{"id":"` + strconv.FormatInt(time.Now().UnixNano(), 10) + `","application_id":"` + applicationID.String() + `","type":1,"token":"token","version":1}`
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		rq := httptest.NewRequest(http.MethodPost, path, bytes.NewReader([]byte(body)))
+		rq.Header.Set("X-Signature-Ed25519", hex.EncodeToString(ed25519.Sign(app.privateKey, []byte(timestamp+body))))
+		rq.Header.Set("X-Signature-Timestamp", timestamp)
+		rs := httptest.NewRecorder()
+		mux.ServeHTTP(rs, rq)
+		return rs.Code
+	}
+	get := func(path string) int {
+		rs := httptest.NewRecorder()
+		mux.ServeHTTP(rs, httptest.NewRequest(http.MethodGet, path, nil))
+		return rs.Code
+	}
+
+	server.Start()
+
+	assert.Equal(t, http.StatusOK, get("/health"))
+	assert.Equal(t, http.StatusOK, get("/ready"))
+
+	// routed by application id
+	assert.Equal(t, http.StatusOK, serve("/interactions/callback", app1, app1.id))
+	<-app1.called
+	assert.Equal(t, http.StatusOK, serve("/interactions/callback", app2, app2.id))
+	<-app2.called
+	assert.Equal(t, http.StatusNotFound, serve("/interactions/callback", app1, 3))
+	// signed with the key of another application
+	assert.Equal(t, http.StatusUnauthorized, serve("/interactions/callback", app1, app2.id))
+
+	// routed by path
+	assert.Equal(t, http.StatusOK, serve("/app2", app2, app2.id))
+	<-app2.called
+	assert.Equal(t, http.StatusUnauthorized, serve("/app2", app1, app1.id))
+
+	server.RemoveApplication(app2.id)
+	assert.Equal(t, http.StatusNotFound, serve("/app2", app2, app2.id))
+	assert.Len(t, server.Applications(), 1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	server.Close(ctx)
+	assert.Equal(t, http.StatusServiceUnavailable, get("/ready"))
+}
+
+func TestMultiServer_ApplicationVerifierConfig(t *testing.T) {
+	mux := http.NewServeMux()
+	server := NewMultiServer(WithServeMux(mux), WithVerifierConfigOpts(WithMaxBodySize(300)))
+
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	assert.NoError(t, err)
+	addApp := func(id snowflake.ID, maxBodySize int64) {
+		assert.NoError(t, server.AddApplication(Application{
+			ID:        id,
+			PublicKey: hex.EncodeToString(publicKey),
+			EventHandlerFunc: func(respondFunc RespondFunc, event EventInteractionCreate) {
+				_ = respondFunc(discord.InteractionResponse{Type: discord.InteractionResponseTypePong})
+			},
+			VerifierConfigOpts: []VerifierConfigOpt{WithMaxBodySize(maxBodySize)},
+		}))
+	}
+	addApp(1, 1000)
+	addApp(2, 200)
+	server.Start()
+
+	serve := func(applicationID snowflake.ID, size int) int {
+		body := `{"id":"` + strconv.FormatInt(time.Now().UnixNano(), 10) + `","application_id":"` + applicationID.String() + `","type":1,"token":"token","version":1,"padding":"`
+		body += string(bytes.Repeat([]byte("a"), size-len(body)-2)) + `"}`
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		rq := httptest.NewRequest(http.MethodPost, "/interactions/callback", bytes.NewReader([]byte(body)))
+		rq.Header.Set("X-Signature-Ed25519", hex.EncodeToString(ed25519.Sign(privateKey, []byte(timestamp+body))))
+		rq.Header.Set("X-Signature-Timestamp", timestamp)
+		rs := httptest.NewRecorder()
+		mux.ServeHTTP(rs, rq)
+		return rs.Code
+	}
+
+	// the limit of the application is used instead of the one of the Config
+	assert.Equal(t, http.StatusOK, serve(1, 500))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, serve(1, 1001))
+	assert.Equal(t, http.StatusOK, serve(2, 200))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, serve(2, 250))
+}
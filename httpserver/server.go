@@ -3,7 +3,7 @@ package httpserver
 import (
 	"bytes"
 	"context"
-	"encoding/hex"
+	"errors"
 	"io"
 	"log/slog"
 	"net/http"
@@ -46,53 +46,10 @@ type Server interface {
 	// Start starts the Server
 	Start()
 
-	// Close closes the Server
+	// Close closes the Server & waits for in-flight interactions until the context.Context is done
 	Close(ctx context.Context)
 }
 
-// VerifyRequest implements the verification side of the discord interactions api signing algorithm, as documented here: https://discord.com/developers/docs/interactions/slash-commands#security-and-authorization
-// Credit: https://github.com/bsdlp/discord-interactions-go/blob/main/interactions/verify.go
-func VerifyRequest(r *http.Request, key PublicKey) bool {
-	var msg bytes.Buffer
-
-	signature := r.Header.Get("X-Signature-Ed25519")
-	if signature == "" {
-		return false
-	}
-
-	sig, err := hex.DecodeString(signature)
-	if err != nil {
-		return false
-	}
-
-	if len(sig) != SignatureSize || sig[63]&224 != 0 {
-		return false
-	}
-
-	timestamp := r.Header.Get("X-Signature-Timestamp")
-	if timestamp == "" {
-		return false
-	}
-
-	msg.WriteString(timestamp)
-
-	defer func() {
-		_ = r.Body.Close()
-	}()
-	var body bytes.Buffer
-
-	defer func() {
-		r.Body = io.NopCloser(&body)
-	}()
-
-	_, err = io.Copy(&msg, io.TeeReader(r.Body, &body))
-	if err != nil {
-		return false
-	}
-
-	return Verify(key, msg.Bytes(), sig)
-}
-
 type replyStatus int
 
 const (
@@ -102,30 +59,39 @@ const (
 )
 
 // HandleInteraction handles an interaction from Discord's Outgoing Webhooks. It verifies and parses the interaction and then calls the passed EventHandlerFunc.
-func HandleInteraction(publicKey PublicKey, logger *slog.Logger, handleFunc EventHandlerFunc) http.HandlerFunc {
+// The VerifierConfigOpt(s) configure the RequestVerifier used to verify the requests.
+func HandleInteraction(publicKey PublicKey, logger *slog.Logger, handleFunc EventHandlerFunc, opts ...VerifierConfigOpt) http.HandlerFunc {
+	return handleInteraction(NewRequestVerifier(publicKey, opts...), logger, handleFunc, nil)
+}
+
+// handleInteraction is the implementation of HandleInteraction. If inFlight is not nil, it tracks the running EventHandlerFunc(s).
+func handleInteraction(verifier *RequestVerifier, logger *slog.Logger, handleFunc EventHandlerFunc, inFlight *sync.WaitGroup) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		if ok := VerifyRequest(r, publicKey); !ok {
+		rqData, err := verifier.Verify(r)
+		if err != nil {
+			logger.Debug("received http interaction which failed verification", slog.Any("err", err))
+			if errors.Is(err, ErrBodyTooLarge) {
+				http.Error(w, "Request Entity Too Large", http.StatusRequestEntityTooLarge)
+				return
+			}
 			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			data, _ := io.ReadAll(r.Body)
-			logger.Debug("received http interaction with invalid signature", slog.String("body", string(data)))
 			return
 		}
-
-		defer func() {
-			_ = r.Body.Close()
-		}()
-
-		buff := new(bytes.Buffer)
-		rqData, _ := io.ReadAll(io.TeeReader(r.Body, buff))
 		logger.Debug("received http interaction", slog.String("body", string(rqData)))
 
 		var v EventInteractionCreate
-		if err := json.NewDecoder(buff).Decode(&v); err != nil {
+		if err = json.Unmarshal(rqData, &v); err != nil {
 			logger.Error("error while decoding interaction", slog.Any("err", err))
 			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 			return
 		}
 
+		if err = verifier.CheckReplay(v.ID()); err != nil {
+			logger.Debug("received duplicate http interaction", slog.String("id", v.ID().String()))
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
 		// these channels are used to communicate between the http handler and where the interaction is responded to
 		responseChannel := make(chan discord.InteractionResponse, 1)
 		defer close(responseChannel)
@@ -138,8 +104,7 @@ func HandleInteraction(publicKey PublicKey, logger *slog.Logger, handleFunc Even
 			mu     sync.Mutex
 		)
 
-		// send interaction to our handler
-		go handleFunc(func(response discord.InteractionResponse) error {
+		respondFunc := func(response discord.InteractionResponse) error {
 			mu.Lock()
 			defer mu.Unlock()
 
@@ -155,12 +120,20 @@ func HandleInteraction(publicKey PublicKey, logger *slog.Logger, handleFunc Even
 			responseChannel <- response
 			// wait if we get any error while processing the response
 			return <-errorChannel
-		}, v)
+		}
 
-		var (
-			body any
-			err  error
-		)
+		// send interaction to our handler
+		if inFlight != nil {
+			inFlight.Add(1)
+		}
+		go func() {
+			if inFlight != nil {
+				defer inFlight.Done()
+			}
+			handleFunc(respondFunc, v)
+		}()
+
+		var body any
 
 		// wait for the interaction to be responded to or to time out after 3s
 		ctx, cancel := context.WithTimeout(context.Background(), 3100*time.Millisecond)
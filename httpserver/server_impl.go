@@ -6,6 +6,8 @@ import (
 	"errors"
 	"log/slog"
 	"net/http"
+	"sync"
+	"sync/atomic"
 )
 
 var _ Server = (*serverImpl)(nil)
@@ -32,13 +34,27 @@ type serverImpl struct {
 	config           Config
 	publicKey        PublicKey
 	eventHandlerFunc EventHandlerFunc
+
+	ready    atomic.Bool
+	inFlight sync.WaitGroup
 }
 
 func (s *serverImpl) Start() {
-	s.config.ServeMux.Handle(s.config.URL, HandleInteraction(s.publicKey, s.config.Logger, s.eventHandlerFunc))
+	verifier := NewRequestVerifier(s.publicKey, s.config.VerifierConfigOpts...)
+	s.config.ServeMux.Handle(s.config.URL, handleInteraction(verifier, s.config.Logger, s.eventHandlerFunc, &s.inFlight))
+	if s.config.WebhookEventsURL != "" && s.config.WebhookEventHandlerFunc != nil {
+		s.config.ServeMux.Handle(s.config.WebhookEventsURL, handleWebhookEvent(verifier, s.config.Logger, s.config.WebhookEventHandlerFunc, &s.inFlight))
+	}
+	if s.config.HealthURL != "" {
+		s.config.ServeMux.Handle(s.config.HealthURL, HandleHealth())
+	}
+	if s.config.ReadinessURL != "" {
+		s.config.ServeMux.Handle(s.config.ReadinessURL, HandleReadiness(s.ready.Load))
+	}
 	s.config.HTTPServer.Addr = s.config.Address
 	s.config.HTTPServer.Handler = s.config.ServeMux
 
+	s.ready.Store(true)
 	go func() {
 		var err error
 		if s.config.CertFile != "" && s.config.KeyFile != "" {
@@ -53,5 +69,9 @@ func (s *serverImpl) Start() {
 }
 
 func (s *serverImpl) Close(ctx context.Context) {
+	s.ready.Store(false)
 	_ = s.config.HTTPServer.Shutdown(ctx)
+	if err := waitInFlight(ctx, &s.inFlight); err != nil {
+		s.config.Logger.Error("error while waiting for in-flight interactions", slog.Any("err", err))
+	}
 }
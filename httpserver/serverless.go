@@ -0,0 +1,295 @@
+package httpserver
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/json"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+// InteractionRequest is a transport agnostic interaction request sent by Discord.
+type InteractionRequest struct {
+	// Body is the raw request body
+	Body []byte
+	// Signature is the value of the X-Signature-Ed25519 header
+	Signature string
+	// Timestamp is the value of the X-Signature-Timestamp header
+	Timestamp string
+}
+
+// NewInteractionRequest returns a new InteractionRequest from the given http.Header & raw body.
+func NewInteractionRequest(header http.Header, body []byte) InteractionRequest {
+	return InteractionRequest{
+		Body:      body,
+		Signature: header.Get("X-Signature-Ed25519"),
+		Timestamp: header.Get("X-Signature-Timestamp"),
+	}
+}
+
+// InteractionResult is the transport agnostic response to an InteractionRequest.
+type InteractionResult struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+
+	done <-chan struct{}
+}
+
+// Wait blocks until the EventHandlerFunc returned. This is useful to keep the function alive until the interaction was handled, for example in deferral mode or after a timeout.
+func (r InteractionResult) Wait() {
+	if r.done != nil {
+		<-r.done
+	}
+}
+
+// Write writes the InteractionResult to the given http.ResponseWriter.
+func (r InteractionResult) Write(w http.ResponseWriter) error {
+	for key, values := range r.Header {
+		for _, value := range values {
+			w.Header().Add(key, value)
+		}
+	}
+	w.WriteHeader(r.StatusCode)
+	_, err := w.Write(r.Body)
+	return err
+}
+
+// DefaultProcessConfig returns a ProcessConfig with sensible defaults.
+func DefaultProcessConfig() *ProcessConfig {
+	return &ProcessConfig{
+		Logger: slog.Default(),
+	}
+}
+
+// ProcessConfig lets you configure how ProcessInteraction handles interactions.
+type ProcessConfig struct {
+	Logger *slog.Logger
+	// DeferAfter enables deferral mode. If the EventHandlerFunc did not respond after this duration, the interaction is deferred.
+	DeferAfter time.Duration
+	// DeferEphemeral makes deferred messages ephemeral.
+	DeferEphemeral bool
+}
+
+// ProcessOpt is a type alias for a function that takes a ProcessConfig and is used to configure ProcessInteraction.
+type ProcessOpt func(config *ProcessConfig)
+
+// Apply applies the given ProcessOpt(s) to the ProcessConfig
+func (c *ProcessConfig) Apply(opts []ProcessOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithProcessLogger sets the Logger of the ProcessConfig.
+func WithProcessLogger(logger *slog.Logger) ProcessOpt {
+	return func(config *ProcessConfig) {
+		config.Logger = logger
+	}
+}
+
+// WithDeferral enables deferral mode. If the EventHandlerFunc did not respond after the given duration, the interaction is deferred.
+// Application commands & modals are deferred with discord.InteractionResponseTypeDeferredCreateMessage and components with discord.InteractionResponseTypeDeferredUpdateMessage.
+// Once deferred, calls to the RespondFunc return discord.ErrInteractionAlreadyReplied & the response has to be sent via rest.Interactions.UpdateInteractionResponse.
+func WithDeferral(deferAfter time.Duration, ephemeral bool) ProcessOpt {
+	return func(config *ProcessConfig) {
+		config.DeferAfter = deferAfter
+		config.DeferEphemeral = ephemeral
+	}
+}
+
+// ProcessInteraction verifies & parses the InteractionRequest, passes it to the EventHandlerFunc and returns the response as InteractionResult.
+// It does not depend on net/http, so it can be used in function-as-a-service runtimes or to test interaction handlers.
+//
+// By default, ProcessInteraction waits until the EventHandlerFunc responded or the context.Context is done & returns http.StatusRequestTimeout in the latter case.
+// If the EventHandlerFunc returns without responding, http.StatusInternalServerError is returned right away, also when WithDeferral is used.
+// If the context.Context has no deadline, Discord's 3 second response window is used.
+// Use WithDeferral to automatically defer the interaction if the EventHandlerFunc takes too long to respond.
+func ProcessInteraction(ctx context.Context, verifier *RequestVerifier, rq InteractionRequest, handleFunc EventHandlerFunc, opts ...ProcessOpt) InteractionResult {
+	cfg := DefaultProcessConfig()
+	cfg.Apply(opts)
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 3100*time.Millisecond)
+		defer cancel()
+	}
+
+	if err := verifier.VerifyBody(rq.Signature, rq.Timestamp, rq.Body); err != nil {
+		cfg.Logger.Debug("received interaction which failed verification", slog.Any("err", err))
+		if errors.Is(err, ErrBodyTooLarge) {
+			return errorResult(http.StatusRequestEntityTooLarge)
+		}
+		return errorResult(http.StatusUnauthorized)
+	}
+
+	var v EventInteractionCreate
+	if err := json.Unmarshal(rq.Body, &v); err != nil {
+		cfg.Logger.Error("error while decoding interaction", slog.Any("err", err))
+		return errorResult(http.StatusBadRequest)
+	}
+
+	if err := verifier.CheckReplay(v.ID()); err != nil {
+		cfg.Logger.Debug("received duplicate interaction", slog.String("id", v.ID().String()))
+		return errorResult(http.StatusUnauthorized)
+	}
+
+	var (
+		mu       sync.Mutex
+		replied  bool
+		response = make(chan InteractionResult, 1)
+		done     = make(chan struct{})
+	)
+	respondFunc := func(interactionResponse discord.InteractionResponse) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if replied {
+			return discord.ErrInteractionAlreadyReplied
+		}
+
+		result, err := encodeInteractionResponse(interactionResponse)
+		if err != nil {
+			return err
+		}
+		replied = true
+		response <- result
+		return nil
+	}
+
+	go func() {
+		defer close(done)
+		handleFunc(respondFunc, v)
+	}()
+
+	deferResponseType, canDefer := deferredResponseType(v.Type())
+	if cfg.DeferAfter <= 0 || !canDefer {
+		select {
+		case result := <-response:
+			result.done = done
+			return result
+		case <-done:
+		case <-ctx.Done():
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		// the handler might have responded right before returning or while we were waiting for the lock
+		select {
+		case result := <-response:
+			result.done = done
+			return result
+		default:
+		}
+
+		replied = true
+		var result InteractionResult
+		if ctx.Err() != nil {
+			cfg.Logger.Debug("interaction timed out", slog.Any("err", ctx.Err()))
+			result = errorResult(http.StatusRequestTimeout)
+		} else {
+			cfg.Logger.Error("interaction handler returned without responding", slog.String("id", v.ID().String()))
+			result = errorResult(http.StatusInternalServerError)
+		}
+		result.done = done
+		return result
+	}
+
+	timer := time.NewTimer(cfg.DeferAfter)
+	defer timer.Stop()
+	select {
+	case result := <-response:
+		result.done = done
+		return result
+	case <-timer.C:
+	case <-done:
+	case <-ctx.Done():
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	// the handler might have responded while we were waiting for the lock
+	select {
+	case result := <-response:
+		result.done = done
+		return result
+	default:
+	}
+
+	replied = true
+	select {
+	case <-done:
+		cfg.Logger.Error("interaction handler returned without responding", slog.String("id", v.ID().String()))
+		result := errorResult(http.StatusInternalServerError)
+		result.done = done
+		return result
+	default:
+	}
+
+	var data discord.InteractionResponseData
+	if cfg.DeferEphemeral && deferResponseType == discord.InteractionResponseTypeDeferredCreateMessage {
+		data = discord.MessageCreate{Flags: discord.MessageFlagEphemeral}
+	}
+	result, err := encodeInteractionResponse(discord.InteractionResponse{
+		Type: deferResponseType,
+		Data: data,
+	})
+	if err != nil {
+		cfg.Logger.Error("error while encoding deferred interaction response", slog.Any("err", err))
+		return errorResult(http.StatusInternalServerError)
+	}
+	cfg.Logger.Debug("deferred interaction")
+	result.done = done
+	return result
+}
+
+func deferredResponseType(interactionType discord.InteractionType) (discord.InteractionResponseType, bool) {
+	switch interactionType {
+	case discord.InteractionTypeApplicationCommand, discord.InteractionTypeModalSubmit:
+		return discord.InteractionResponseTypeDeferredCreateMessage, true
+	case discord.InteractionTypeComponent:
+		return discord.InteractionResponseTypeDeferredUpdateMessage, true
+	default:
+		return 0, false
+	}
+}
+
+func encodeInteractionResponse(response discord.InteractionResponse) (InteractionResult, error) {
+	body, err := response.ToBody()
+	if err != nil {
+		return InteractionResult{}, err
+	}
+
+	header := http.Header{}
+	if multiPart, ok := body.(*discord.MultipartBuffer); ok {
+		header.Set("Content-Type", multiPart.ContentType)
+		data, err := io.ReadAll(multiPart.Buffer)
+		if err != nil {
+			return InteractionResult{}, err
+		}
+		return InteractionResult{StatusCode: http.StatusOK, Header: header, Body: data}, nil
+	}
+
+	buff := new(bytes.Buffer)
+	if err = json.NewEncoder(buff).Encode(body); err != nil {
+		return InteractionResult{}, err
+	}
+	header.Set("Content-Type", "application/json")
+	return InteractionResult{StatusCode: http.StatusOK, Header: header, Body: buff.Bytes()}, nil
+}
+
+func errorResult(statusCode int) InteractionResult {
+	header := http.Header{}
+	header.Set("Content-Type", "text/plain; charset=utf-8")
+	return InteractionResult{
+		StatusCode: statusCode,
+		Header:     header,
+		Body:       []byte(http.StatusText(statusCode) + "\n"),
+	}
+}
@@ -0,0 +1,117 @@
+package httpserver
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+func TestProcessInteraction(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	assert.NoError(t, err)
+
+	request := func(body string) InteractionRequest {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		return InteractionRequest{
+			Body:      []byte(body),
+			Signature: hex.EncodeToString(ed25519.Sign(privateKey, []byte(timestamp+body))),
+			Timestamp: timestamp,
+		}
+	}
+	command := func(id int) string {
+		return `{"id":"` + strconv.Itoa(id) + `","application_id":"1","type":2,"token":"token","version":1,"channel_id":"1","user":{"id":"1","username":"test"},"data":{"id":"1","name":"test","type":1}}`
+	}
+
+	t.Run("ping", func(t *testing.T) {
+		verifier := NewRequestVerifier(publicKey)
+		result := ProcessInteraction(context.Background(), verifier, request(`{"id":"1","application_id":"1","type":1,"token":"token","version":1}`), func(respondFunc RespondFunc, event EventInteractionCreate) {
+			_ = respondFunc(discord.InteractionResponse{Type: discord.InteractionResponseTypePong})
+		})
+		assert.Equal(t, http.StatusOK, result.StatusCode)
+		assert.JSONEq(t, `{"type":1}`, string(result.Body))
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		verifier := NewRequestVerifier(publicKey)
+		rq := request(command(1))
+		rq.Body = []byte(command(2))
+		result := ProcessInteraction(context.Background(), verifier, rq, func(respondFunc RespondFunc, event EventInteractionCreate) {
+			t.Fatal("handler should not be called")
+		})
+		assert.Equal(t, http.StatusUnauthorized, result.StatusCode)
+	})
+
+	t.Run("sync", func(t *testing.T) {
+		verifier := NewRequestVerifier(publicKey)
+		result := ProcessInteraction(context.Background(), verifier, request(command(2)), func(respondFunc RespondFunc, event EventInteractionCreate) {
+			_ = respondFunc(discord.InteractionResponse{
+				Type: discord.InteractionResponseTypeCreateMessage,
+				Data: discord.MessageCreate{Content: "pong"},
+			})
+		})
+		assert.Equal(t, http.StatusOK, result.StatusCode)
+		assert.Equal(t, "application/json", result.Header.Get("Content-Type"))
+		assert.JSONEq(t, `{"type":4,"data":{"content":"pong"}}`, string(result.Body))
+
+		// the same interaction is rejected as replay
+		result = ProcessInteraction(context.Background(), verifier, request(command(2)), func(respondFunc RespondFunc, event EventInteractionCreate) {})
+		assert.Equal(t, http.StatusUnauthorized, result.StatusCode)
+	})
+
+	t.Run("no response", func(t *testing.T) {
+		verifier := NewRequestVerifier(publicKey)
+		start := time.Now()
+		result := ProcessInteraction(context.Background(), verifier, request(command(4)), func(respondFunc RespondFunc, event EventInteractionCreate) {})
+		assert.Equal(t, http.StatusInternalServerError, result.StatusCode)
+		assert.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("deferral no response", func(t *testing.T) {
+		verifier := NewRequestVerifier(publicKey)
+		start := time.Now()
+		result := ProcessInteraction(context.Background(), verifier, request(command(6)), func(respondFunc RespondFunc, event EventInteractionCreate) {}, WithDeferral(time.Second, true))
+		assert.Equal(t, http.StatusInternalServerError, result.StatusCode)
+		assert.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		verifier := NewRequestVerifier(publicKey)
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		release := make(chan struct{})
+		var respondErr error
+		result := ProcessInteraction(ctx, verifier, request(command(5)), func(respondFunc RespondFunc, event EventInteractionCreate) {
+			<-release
+			respondErr = respondFunc(discord.InteractionResponse{Type: discord.InteractionResponseTypeCreateMessage})
+		})
+		assert.Equal(t, http.StatusRequestTimeout, result.StatusCode)
+
+		close(release)
+		result.Wait()
+		assert.ErrorIs(t, respondErr, discord.ErrInteractionAlreadyReplied)
+	})
+
+	t.Run("deferral", func(t *testing.T) {
+		verifier := NewRequestVerifier(publicKey)
+		release := make(chan struct{})
+		var respondErr error
+		result := ProcessInteraction(context.Background(), verifier, request(command(3)), func(respondFunc RespondFunc, event EventInteractionCreate) {
+			<-release
+			respondErr = respondFunc(discord.InteractionResponse{Type: discord.InteractionResponseTypeCreateMessage})
+		}, WithDeferral(10*time.Millisecond, true))
+		assert.Equal(t, http.StatusOK, result.StatusCode)
+		assert.JSONEq(t, `{"type":5,"data":{"flags":64}}`, string(result.Body))
+
+		close(release)
+		result.Wait()
+		assert.ErrorIs(t, respondErr, discord.ErrInteractionAlreadyReplied)
+	})
+}
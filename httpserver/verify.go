@@ -0,0 +1,211 @@
+package httpserver
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+)
+
+var (
+	// ErrInvalidSignature is returned when the signature of a request is missing or invalid
+	ErrInvalidSignature = errors.New("invalid request signature")
+	// ErrTimestampSkew is returned when the timestamp of a request is outside the configured skew window
+	ErrTimestampSkew = errors.New("request timestamp outside of allowed skew")
+	// ErrBodyTooLarge is returned when the body of a request exceeds the configured maximum size
+	ErrBodyTooLarge = errors.New("request body too large")
+	// ErrDuplicateInteraction is returned when an interaction with the same ID was already received
+	ErrDuplicateInteraction = errors.New("duplicate interaction")
+)
+
+// DefaultVerifierConfig returns a VerifierConfig with sensible defaults.
+func DefaultVerifierConfig() *VerifierConfig {
+	return &VerifierConfig{
+		MaxBodySize:      4 << 20,
+		MaxTimestampSkew: 5 * time.Minute,
+		ReplayCacheSize:  1000,
+	}
+}
+
+// VerifierConfig lets you configure the RequestVerifier.
+type VerifierConfig struct {
+	// MaxBodySize is the maximum size of a request body in bytes. 0 disables the limit.
+	MaxBodySize int64
+	// MaxTimestampSkew is the maximum difference between the X-Signature-Timestamp header & the current time. 0 disables the check.
+	MaxTimestampSkew time.Duration
+	// ReplayCacheSize is the maximum amount of interaction IDs remembered to reject duplicates. 0 disables the check.
+	ReplayCacheSize int
+}
+
+// VerifierConfigOpt is a type alias for a function that takes a VerifierConfig and is used to configure your RequestVerifier.
+type VerifierConfigOpt func(config *VerifierConfig)
+
+// Apply applies the given VerifierConfigOpt(s) to the VerifierConfig
+func (c *VerifierConfig) Apply(opts []VerifierConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithMaxBodySize sets the MaxBodySize of the VerifierConfig.
+func WithMaxBodySize(maxBodySize int64) VerifierConfigOpt {
+	return func(config *VerifierConfig) {
+		config.MaxBodySize = maxBodySize
+	}
+}
+
+// WithMaxTimestampSkew sets the MaxTimestampSkew of the VerifierConfig.
+func WithMaxTimestampSkew(maxTimestampSkew time.Duration) VerifierConfigOpt {
+	return func(config *VerifierConfig) {
+		config.MaxTimestampSkew = maxTimestampSkew
+	}
+}
+
+// WithReplayCacheSize sets the ReplayCacheSize of the VerifierConfig.
+func WithReplayCacheSize(replayCacheSize int) VerifierConfigOpt {
+	return func(config *VerifierConfig) {
+		config.ReplayCacheSize = replayCacheSize
+	}
+}
+
+// NewRequestVerifier returns a new RequestVerifier for the given PublicKey and VerifierConfigOpt(s).
+func NewRequestVerifier(publicKey PublicKey, opts ...VerifierConfigOpt) *RequestVerifier {
+	config := DefaultVerifierConfig()
+	config.Apply(opts)
+
+	return &RequestVerifier{
+		config:    *config,
+		publicKey: publicKey,
+		seen:      make(map[snowflake.ID]time.Time, config.ReplayCacheSize),
+		now:       time.Now,
+	}
+}
+
+// RequestVerifier verifies the signature of requests sent by Discord and protects against replayed requests.
+type RequestVerifier struct {
+	config    VerifierConfig
+	publicKey PublicKey
+	now       func() time.Time
+
+	mu       sync.Mutex
+	seen     map[snowflake.ID]time.Time
+	seenRing []snowflake.ID
+	seenNext int
+}
+
+// Verify reads the body of the http.Request once and verifies its signature, timestamp and size.
+// The returned body can be used to decode the request.
+func (v *RequestVerifier) Verify(r *http.Request) ([]byte, error) {
+	defer func() {
+		_ = r.Body.Close()
+	}()
+
+	var reader io.Reader = r.Body
+	if v.config.MaxBodySize > 0 {
+		reader = io.LimitReader(r.Body, v.config.MaxBodySize+1)
+	}
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+	if v.config.MaxBodySize > 0 && int64(len(body)) > v.config.MaxBodySize {
+		return nil, ErrBodyTooLarge
+	}
+
+	if err = v.VerifyBody(r.Header.Get("X-Signature-Ed25519"), r.Header.Get("X-Signature-Timestamp"), body); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+// VerifyBody verifies the signature and timestamp of an already read request body.
+func (v *RequestVerifier) VerifyBody(signature string, timestamp string, body []byte) error {
+	if v.config.MaxBodySize > 0 && int64(len(body)) > v.config.MaxBodySize {
+		return ErrBodyTooLarge
+	}
+	if !verifySignature(v.publicKey, signature, timestamp, body) {
+		return ErrInvalidSignature
+	}
+	if v.config.MaxTimestampSkew > 0 {
+		unix, err := strconv.ParseInt(timestamp, 10, 64)
+		if err != nil {
+			return ErrTimestampSkew
+		}
+		skew := v.now().Sub(time.Unix(unix, 0))
+		if skew < -v.config.MaxTimestampSkew || skew > v.config.MaxTimestampSkew {
+			return ErrTimestampSkew
+		}
+	}
+	return nil
+}
+
+// CheckReplay remembers the given interaction ID and returns ErrDuplicateInteraction if it was already seen.
+// IDs are forgotten once the cache is full or once they are older than the MaxTimestampSkew, as such requests are rejected by VerifyBody anyway.
+func (v *RequestVerifier) CheckReplay(interactionID snowflake.ID) error {
+	if v.config.ReplayCacheSize <= 0 {
+		return nil
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	now := v.now()
+	if seenAt, ok := v.seen[interactionID]; ok {
+		if v.config.MaxTimestampSkew <= 0 || now.Sub(seenAt) <= v.config.MaxTimestampSkew {
+			return ErrDuplicateInteraction
+		}
+		// the ID expired but is still tracked in the ring, so we only refresh when it was seen
+		v.seen[interactionID] = now
+		return nil
+	}
+
+	if len(v.seenRing) < v.config.ReplayCacheSize {
+		v.seenRing = append(v.seenRing, interactionID)
+	} else {
+		delete(v.seen, v.seenRing[v.seenNext])
+		v.seenRing[v.seenNext] = interactionID
+		v.seenNext = (v.seenNext + 1) % v.config.ReplayCacheSize
+	}
+	v.seen[interactionID] = now
+	return nil
+}
+
+// VerifyRequest implements the verification side of the discord interactions api signing algorithm, as documented here: https://discord.com/developers/docs/interactions/slash-commands#security-and-authorization
+// It only verifies the signature. Use a RequestVerifier to also verify the timestamp and body size.
+// Credit: https://github.com/bsdlp/discord-interactions-go/blob/main/interactions/verify.go
+func VerifyRequest(r *http.Request, key PublicKey) bool {
+	body, err := io.ReadAll(r.Body)
+	_ = r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return false
+	}
+	return verifySignature(key, r.Header.Get("X-Signature-Ed25519"), r.Header.Get("X-Signature-Timestamp"), body)
+}
+
+func verifySignature(key PublicKey, signature string, timestamp string, body []byte) bool {
+	if signature == "" || timestamp == "" {
+		return false
+	}
+
+	sig, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	if len(sig) != SignatureSize || sig[63]&224 != 0 {
+		return false
+	}
+
+	msg := make([]byte, 0, len(timestamp)+len(body))
+	msg = append(msg, timestamp...)
+	msg = append(msg, body...)
+
+	return Verify(key, msg, sig)
+}
@@ -0,0 +1,59 @@
+package httpserver
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestVerifier_VerifyBody(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	assert.NoError(t, err)
+
+	now := time.Unix(1700000000, 0)
+	verifier := NewRequestVerifier(publicKey, WithMaxTimestampSkew(time.Minute), WithMaxBodySize(16))
+	verifier.now = func() time.Time { return now }
+
+	sign := func(timestamp time.Time, body string) (string, string) {
+		ts := strconv.FormatInt(timestamp.Unix(), 10)
+		return hex.EncodeToString(ed25519.Sign(privateKey, []byte(ts+body))), ts
+	}
+
+	signature, timestamp := sign(now, `{"type":1}`)
+	assert.NoError(t, verifier.VerifyBody(signature, timestamp, []byte(`{"type":1}`)))
+	assert.ErrorIs(t, verifier.VerifyBody(signature, timestamp, []byte(`{"type":2}`)), ErrInvalidSignature)
+	assert.ErrorIs(t, verifier.VerifyBody("", timestamp, []byte(`{"type":1}`)), ErrInvalidSignature)
+
+	signature, timestamp = sign(now.Add(-2*time.Minute), `{"type":1}`)
+	assert.ErrorIs(t, verifier.VerifyBody(signature, timestamp, []byte(`{"type":1}`)), ErrTimestampSkew)
+
+	signature, timestamp = sign(now.Add(2*time.Minute), `{"type":1}`)
+	assert.ErrorIs(t, verifier.VerifyBody(signature, timestamp, []byte(`{"type":1}`)), ErrTimestampSkew)
+
+	signature, timestamp = sign(now, `{"type":1,"data":{}}`)
+	assert.ErrorIs(t, verifier.VerifyBody(signature, timestamp, []byte(`{"type":1,"data":{}}`)), ErrBodyTooLarge)
+}
+
+func TestRequestVerifier_CheckReplay(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	verifier := NewRequestVerifier(nil, WithMaxTimestampSkew(time.Minute), WithReplayCacheSize(2))
+	verifier.now = func() time.Time { return now }
+
+	assert.NoError(t, verifier.CheckReplay(snowflake.ID(1)))
+	assert.ErrorIs(t, verifier.CheckReplay(snowflake.ID(1)), ErrDuplicateInteraction)
+	assert.NoError(t, verifier.CheckReplay(snowflake.ID(2)))
+	assert.NoError(t, verifier.CheckReplay(snowflake.ID(3)))
+
+	// 1 was evicted as the cache only holds 2 IDs
+	assert.NoError(t, verifier.CheckReplay(snowflake.ID(1)))
+	assert.ErrorIs(t, verifier.CheckReplay(snowflake.ID(3)), ErrDuplicateInteraction)
+
+	// IDs older than the skew window are forgotten
+	now = now.Add(2 * time.Minute)
+	assert.NoError(t, verifier.CheckReplay(snowflake.ID(3)))
+}
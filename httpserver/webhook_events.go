@@ -0,0 +1,211 @@
+package httpserver
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/json"
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+// WebhookEventHandlerFunc is used to handle webhook events from Discord
+type WebhookEventHandlerFunc func(event WebhookEvent)
+
+// WebhookType is the type of WebhookEventPayload (https://discord.com/developers/docs/events/webhook-events#webhook-types)
+type WebhookType int
+
+const (
+	// WebhookTypePing is sent by Discord to test the webhook events endpoint
+	WebhookTypePing WebhookType = iota
+	// WebhookTypeEvent contains a WebhookEvent
+	WebhookTypeEvent
+)
+
+// WebhookEventType is the type of WebhookEvent (https://discord.com/developers/docs/events/webhook-events#event-types)
+type WebhookEventType string
+
+const (
+	WebhookEventTypeApplicationAuthorized   WebhookEventType = "APPLICATION_AUTHORIZED"
+	WebhookEventTypeApplicationDeauthorized WebhookEventType = "APPLICATION_DEAUTHORIZED"
+	WebhookEventTypeEntitlementCreate       WebhookEventType = "ENTITLEMENT_CREATE"
+	WebhookEventTypeQuestUserEnrollment     WebhookEventType = "QUEST_USER_ENROLLMENT"
+)
+
+// WebhookEventPayload is the envelope Discord sends to the webhook events endpoint (https://discord.com/developers/docs/events/webhook-events#payload-structure)
+type WebhookEventPayload struct {
+	Version       int           `json:"version"`
+	ApplicationID snowflake.ID  `json:"application_id"`
+	Type          WebhookType   `json:"type"`
+	Event         *WebhookEvent `json:"event"`
+}
+
+// WebhookEvent is a single event received over the webhook events endpoint
+type WebhookEvent struct {
+	Type          WebhookEventType `json:"type"`
+	Timestamp     time.Time        `json:"timestamp"`
+	Data          WebhookEventData `json:"data"`
+	ApplicationID snowflake.ID     `json:"-"`
+}
+
+func (e *WebhookEvent) UnmarshalJSON(data []byte) error {
+	var v struct {
+		Type      WebhookEventType `json:"type"`
+		Timestamp string           `json:"timestamp"`
+		Data      json.RawMessage  `json:"data"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	timestamp, err := parseWebhookEventTimestamp(v.Timestamp)
+	if err != nil {
+		return err
+	}
+
+	var eventData WebhookEventData
+	switch v.Type {
+	case WebhookEventTypeApplicationAuthorized:
+		var d WebhookEventApplicationAuthorized
+		err = json.Unmarshal(v.Data, &d)
+		eventData = d
+
+	case WebhookEventTypeApplicationDeauthorized:
+		var d WebhookEventApplicationDeauthorized
+		err = json.Unmarshal(v.Data, &d)
+		eventData = d
+
+	case WebhookEventTypeEntitlementCreate:
+		var d WebhookEventEntitlementCreate
+		err = json.Unmarshal(v.Data, &d)
+		eventData = d
+
+	case WebhookEventTypeQuestUserEnrollment:
+		eventData = WebhookEventQuestUserEnrollment{Data: v.Data}
+
+	default:
+		eventData = WebhookEventUnknown{Data: v.Data}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal webhook event data of type %s: %w", v.Type, err)
+	}
+
+	e.Type = v.Type
+	e.Timestamp = timestamp
+	e.Data = eventData
+	return nil
+}
+
+// webhookEventTimestampLayout is the layout of WebhookEvent timestamps which are sent without a timezone in UTC
+const webhookEventTimestampLayout = "2006-01-02T15:04:05.999999999"
+
+func parseWebhookEventTimestamp(timestamp string) (time.Time, error) {
+	if timestamp == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339Nano, timestamp); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(webhookEventTimestampLayout, timestamp)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse webhook event timestamp: %w", err)
+	}
+	return t, nil
+}
+
+// WebhookEventData is the data of a WebhookEvent
+type WebhookEventData interface {
+	webhookEventData()
+}
+
+// WebhookEventApplicationAuthorized is sent when the app was added to a server or user account
+type WebhookEventApplicationAuthorized struct {
+	IntegrationType *discord.ApplicationIntegrationType `json:"integration_type"`
+	User            discord.User                        `json:"user"`
+	Scopes          []discord.OAuth2Scope               `json:"scopes"`
+	Guild           *discord.Guild                      `json:"guild"`
+}
+
+func (WebhookEventApplicationAuthorized) webhookEventData() {}
+
+// WebhookEventApplicationDeauthorized is sent when the app was deauthorized by a user
+type WebhookEventApplicationDeauthorized struct {
+	User discord.User `json:"user"`
+}
+
+func (WebhookEventApplicationDeauthorized) webhookEventData() {}
+
+// WebhookEventEntitlementCreate is sent when an entitlement was created
+type WebhookEventEntitlementCreate struct {
+	discord.Entitlement
+}
+
+func (WebhookEventEntitlementCreate) webhookEventData() {}
+
+// WebhookEventQuestUserEnrollment is sent when a user enrolled in a quest. Its data is not documented by Discord yet.
+type WebhookEventQuestUserEnrollment struct {
+	Data json.RawMessage
+}
+
+func (WebhookEventQuestUserEnrollment) webhookEventData() {}
+
+// WebhookEventUnknown is used for WebhookEventType(s) disgo does not know about yet
+type WebhookEventUnknown struct {
+	Data json.RawMessage
+}
+
+func (WebhookEventUnknown) webhookEventData() {}
+
+// HandleWebhookEvent handles webhook events from Discord. It verifies and parses the payload, acknowledges it and then calls the passed WebhookEventHandlerFunc.
+// The VerifierConfigOpt(s) configure the RequestVerifier used to verify the requests.
+func HandleWebhookEvent(publicKey PublicKey, logger *slog.Logger, handleFunc WebhookEventHandlerFunc, opts ...VerifierConfigOpt) http.HandlerFunc {
+	return handleWebhookEvent(NewRequestVerifier(publicKey, opts...), logger, handleFunc, nil)
+}
+
+// handleWebhookEvent is the implementation of HandleWebhookEvent. If inFlight is not nil, it tracks the running WebhookEventHandlerFunc(s).
+func handleWebhookEvent(verifier *RequestVerifier, logger *slog.Logger, handleFunc WebhookEventHandlerFunc, inFlight *sync.WaitGroup) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		rqData, err := verifier.Verify(r)
+		if err != nil {
+			logger.Debug("received webhook event which failed verification", slog.Any("err", err))
+			if errors.Is(err, ErrBodyTooLarge) {
+				http.Error(w, "Request Entity Too Large", http.StatusRequestEntityTooLarge)
+				return
+			}
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		logger.Debug("received webhook event", slog.String("body", string(rqData)))
+
+		var v WebhookEventPayload
+		if err = json.Unmarshal(rqData, &v); err != nil {
+			logger.Error("error while decoding webhook event", slog.Any("err", err))
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+
+		// discord expects a 204 within 3 seconds, so we acknowledge before handling the event
+		w.WriteHeader(http.StatusNoContent)
+
+		if v.Type == WebhookTypePing || v.Event == nil {
+			logger.Debug("received webhook event ping")
+			return
+		}
+
+		v.Event.ApplicationID = v.ApplicationID
+		if inFlight != nil {
+			inFlight.Add(1)
+		}
+		go func() {
+			if inFlight != nil {
+				defer inFlight.Done()
+			}
+			handleFunc(*v.Event)
+		}()
+	}
+}
@@ -0,0 +1,59 @@
+package httpserver
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func signedRequest(t *testing.T, privateKey ed25519.PrivateKey, body string) *http.Request {
+	t.Helper()
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	r := httptest.NewRequest(http.MethodPost, "/webhook-events", strings.NewReader(body))
+	r.Header.Set("X-Signature-Ed25519", hex.EncodeToString(ed25519.Sign(privateKey, []byte(timestamp+body))))
+	r.Header.Set("X-Signature-Timestamp", timestamp)
+	return r
+}
+
+func TestHandleWebhookEvent(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	assert.NoError(t, err)
+
+	events := make(chan WebhookEvent, 1)
+	handler := HandleWebhookEvent(publicKey, slog.Default(), func(event WebhookEvent) {
+		events <- event
+	})
+
+	rs := httptest.NewRecorder()
+	handler(rs, signedRequest(t, privateKey, `{"version":1,"application_id":"1","type":0}`))
+	assert.Equal(t, http.StatusNoContent, rs.Code)
+
+	rs = httptest.NewRecorder()
+	r := signedRequest(t, privateKey, `{"version":1,"application_id":"1","type":0}`)
+	r.Header.Set("X-Signature-Ed25519", hex.EncodeToString(ed25519.Sign(privateKey, []byte("0"))))
+	handler(rs, r)
+	assert.Equal(t, http.StatusUnauthorized, rs.Code)
+
+	rs = httptest.NewRecorder()
+	handler(rs, signedRequest(t, privateKey, `{"version":1,"application_id":"1","type":1,"event":{"type":"APPLICATION_AUTHORIZED","timestamp":"2024-10-18T14:42:53.064834","data":{"integration_type":1,"scopes":["applications.commands"],"user":{"id":"2","username":"test"}}}}`))
+	assert.Equal(t, http.StatusNoContent, rs.Code)
+
+	select {
+	case event := <-events:
+		assert.Equal(t, WebhookEventTypeApplicationAuthorized, event.Type)
+		if data, ok := event.Data.(WebhookEventApplicationAuthorized); assert.True(t, ok) {
+			assert.Equal(t, "test", data.User.Username)
+			assert.Len(t, data.Scopes, 1)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("webhook event was not handled")
+	}
+}
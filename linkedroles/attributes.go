@@ -0,0 +1,105 @@
+package linkedroles
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+type attributeKind int
+
+const (
+	attributeKindInteger attributeKind = iota + 1
+	attributeKindDateTime
+	attributeKindBoolean
+)
+
+func (k attributeKind) String() string {
+	switch k {
+	case attributeKindInteger:
+		return "integer"
+	case attributeKindDateTime:
+		return "datetime"
+	case attributeKindBoolean:
+		return "boolean"
+	default:
+		return "unknown"
+	}
+}
+
+func kindOf(metadataType discord.ApplicationRoleConnectionMetadataType) attributeKind {
+	switch metadataType {
+	case discord.ApplicationRoleConnectionMetadataTypeIntegerLessThanOrEqual,
+		discord.ApplicationRoleConnectionMetadataTypeIntegerGreaterThanOrEqual,
+		discord.ApplicationRoleConnectionMetadataTypeIntegerEqual,
+		discord.ApplicationRoleConnectionMetadataTypeIntegerNotEqual:
+		return attributeKindInteger
+	case discord.ApplicationRoleConnectionMetadataTypeDateTimeLessThanOrEqual,
+		discord.ApplicationRoleConnectionMetadataTypeDateTimeGreaterThanOrEqual:
+		return attributeKindDateTime
+	case discord.ApplicationRoleConnectionMetadataTypeBooleanEqual,
+		discord.ApplicationRoleConnectionMetadataTypeBooleanNotEqual:
+		return attributeKindBoolean
+	default:
+		return 0
+	}
+}
+
+type attribute struct {
+	kind  attributeKind
+	value string
+}
+
+// NewAttributes returns new empty Attributes.
+func NewAttributes() *Attributes {
+	return &Attributes{
+		values: map[string]attribute{},
+	}
+}
+
+// Attributes are the linked roles attributes of a single user.
+// Values are validated against the metadata definitions of the Manager before they are pushed to Discord.
+type Attributes struct {
+	// PlatformName is the vanity name of the platform shown in the user's profile
+	PlatformName string
+	// PlatformUsername is the username on the platform shown in the user's profile
+	PlatformUsername string
+
+	values map[string]attribute
+}
+
+// SetPlatform sets the PlatformName & PlatformUsername.
+func (a *Attributes) SetPlatform(name string, username string) *Attributes {
+	a.PlatformName = name
+	a.PlatformUsername = username
+	return a
+}
+
+// SetInt sets the value of an integer metadata.
+func (a *Attributes) SetInt(key string, value int64) *Attributes {
+	a.values[key] = attribute{kind: attributeKindInteger, value: strconv.FormatInt(value, 10)}
+	return a
+}
+
+// SetTime sets the value of a datetime metadata.
+func (a *Attributes) SetTime(key string, value time.Time) *Attributes {
+	a.values[key] = attribute{kind: attributeKindDateTime, value: value.UTC().Format(time.RFC3339)}
+	return a
+}
+
+// SetBool sets the value of a boolean metadata.
+func (a *Attributes) SetBool(key string, value bool) *Attributes {
+	v := "0"
+	if value {
+		v = "1"
+	}
+	a.values[key] = attribute{kind: attributeKindBoolean, value: v}
+	return a
+}
+
+// Delete removes the value of a metadata.
+func (a *Attributes) Delete(key string) *Attributes {
+	delete(a.values, key)
+	return a
+}
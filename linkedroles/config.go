@@ -0,0 +1,34 @@
+package linkedroles
+
+import (
+	"log/slog"
+)
+
+// DefaultConfig returns a Config with sensible defaults.
+func DefaultConfig() *Config {
+	return &Config{
+		Logger: slog.Default(),
+	}
+}
+
+// Config lets you configure your Manager instance.
+type Config struct {
+	Logger *slog.Logger
+}
+
+// ConfigOpt is a type alias for a function that takes a Config and is used to configure your Manager.
+type ConfigOpt func(config *Config)
+
+// Apply applies the given ConfigOpt(s) to the Config
+func (c *Config) Apply(opts []ConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithLogger sets the Logger of the Config.
+func WithLogger(logger *slog.Logger) ConfigOpt {
+	return func(config *Config) {
+		config.Logger = logger
+	}
+}
@@ -0,0 +1,237 @@
+package linkedroles
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"regexp"
+	"slices"
+
+	"github.com/disgoorg/json"
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/oauth2"
+	"github.com/disgoorg/disgo/rest"
+)
+
+// MaxMetadata is the maximum amount of metadata definitions an application can have
+const MaxMetadata = 5
+
+var (
+	// ErrTooManyMetadata is returned when more than MaxMetadata definitions are declared
+	ErrTooManyMetadata = fmt.Errorf("an application can have at most %d metadata definitions", MaxMetadata)
+
+	// ErrInvalidMetadataKey is returned when a metadata key is not 1-50 characters of a-z, 0-9 or _
+	ErrInvalidMetadataKey = errors.New("metadata keys must be 1-50 characters of a-z, 0-9 or _")
+
+	// ErrUnknownMetadata is returned when an attribute is set for a key without metadata definition
+	ErrUnknownMetadata = errors.New("unknown metadata key")
+
+	// ErrMetadataTypeMismatch is returned when the type of an attribute doesn't match its metadata definition
+	ErrMetadataTypeMismatch = errors.New("attribute type does not match metadata type")
+
+	// ErrSessionStoreNotListable is returned by Manager.PushAll when the oauth2.SessionStore doesn't implement ListableSessionStore
+	ErrSessionStoreNotListable = errors.New("session store does not implement linkedroles.ListableSessionStore")
+)
+
+var metadataKeyRegex = regexp.MustCompile(`^[a-z0-9_]{1,50}$`)
+
+// ListableSessionStore is an oauth2.SessionStore which can list the users with a stored oauth2.Session.
+// It's required by Manager.PushAll. The oauth2.SessionStore(s) of disgo implement it.
+type ListableSessionStore interface {
+	oauth2.SessionStore
+
+	// UserIDs returns the IDs of all users with a stored oauth2.Session.
+	UserIDs() ([]snowflake.ID, error)
+}
+
+// AttributeProvider returns the Attributes of the given user. Returning nil skips the user.
+type AttributeProvider func(ctx context.Context, userID snowflake.ID) (*Attributes, error)
+
+// Manager declares the linked roles metadata of an application once, syncs it to Discord & pushes the Attributes of users.
+type Manager interface {
+	// Metadata returns the declared metadata definitions.
+	Metadata() []discord.ApplicationRoleConnectionMetadata
+
+	// SyncMetadata compares the declared metadata definitions with the ones registered at Discord & only updates them if they differ.
+	// It returns whether the metadata definitions were updated.
+	SyncMetadata(ctx context.Context) (bool, error)
+
+	// Push pushes the Attributes of the given user returned by the AttributeProvider to Discord.
+	Push(ctx context.Context, userID snowflake.ID) error
+
+	// PushAll pushes the Attributes of all users with a Session in the oauth2.SessionStore of the oauth2.ManagedClient.
+	// The oauth2.SessionStore has to implement ListableSessionStore, otherwise ErrSessionStoreNotListable is returned.
+	// Users with a revoked Session are skipped. It returns the joined errors of all failed users.
+	PushAll(ctx context.Context) error
+
+	// RoleConnectionFunc returns an oauth2.RoleConnectionFunc which pushes the Attributes on login with oauth2.NewHTTPHandlers.
+	RoleConnectionFunc() oauth2.RoleConnectionFunc
+}
+
+// New returns a new Manager for the given application.
+// The rest.Applications is used to sync the metadata definitions & the oauth2.ManagedClient to push the Attributes of users.
+func New(applicationID snowflake.ID, applications rest.Applications, managedClient oauth2.ManagedClient, metadata []discord.ApplicationRoleConnectionMetadata, provider AttributeProvider, opts ...ConfigOpt) (Manager, error) {
+	config := DefaultConfig()
+	config.Apply(opts)
+	config.Logger = config.Logger.With(slog.String("name", "linkedroles"))
+
+	if len(metadata) > MaxMetadata {
+		return nil, ErrTooManyMetadata
+	}
+	kinds := make(map[string]attributeKind, len(metadata))
+	for _, m := range metadata {
+		if !metadataKeyRegex.MatchString(m.Key) {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidMetadataKey, m.Key)
+		}
+		kind := kindOf(m.Type)
+		if kind == 0 {
+			return nil, fmt.Errorf("unknown metadata type %d for key %q", m.Type, m.Key)
+		}
+		kinds[m.Key] = kind
+	}
+
+	return &managerImpl{
+		applicationID: applicationID,
+		applications:  applications,
+		managedClient: managedClient,
+		metadata:      metadata,
+		kinds:         kinds,
+		provider:      provider,
+		logger:        config.Logger,
+	}, nil
+}
+
+type managerImpl struct {
+	applicationID snowflake.ID
+	applications  rest.Applications
+	managedClient oauth2.ManagedClient
+	metadata      []discord.ApplicationRoleConnectionMetadata
+	kinds         map[string]attributeKind
+	provider      AttributeProvider
+	logger        *slog.Logger
+}
+
+func (m *managerImpl) Metadata() []discord.ApplicationRoleConnectionMetadata {
+	return slices.Clone(m.metadata)
+}
+
+func (m *managerImpl) SyncMetadata(ctx context.Context) (bool, error) {
+	current, err := m.applications.GetApplicationRoleConnectionMetadata(m.applicationID, rest.WithCtx(ctx))
+	if err != nil {
+		return false, err
+	}
+	if metadataEqual(current, m.metadata) {
+		m.logger.Debug("linked roles metadata is up to date")
+		return false, nil
+	}
+
+	m.logger.Debug("updating linked roles metadata", slog.Int("current", len(current)), slog.Int("declared", len(m.metadata)))
+	if _, err = m.applications.UpdateApplicationRoleConnectionMetadata(m.applicationID, m.metadata, rest.WithCtx(ctx)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (m *managerImpl) Push(ctx context.Context, userID snowflake.ID) error {
+	session, err := m.managedClient.Session(userID, rest.WithCtx(ctx))
+	if err != nil {
+		return err
+	}
+	update, err := m.roleConnectionUpdate(ctx, userID)
+	if err != nil || update == nil {
+		return err
+	}
+	_, err = m.managedClient.Client().UpdateApplicationRoleConnection(session, m.applicationID, *update, rest.WithCtx(ctx))
+	return err
+}
+
+func (m *managerImpl) PushAll(ctx context.Context) error {
+	sessionStore, ok := m.managedClient.SessionStore().(ListableSessionStore)
+	if !ok {
+		return ErrSessionStoreNotListable
+	}
+	userIDs, err := sessionStore.UserIDs()
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, userID := range userIDs {
+		if err = ctx.Err(); err != nil {
+			errs = append(errs, err)
+			break
+		}
+		if err = m.Push(ctx, userID); err != nil {
+			if errors.Is(err, oauth2.ErrSessionRevoked) || errors.Is(err, oauth2.ErrSessionNotFound) {
+				m.logger.Debug("skipping user without valid session", slog.String("user_id", userID.String()))
+				continue
+			}
+			errs = append(errs, fmt.Errorf("failed to push attributes of user %s: %w", userID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (m *managerImpl) RoleConnectionFunc() oauth2.RoleConnectionFunc {
+	return func(ctx context.Context, user *discord.OAuth2User, _ oauth2.Session) (*discord.ApplicationRoleConnectionUpdate, error) {
+		return m.roleConnectionUpdate(ctx, user.ID)
+	}
+}
+
+func (m *managerImpl) roleConnectionUpdate(ctx context.Context, userID snowflake.ID) (*discord.ApplicationRoleConnectionUpdate, error) {
+	attributes, err := m.provider(ctx, userID)
+	if err != nil || attributes == nil {
+		return nil, err
+	}
+
+	metadata := make(map[string]string, len(attributes.values))
+	for key, attr := range attributes.values {
+		kind, ok := m.kinds[key]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownMetadata, key)
+		}
+		if kind != attr.kind {
+			return nil, fmt.Errorf("%w: %q is %s but got %s", ErrMetadataTypeMismatch, key, kind, attr.kind)
+		}
+		metadata[key] = attr.value
+	}
+
+	update := &discord.ApplicationRoleConnectionUpdate{
+		// always send the metadata so removed attributes are cleared
+		Metadata: &metadata,
+	}
+	if attributes.PlatformName != "" {
+		update.PlatformName = json.Ptr(attributes.PlatformName)
+	}
+	if attributes.PlatformUsername != "" {
+		update.PlatformUsername = json.Ptr(attributes.PlatformUsername)
+	}
+	return update, nil
+}
+
+// metadataEqual returns whether both metadata definitions are equal regardless of their order.
+func metadataEqual(a []discord.ApplicationRoleConnectionMetadata, b []discord.ApplicationRoleConnectionMetadata) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	byKey := make(map[string]discord.ApplicationRoleConnectionMetadata, len(a))
+	for _, m := range a {
+		byKey[m.Key] = m
+	}
+	for _, m := range b {
+		other, ok := byKey[m.Key]
+		if !ok ||
+			other.Type != m.Type ||
+			other.Name != m.Name ||
+			other.Description != m.Description ||
+			!maps.Equal(other.NameLocalizations, m.NameLocalizations) ||
+			!maps.Equal(other.DescriptionLocalizations, m.DescriptionLocalizations) {
+			return false
+		}
+	}
+	return true
+}
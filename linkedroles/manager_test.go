@@ -0,0 +1,116 @@
+package linkedroles
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/oauth2"
+	"github.com/disgoorg/disgo/rest"
+)
+
+func TestManager(t *testing.T) {
+	var (
+		metadataUpdates int
+		registered      = `[{"type":2,"key":"cookies_eaten","name":"Cookies Eaten","description":"How many cookies have you eaten?"}]`
+		pushed          = map[string]string{}
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		body, _ := io.ReadAll(r.Body)
+		switch r.URL.Path {
+		case "/applications/1/role-connections/metadata":
+			if r.Method == http.MethodPut {
+				metadataUpdates++
+				registered = string(body)
+			}
+			_, _ = w.Write([]byte(registered))
+		case "/users/@me/applications/1/role-connection":
+			pushed[r.Header.Get("Authorization")] = string(body)
+			_, _ = w.Write(body)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	restClient := rest.NewClient("token", rest.WithURL(server.URL))
+	managedClient := oauth2.NewManagedClient(oauth2.New(1, "secret", oauth2.WithRestClient(restClient)))
+	for userID, token := range map[snowflake.ID]string{2: "user2", 3: "user3"} {
+		assert.NoError(t, managedClient.PutSession(userID, oauth2.Session{
+			AccessToken: token,
+			TokenType:   discord.TokenTypeBearer,
+			Scopes:      []discord.OAuth2Scope{discord.OAuth2ScopeRoleConnectionsWrite},
+			Expiration:  time.Now().Add(time.Hour),
+		}))
+	}
+	assert.NoError(t, managedClient.PutSession(4, oauth2.Session{Revoked: true}))
+
+	metadata := []discord.ApplicationRoleConnectionMetadata{
+		{
+			Type:        discord.ApplicationRoleConnectionMetadataTypeIntegerGreaterThanOrEqual,
+			Key:         "cookies_eaten",
+			Name:        "Cookies Eaten",
+			Description: "How many cookies have you eaten?",
+		},
+	}
+	cookies := int64(10)
+	provider := func(ctx context.Context, userID snowflake.ID) (*Attributes, error) {
+		return NewAttributes().SetPlatform("Cookies", userID.String()).SetInt("cookies_eaten", cookies), nil
+	}
+
+	manager, err := New(1, rest.NewApplications(restClient), managedClient, metadata, provider)
+	assert.NoError(t, err)
+
+	updated, err := manager.SyncMetadata(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, updated)
+	assert.Equal(t, 0, metadataUpdates)
+
+	metadata[0].Name = "Cookies"
+	manager, err = New(1, rest.NewApplications(restClient), managedClient, metadata, provider)
+	assert.NoError(t, err)
+	updated, err = manager.SyncMetadata(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, updated)
+	assert.Equal(t, 1, metadataUpdates)
+
+	cookies = 20
+	assert.NoError(t, manager.PushAll(context.Background()))
+	assert.Len(t, pushed, 2)
+	assert.JSONEq(t, `{"platform_name":"Cookies","platform_username":"2","metadata":{"cookies_eaten":"20"}}`, pushed["Bearer user2"])
+	assert.JSONEq(t, `{"platform_name":"Cookies","platform_username":"3","metadata":{"cookies_eaten":"20"}}`, pushed["Bearer user3"])
+
+	badManager, err := New(1, rest.NewApplications(restClient), managedClient, metadata, func(ctx context.Context, userID snowflake.ID) (*Attributes, error) {
+		return NewAttributes().SetBool("cookies_eaten", true), nil
+	})
+	assert.NoError(t, err)
+	assert.ErrorIs(t, badManager.Push(context.Background(), 2), ErrMetadataTypeMismatch)
+
+	_, err = New(1, nil, nil, []discord.ApplicationRoleConnectionMetadata{{Type: 1, Key: "Invalid Key"}}, provider)
+	assert.ErrorIs(t, err, ErrInvalidMetadataKey)
+}
+
+type unlistableSessionStore struct {
+	oauth2.SessionStore
+}
+
+func TestManager_PushAllUnlistable(t *testing.T) {
+	// the built-in session stores can be listed
+	_, ok := oauth2.NewMemorySessionStore().(ListableSessionStore)
+	assert.True(t, ok)
+
+	managedClient := oauth2.NewManagedClient(oauth2.New(1, "secret"), oauth2.WithSessionStore(unlistableSessionStore{SessionStore: oauth2.NewMemorySessionStore()}))
+	manager, err := New(1, nil, managedClient, nil, func(ctx context.Context, userID snowflake.ID) (*Attributes, error) {
+		return nil, nil
+	})
+	assert.NoError(t, err)
+	assert.ErrorIs(t, manager.PushAll(context.Background()), ErrSessionStoreNotListable)
+}
@@ -18,6 +18,12 @@ var (
 	// ErrSessionExpired is returned when the Session has expired.
 	ErrSessionExpired = errors.New("access token expired. refresh the session")
 
+	// ErrSessionNotFound is returned when no Session is stored for a user in the SessionStore.
+	ErrSessionNotFound = errors.New("session could not be found")
+
+	// ErrSessionRevoked is returned when the Session was revoked & the user needs to authorize again.
+	ErrSessionRevoked = errors.New("session revoked. the user needs to authorize again")
+
 	// ErrMissingOAuth2Scope is returned when a specific OAuth2 scope is missing.
 	ErrMissingOAuth2Scope = func(scope discord.OAuth2Scope) error {
 		return fmt.Errorf("missing '%s' scope", scope)
@@ -40,6 +46,9 @@ type Session struct {
 
 	// Expiration returns the time.Time when the AccessToken expires and needs to be refreshed
 	Expiration time.Time `json:"expiration"`
+
+	// Revoked is true when the Session could not be refreshed anymore & the user needs to authorize again
+	Revoked bool `json:"revoked,omitempty"`
 }
 
 func (s Session) Expired() bool {
@@ -53,6 +62,8 @@ type AuthorizationURLParams struct {
 	DisableGuildSelect bool
 	IntegrationType    discord.ApplicationIntegrationType
 	Scopes             []discord.OAuth2Scope
+	// StatePayload is embedded in the state if the StateController is a PayloadStateController
+	StatePayload []byte
 }
 
 // Client is a high level wrapper around Discord's OAuth2 API.
@@ -78,6 +89,11 @@ type Client interface {
 	RefreshSession(session Session, opts ...rest.RequestOpt) (Session, error)
 	// VerifySession verifies the given Session & refreshes it if needed.
 	VerifySession(session Session, opts ...rest.RequestOpt) (Session, error)
+	// RevokeSession revokes the given Session. Discord revokes the access token & refresh token of the Session.
+	RevokeSession(session Session, opts ...rest.RequestOpt) error
+	// StartClientCredentialsSession starts a new Session for the owner of the application with the client credentials grant.
+	// The Session has no refresh token & has to be started again once it expired.
+	StartClientCredentialsSession(scopes []discord.OAuth2Scope, opts ...rest.RequestOpt) (Session, error)
 
 	// GetUser returns the discord.OAuth2User associated with the given Session. Fields filled in the struct depend on the Session.Scopes.
 	GetUser(session Session, opts ...rest.RequestOpt) (*discord.OAuth2User, error)
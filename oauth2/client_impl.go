@@ -53,7 +53,12 @@ func (c *clientImpl) GenerateAuthorizationURL(params AuthorizationURLParams) str
 }
 
 func (c *clientImpl) GenerateAuthorizationURLState(params AuthorizationURLParams) (string, string) {
-	state := c.StateController().NewState(params.RedirectURI)
+	var state string
+	if payloadController, ok := c.StateController().(PayloadStateController); ok && params.StatePayload != nil {
+		state = payloadController.NewStateWithPayload(params.RedirectURI, params.StatePayload)
+	} else {
+		state = c.StateController().NewState(params.RedirectURI)
+	}
 	values := discord.QueryValues{
 		"client_id":     c.id,
 		"redirect_uri":  params.RedirectURI,
@@ -105,6 +110,21 @@ func (c *clientImpl) VerifySession(session Session, opts ...rest.RequestOpt) (Se
 	return session, nil
 }
 
+func (c *clientImpl) RevokeSession(session Session, opts ...rest.RequestOpt) error {
+	if session.RefreshToken != "" {
+		return c.Rest().RevokeToken(c.id, c.secret, session.RefreshToken, discord.TokenTypeHintRefreshToken, opts...)
+	}
+	return c.Rest().RevokeToken(c.id, c.secret, session.AccessToken, discord.TokenTypeHintAccessToken, opts...)
+}
+
+func (c *clientImpl) StartClientCredentialsSession(scopes []discord.OAuth2Scope, opts ...rest.RequestOpt) (Session, error) {
+	accessToken, err := c.Rest().GetClientCredentialsToken(c.id, c.secret, scopes, opts...)
+	if err != nil {
+		return Session{}, err
+	}
+	return newSession(*accessToken), nil
+}
+
 func (c *clientImpl) GetUser(session Session, opts ...rest.RequestOpt) (*discord.OAuth2User, error) {
 	if err := checkSession(session, discord.OAuth2ScopeIdentify); err != nil {
 		return nil, err
@@ -0,0 +1,187 @@
+package oauth2
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"unicode"
+
+	"github.com/disgoorg/disgo/rest"
+)
+
+// AuthorizationError is returned when Discord redirects back with an error, for example when the user denied the authorization.
+type AuthorizationError struct {
+	Code        string
+	Description string
+}
+
+func (e AuthorizationError) Error() string {
+	if e.Description != "" {
+		return fmt.Sprintf("authorization failed: %s: %s", e.Code, e.Description)
+	}
+	return "authorization failed: " + e.Code
+}
+
+// NewHTTPHandlers returns a login & callback http.HandlerFunc for the given Client.
+// The login handler redirects the user to Discord. The callback handler has to be served on the redirect uri & starts the Session.
+//
+// If the StateController is a PayloadStateController, a relative path passed as "redirect" query parameter to the login handler is
+// embedded in the state & the user is redirected there after a successful login.
+func NewHTTPHandlers(client Client, redirectURI string, opts ...HTTPHandlerConfigOpt) (login http.HandlerFunc, callback http.HandlerFunc) {
+	config := DefaultHTTPHandlerConfig()
+	config.Apply(opts)
+	config.Logger = config.Logger.With(slog.String("name", "oauth2_http_handler"))
+
+	h := &httpHandler{
+		client:      client,
+		redirectURI: redirectURI,
+		config:      *config,
+	}
+	return h.login, h.callback
+}
+
+type httpHandler struct {
+	client      Client
+	redirectURI string
+	config      HTTPHandlerConfig
+}
+
+func (h *httpHandler) login(w http.ResponseWriter, r *http.Request) {
+	params := h.config.AuthorizationURLParams
+	params.RedirectURI = h.redirectURI
+	params.Scopes = h.config.Scopes
+	params.StatePayload = nil
+	if returnPath := r.URL.Query().Get("redirect"); isRelativePath(returnPath) {
+		params.StatePayload = []byte(returnPath)
+	}
+
+	authURL, state := h.client.GenerateAuthorizationURLState(params)
+	if err := h.config.SessionBinder.BindState(w, r, state); err != nil {
+		h.error(w, r, err)
+		return
+	}
+	http.Redirect(w, r, authURL, http.StatusFound)
+}
+
+func (h *httpHandler) callback(w http.ResponseWriter, r *http.Request) {
+	var (
+		query = r.URL.Query()
+		code  = query.Get("code")
+		state = query.Get("state")
+	)
+	if errCode := query.Get("error"); errCode != "" {
+		h.error(w, r, AuthorizationError{Code: errCode, Description: query.Get("error_description")})
+		return
+	}
+	if code == "" || state == "" {
+		h.error(w, r, ErrStateNotFound)
+		return
+	}
+	if err := h.config.SessionBinder.VerifyState(w, r, state); err != nil {
+		h.error(w, r, err)
+		return
+	}
+
+	var statePayload []byte
+	if payloadController, ok := h.client.StateController().(PayloadStateController); ok {
+		// the payload has to be read before the state is used by StartSession
+		_, statePayload, _ = payloadController.UseStatePayload(state)
+	}
+
+	requestOpts := []rest.RequestOpt{rest.WithCtx(r.Context())}
+	session, webhook, err := h.client.StartSession(code, state, requestOpts...)
+	if err != nil {
+		h.error(w, r, err)
+		return
+	}
+	user, err := h.client.GetUser(session, requestOpts...)
+	if err != nil {
+		h.error(w, r, err)
+		return
+	}
+
+	if h.config.ManagedClient != nil {
+		if err = h.config.ManagedClient.PutSession(user.ID, session); err != nil {
+			h.error(w, r, err)
+			return
+		}
+	}
+
+	if h.config.RoleConnectionFunc != nil {
+		update, err := h.config.RoleConnectionFunc(r.Context(), user, session)
+		if err != nil {
+			h.error(w, r, err)
+			return
+		}
+		if update != nil {
+			if _, err = h.client.UpdateApplicationRoleConnection(session, h.client.ID(), *update, requestOpts...); err != nil {
+				h.error(w, r, err)
+				return
+			}
+		}
+	}
+
+	if err = h.config.SessionBinder.BindSession(w, r, user.ID); err != nil {
+		h.error(w, r, err)
+		return
+	}
+
+	if h.config.OnLogin != nil {
+		if err = h.config.OnLogin(w, r, LoginResult{
+			User:         user,
+			Session:      session,
+			Webhook:      webhook,
+			StatePayload: statePayload,
+		}); err != nil {
+			h.error(w, r, err)
+			return
+		}
+	}
+
+	h.config.Logger.Debug("user logged in", slog.String("user_id", user.ID.String()))
+	if returnPath := string(statePayload); isRelativePath(returnPath) {
+		http.Redirect(w, r, returnPath, http.StatusFound)
+		return
+	}
+	if h.config.SuccessURL != "" {
+		http.Redirect(w, r, h.config.SuccessURL, http.StatusFound)
+	}
+}
+
+func (h *httpHandler) error(w http.ResponseWriter, r *http.Request, err error) {
+	h.config.Logger.Debug("login failed", slog.Any("err", err))
+	h.config.ErrorHandler(w, r, err)
+}
+
+// isRelativePath returns true if the path is relative to the current host to prevent open redirects.
+// Browsers ignore control characters & whitespace and treat backslashes like slashes, so paths containing them are rejected.
+func isRelativePath(path string) bool {
+	if path == "" || path[0] != '/' {
+		return false
+	}
+	for _, c := range path {
+		if c < 0x20 || c == 0x7F || c == '\\' || unicode.IsSpace(c) {
+			return false
+		}
+	}
+	u, err := url.Parse(path)
+	if err != nil || u.Scheme != "" || u.Host != "" || u.User != nil {
+		return false
+	}
+	return strings.HasPrefix(u.Path, "/") && !strings.HasPrefix(u.Path, "//")
+}
+
+func defaultErrorHandler(w http.ResponseWriter, _ *http.Request, err error) {
+	var authErr AuthorizationError
+	switch {
+	case errors.As(err, &authErr):
+		http.Error(w, "Authorization failed: "+authErr.Code, http.StatusBadRequest)
+	case errors.Is(err, ErrStateNotFound), errors.Is(err, ErrStateMismatch):
+		http.Error(w, "Invalid or expired login attempt. Please try again.", http.StatusBadRequest)
+	default:
+		http.Error(w, "Login failed. Please try again later.", http.StatusInternalServerError)
+	}
+}
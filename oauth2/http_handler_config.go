@@ -0,0 +1,137 @@
+package oauth2
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+// LoginResult is passed to the OnLoginFunc after a successful login.
+type LoginResult struct {
+	User    *discord.OAuth2User
+	Session Session
+	// Webhook is only present if the scopes include the discord.OAuth2ScopeWebhookIncoming
+	Webhook *discord.IncomingWebhook
+	// StatePayload is the payload embedded in the state if the StateController is a PayloadStateController
+	StatePayload []byte
+}
+
+// OnLoginFunc is called after a successful login. Returning an error renders the error page.
+type OnLoginFunc func(w http.ResponseWriter, r *http.Request, result LoginResult) error
+
+// ErrorHandlerFunc renders the error page of a failed login.
+type ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+
+// RoleConnectionFunc returns the linked roles metadata which is pushed after a successful login. Returning nil skips the push.
+type RoleConnectionFunc func(ctx context.Context, user *discord.OAuth2User, session Session) (*discord.ApplicationRoleConnectionUpdate, error)
+
+// DefaultHTTPHandlerConfig is the default configuration for the HTTP handlers
+func DefaultHTTPHandlerConfig() *HTTPHandlerConfig {
+	return &HTTPHandlerConfig{
+		Logger:       slog.Default(),
+		Scopes:       []discord.OAuth2Scope{discord.OAuth2ScopeIdentify},
+		SuccessURL:   "/",
+		ErrorHandler: defaultErrorHandler,
+	}
+}
+
+// HTTPHandlerConfig is the configuration for the HTTP handlers returned by NewHTTPHandlers
+type HTTPHandlerConfig struct {
+	Logger *slog.Logger
+	// Scopes are requested on login. discord.OAuth2ScopeIdentify is always requested.
+	Scopes []discord.OAuth2Scope
+	// AuthorizationURLParams are used as template for the authorization url. RedirectURI, Scopes & StatePayload are overwritten.
+	AuthorizationURLParams AuthorizationURLParams
+	SessionBinder          SessionBinder
+	// ManagedClient persists the Session of the user if set
+	ManagedClient ManagedClient
+	// SuccessURL is where the user is redirected after a successful login. If it's empty, the OnLoginFunc has to write the response.
+	SuccessURL         string
+	OnLogin            OnLoginFunc
+	ErrorHandler       ErrorHandlerFunc
+	RoleConnectionFunc RoleConnectionFunc
+}
+
+// HTTPHandlerConfigOpt is used to pass optional parameters to NewHTTPHandlers
+type HTTPHandlerConfigOpt func(config *HTTPHandlerConfig)
+
+// Apply applies the given HTTPHandlerConfigOpt(s) to the HTTPHandlerConfig
+func (c *HTTPHandlerConfig) Apply(opts []HTTPHandlerConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.SessionBinder == nil {
+		c.SessionBinder = NewCookieSessionBinder(nil)
+	}
+	if !discord.HasScope(discord.OAuth2ScopeIdentify, c.Scopes...) {
+		c.Scopes = append([]discord.OAuth2Scope{discord.OAuth2ScopeIdentify}, c.Scopes...)
+	}
+	if c.RoleConnectionFunc != nil && !discord.HasScope(discord.OAuth2ScopeRoleConnectionsWrite, c.Scopes...) {
+		c.Scopes = append(c.Scopes, discord.OAuth2ScopeRoleConnectionsWrite)
+	}
+}
+
+// WithHTTPHandlerLogger sets the logger of the HTTP handlers
+func WithHTTPHandlerLogger(logger *slog.Logger) HTTPHandlerConfigOpt {
+	return func(config *HTTPHandlerConfig) {
+		config.Logger = logger
+	}
+}
+
+// WithScopes sets the scopes requested on login
+func WithScopes(scopes ...discord.OAuth2Scope) HTTPHandlerConfigOpt {
+	return func(config *HTTPHandlerConfig) {
+		config.Scopes = scopes
+	}
+}
+
+// WithAuthorizationURLParams sets the template for the authorization url
+func WithAuthorizationURLParams(params AuthorizationURLParams) HTTPHandlerConfigOpt {
+	return func(config *HTTPHandlerConfig) {
+		config.AuthorizationURLParams = params
+	}
+}
+
+// WithSessionBinder sets the SessionBinder which binds the login to the browser
+func WithSessionBinder(sessionBinder SessionBinder) HTTPHandlerConfigOpt {
+	return func(config *HTTPHandlerConfig) {
+		config.SessionBinder = sessionBinder
+	}
+}
+
+// WithManagedClient sets the ManagedClient which persists the Session of the user
+func WithManagedClient(managedClient ManagedClient) HTTPHandlerConfigOpt {
+	return func(config *HTTPHandlerConfig) {
+		config.ManagedClient = managedClient
+	}
+}
+
+// WithSuccessURL sets where the user is redirected after a successful login
+func WithSuccessURL(successURL string) HTTPHandlerConfigOpt {
+	return func(config *HTTPHandlerConfig) {
+		config.SuccessURL = successURL
+	}
+}
+
+// WithOnLogin sets the OnLoginFunc which is called after a successful login
+func WithOnLogin(onLogin OnLoginFunc) HTTPHandlerConfigOpt {
+	return func(config *HTTPHandlerConfig) {
+		config.OnLogin = onLogin
+	}
+}
+
+// WithErrorHandler sets the ErrorHandlerFunc which renders the error page
+func WithErrorHandler(errorHandler ErrorHandlerFunc) HTTPHandlerConfigOpt {
+	return func(config *HTTPHandlerConfig) {
+		config.ErrorHandler = errorHandler
+	}
+}
+
+// WithRoleConnectionFunc enables pushing linked roles metadata after a successful login
+func WithRoleConnectionFunc(roleConnectionFunc RoleConnectionFunc) HTTPHandlerConfigOpt {
+	return func(config *HTTPHandlerConfig) {
+		config.RoleConnectionFunc = roleConnectionFunc
+	}
+}
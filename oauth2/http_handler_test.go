@@ -0,0 +1,156 @@
+package oauth2
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/disgoorg/json"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/rest"
+)
+
+func TestHTTPHandlers(t *testing.T) {
+	var roleConnection string
+	discordAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/oauth2/token":
+			_ = r.ParseForm()
+			if r.PostForm.Get("code") != "valid" {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"error":"invalid_grant"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"access_token":"access","token_type":"Bearer","expires_in":604800,"refresh_token":"refresh","scope":"identify role_connections.write"}`))
+		case "/users/@me":
+			_, _ = w.Write([]byte(`{"id":"2","username":"test","discriminator":"0"}`))
+		case "/users/@me/applications/1/role-connection":
+			body, _ := io.ReadAll(r.Body)
+			roleConnection = string(body)
+			_, _ = w.Write(body)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer discordAPI.Close()
+
+	stateController, err := NewSignedStateController(WithSigningKeys([]byte("key")))
+	if !assert.NoError(t, err) {
+		return
+	}
+	client := New(1, "secret",
+		WithRestClientConfigOpts(rest.WithURL(discordAPI.URL)),
+		WithStateController(stateController),
+	)
+	binder := NewCookieSessionBinder([]byte("session-key"))
+	var loginResult LoginResult
+	login, callback := NewHTTPHandlers(client, "https://example.com/callback",
+		WithSessionBinder(binder),
+		WithOnLogin(func(w http.ResponseWriter, r *http.Request, result LoginResult) error {
+			loginResult = result
+			return nil
+		}),
+		WithRoleConnectionFunc(func(ctx context.Context, user *discord.OAuth2User, session Session) (*discord.ApplicationRoleConnectionUpdate, error) {
+			return &discord.ApplicationRoleConnectionUpdate{PlatformName: json.Ptr("disgo " + user.Username)}, nil
+		}),
+	)
+
+	startLogin := func() (string, []*http.Cookie) {
+		rs := httptest.NewRecorder()
+		login(rs, httptest.NewRequest(http.MethodGet, "/login?redirect=/dashboard", nil))
+		assert.Equal(t, http.StatusFound, rs.Code)
+		location, err := url.Parse(rs.Header().Get("Location"))
+		assert.NoError(t, err)
+		assert.Equal(t, "https://example.com/callback", location.Query().Get("redirect_uri"))
+		assert.Contains(t, location.Query().Get("scope"), string(discord.OAuth2ScopeRoleConnectionsWrite))
+		return location.Query().Get("state"), rs.Result().Cookies()
+	}
+	doCallback := func(query string, cookies []*http.Cookie) *httptest.ResponseRecorder {
+		rq := httptest.NewRequest(http.MethodGet, "/callback?"+query, nil)
+		for _, cookie := range cookies {
+			rq.AddCookie(cookie)
+		}
+		rs := httptest.NewRecorder()
+		callback(rs, rq)
+		return rs
+	}
+
+	t.Run("success", func(t *testing.T) {
+		state, cookies := startLogin()
+		rs := doCallback("code=valid&state="+url.QueryEscape(state), cookies)
+		assert.Equal(t, http.StatusFound, rs.Code)
+		assert.Equal(t, "/dashboard", rs.Header().Get("Location"))
+		assert.JSONEq(t, `{"platform_name":"disgo test"}`, roleConnection)
+		assert.Equal(t, "access", loginResult.Session.AccessToken)
+		assert.Equal(t, "2", loginResult.User.ID.String())
+
+		rq := httptest.NewRequest(http.MethodGet, "/", nil)
+		for _, cookie := range rs.Result().Cookies() {
+			rq.AddCookie(cookie)
+		}
+		userID, err := binder.UserID(rq)
+		assert.NoError(t, err)
+		assert.Equal(t, "2", userID.String())
+	})
+
+	t.Run("state of another browser", func(t *testing.T) {
+		state, _ := startLogin()
+		_, cookies := startLogin()
+		rs := doCallback("code=valid&state="+url.QueryEscape(state), cookies)
+		assert.Equal(t, http.StatusBadRequest, rs.Code)
+	})
+
+	t.Run("denied", func(t *testing.T) {
+		rs := doCallback("error=access_denied", nil)
+		assert.Equal(t, http.StatusBadRequest, rs.Code)
+	})
+
+	t.Run("invalid code", func(t *testing.T) {
+		state, cookies := startLogin()
+		rs := doCallback("code=invalid&state="+url.QueryEscape(state), cookies)
+		assert.Equal(t, http.StatusInternalServerError, rs.Code)
+	})
+}
+
+func TestIsRelativePath(t *testing.T) {
+	data := []struct {
+		path     string
+		expected bool
+	}{
+		{"/", true},
+		{"/dashboard", true},
+		{"/dashboard?tab=1#top", true},
+		{"/a/b/../c", true},
+		{"", false},
+		{"dashboard", false},
+		{"//evil.com", false},
+		{"/\\evil.com", false},
+		{"/\t/evil.com", false},
+		{"/\n/evil.com", false},
+		{"/\r/evil.com", false},
+		{"/ /evil.com", false},
+		{"/\x00/evil.com", false},
+		{"/\x7f/evil.com", false},
+		{"/\u00a0/evil.com", false},
+		{"/\u3000/evil.com", false},
+		{"/%2F/evil.com", false},
+		{"/%2f%2fevil.com", false},
+		{"/dashboard\\..\\..\\evil.com", false},
+		{"https://evil.com", false},
+		{"javascript:alert(1)", false},
+		{"/%zz", false},
+	}
+	for _, d := range data {
+		assert.Equal(t, d.expected, isRelativePath(d.path), "path %q", d.path)
+	}
+
+	// the tab is decoded from the query & must not pass
+	rq := httptest.NewRequest(http.MethodGet, "/login?redirect=/%09/evil.com", nil)
+	assert.False(t, isRelativePath(rq.URL.Query().Get("redirect")))
+}
@@ -0,0 +1,214 @@
+package oauth2
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/rest"
+)
+
+var _ ManagedClient = (*managedClientImpl)(nil)
+
+// ManagedClient wraps a Client & persists the Session(s) of users in a SessionStore.
+// Session(s) are transparently refreshed shortly before they expire & concurrent refreshes of the same Session are serialized.
+type ManagedClient interface {
+	// Client returns the underlying Client.
+	Client() Client
+	// SessionStore returns the configured SessionStore.
+	SessionStore() SessionStore
+
+	// StartSession starts a new Session with the given authorization code & state and stores it for the authorized user.
+	// This requires the discord.OAuth2ScopeIdentify scope.
+	StartSession(code string, state string, opts ...rest.RequestOpt) (*discord.OAuth2User, Session, *discord.IncomingWebhook, error)
+	// PutSession stores the Session for the given user.
+	PutSession(userID snowflake.ID, session Session) error
+	// Session returns the stored Session of the given user & refreshes it if it expires soon.
+	// It returns ErrSessionNotFound if no Session is stored & ErrSessionRevoked if the Session can't be refreshed anymore.
+	Session(userID snowflake.ID, opts ...rest.RequestOpt) (Session, error)
+	// RefreshSession refreshes the stored Session of the given user regardless of its expiration.
+	RefreshSession(userID snowflake.ID, opts ...rest.RequestOpt) (Session, error)
+	// DeleteSession removes the stored Session of the given user.
+	DeleteSession(userID snowflake.ID) error
+	// RevokeSession revokes the stored Session of the given user at Discord & removes it from the SessionStore.
+	RevokeSession(userID snowflake.ID, opts ...rest.RequestOpt) error
+}
+
+// NewManagedClient returns a new ManagedClient wrapping the given Client with the given ManagedClientConfigOpt(s).
+func NewManagedClient(client Client, opts ...ManagedClientConfigOpt) ManagedClient {
+	config := DefaultManagedClientConfig()
+	config.Apply(opts)
+	config.Logger = config.Logger.With(slog.String("name", "oauth2_managed_client"))
+
+	return &managedClientImpl{
+		client:        client,
+		sessionStore:  config.SessionStore,
+		refreshBefore: config.RefreshBefore,
+		logger:        config.Logger,
+		locks:         map[snowflake.ID]*sessionLock{},
+	}
+}
+
+type sessionLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+type managedClientImpl struct {
+	client        Client
+	sessionStore  SessionStore
+	refreshBefore time.Duration
+	logger        *slog.Logger
+
+	locksMu sync.Mutex
+	locks   map[snowflake.ID]*sessionLock
+}
+
+func (c *managedClientImpl) Client() Client {
+	return c.client
+}
+
+func (c *managedClientImpl) SessionStore() SessionStore {
+	return c.sessionStore
+}
+
+func (c *managedClientImpl) StartSession(code string, state string, opts ...rest.RequestOpt) (*discord.OAuth2User, Session, *discord.IncomingWebhook, error) {
+	session, webhook, err := c.client.StartSession(code, state, opts...)
+	if err != nil {
+		return nil, Session{}, nil, err
+	}
+	user, err := c.client.GetUser(session, opts...)
+	if err != nil {
+		return nil, Session{}, nil, err
+	}
+	if err = c.PutSession(user.ID, session); err != nil {
+		return nil, Session{}, nil, err
+	}
+	return user, session, webhook, nil
+}
+
+func (c *managedClientImpl) PutSession(userID snowflake.ID, session Session) error {
+	unlock := c.lock(userID)
+	defer unlock()
+	return c.sessionStore.Put(userID, session)
+}
+
+func (c *managedClientImpl) Session(userID snowflake.ID, opts ...rest.RequestOpt) (Session, error) {
+	session, err := c.sessionStore.Get(userID)
+	if err != nil {
+		return Session{}, err
+	}
+	if session.Revoked {
+		return Session{}, ErrSessionRevoked
+	}
+	if !c.expiresSoon(session) {
+		return session, nil
+	}
+	return c.refresh(userID, false, opts...)
+}
+
+func (c *managedClientImpl) RefreshSession(userID snowflake.ID, opts ...rest.RequestOpt) (Session, error) {
+	return c.refresh(userID, true, opts...)
+}
+
+func (c *managedClientImpl) DeleteSession(userID snowflake.ID) error {
+	unlock := c.lock(userID)
+	defer unlock()
+	return c.sessionStore.Delete(userID)
+}
+
+func (c *managedClientImpl) RevokeSession(userID snowflake.ID, opts ...rest.RequestOpt) error {
+	unlock := c.lock(userID)
+	defer unlock()
+
+	session, err := c.sessionStore.Get(userID)
+	if err != nil {
+		return err
+	}
+	// revoked sessions are already invalid at Discord
+	if !session.Revoked {
+		if err = c.client.RevokeSession(session, opts...); err != nil {
+			return err
+		}
+	}
+	return c.sessionStore.Delete(userID)
+}
+
+func (c *managedClientImpl) refresh(userID snowflake.ID, force bool, opts ...rest.RequestOpt) (Session, error) {
+	unlock := c.lock(userID)
+	defer unlock()
+
+	// the session might have been refreshed while we were waiting for the lock
+	session, err := c.sessionStore.Get(userID)
+	if err != nil {
+		return Session{}, err
+	}
+	if session.Revoked {
+		return Session{}, ErrSessionRevoked
+	}
+	if !force && !c.expiresSoon(session) {
+		return session, nil
+	}
+
+	c.logger.Debug("refreshing session", slog.String("user_id", userID.String()))
+	newSession, err := c.client.RefreshSession(session, opts...)
+	if err != nil {
+		if !isInvalidGrant(err) {
+			return Session{}, err
+		}
+		c.logger.Debug("session was revoked", slog.String("user_id", userID.String()), slog.Any("err", err))
+		session.Revoked = true
+		if err = c.sessionStore.Put(userID, session); err != nil {
+			return Session{}, err
+		}
+		return Session{}, ErrSessionRevoked
+	}
+
+	if err = c.sessionStore.Put(userID, newSession); err != nil {
+		return Session{}, err
+	}
+	return newSession, nil
+}
+
+func (c *managedClientImpl) expiresSoon(session Session) bool {
+	return time.Until(session.Expiration) < c.refreshBefore
+}
+
+// lock locks the Session of the given user & returns a function to unlock it again.
+func (c *managedClientImpl) lock(userID snowflake.ID) func() {
+	c.locksMu.Lock()
+	l, ok := c.locks[userID]
+	if !ok {
+		l = &sessionLock{}
+		c.locks[userID] = l
+	}
+	l.refs++
+	c.locksMu.Unlock()
+
+	l.mu.Lock()
+	return func() {
+		l.mu.Unlock()
+
+		c.locksMu.Lock()
+		l.refs--
+		if l.refs == 0 {
+			delete(c.locks, userID)
+		}
+		c.locksMu.Unlock()
+	}
+}
+
+// isInvalidGrant returns true if the error means the refresh token is not valid anymore.
+// Other errors like network failures or rate limits are transient & don't revoke the Session.
+func isInvalidGrant(err error) bool {
+	var restErr rest.Error
+	if !errors.As(err, &restErr) || restErr.Response == nil {
+		return false
+	}
+	return restErr.Response.StatusCode == http.StatusBadRequest || restErr.Response.StatusCode == http.StatusUnauthorized
+}
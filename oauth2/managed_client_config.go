@@ -0,0 +1,56 @@
+package oauth2
+
+import (
+	"log/slog"
+	"time"
+)
+
+// DefaultManagedClientConfig is the default configuration for the ManagedClient
+func DefaultManagedClientConfig() *ManagedClientConfig {
+	return &ManagedClientConfig{
+		Logger:        slog.Default(),
+		RefreshBefore: time.Minute,
+	}
+}
+
+// ManagedClientConfig is the configuration for the ManagedClient
+type ManagedClientConfig struct {
+	Logger       *slog.Logger
+	SessionStore SessionStore
+	// RefreshBefore is how long before the Session.Expiration the Session is refreshed
+	RefreshBefore time.Duration
+}
+
+// ManagedClientConfigOpt is used to pass optional parameters to NewManagedClient
+type ManagedClientConfigOpt func(config *ManagedClientConfig)
+
+// Apply applies the given ManagedClientConfigOpt(s) to the ManagedClientConfig
+func (c *ManagedClientConfig) Apply(opts []ManagedClientConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.SessionStore == nil {
+		c.SessionStore = NewMemorySessionStore()
+	}
+}
+
+// WithManagedClientLogger sets the logger for the ManagedClient
+func WithManagedClientLogger(logger *slog.Logger) ManagedClientConfigOpt {
+	return func(config *ManagedClientConfig) {
+		config.Logger = logger
+	}
+}
+
+// WithSessionStore sets the SessionStore the ManagedClient persists Session(s) in
+func WithSessionStore(sessionStore SessionStore) ManagedClientConfigOpt {
+	return func(config *ManagedClientConfig) {
+		config.SessionStore = sessionStore
+	}
+}
+
+// WithRefreshBefore sets how long before the Session.Expiration the Session is refreshed
+func WithRefreshBefore(refreshBefore time.Duration) ManagedClientConfigOpt {
+	return func(config *ManagedClientConfig) {
+		config.RefreshBefore = refreshBefore
+	}
+}
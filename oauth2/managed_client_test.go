@@ -0,0 +1,120 @@
+package oauth2
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/rest"
+)
+
+func TestManagedClient_Session(t *testing.T) {
+	var (
+		refreshes    atomic.Int32
+		invalidGrant atomic.Bool
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if invalidGrant.Load() {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"invalid_grant"}`))
+			return
+		}
+		refreshes.Add(1)
+		// give concurrent callers time to pile up
+		time.Sleep(20 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"new","token_type":"Bearer","expires_in":604800,"refresh_token":"refresh2","scope":"identify"}`))
+	}))
+	defer server.Close()
+
+	client := New(1, "secret", WithRestClientConfigOpts(rest.WithURL(server.URL)))
+	managed := NewManagedClient(client)
+	userID := snowflake.ID(2)
+
+	_, err := managed.Session(userID)
+	assert.ErrorIs(t, err, ErrSessionNotFound)
+
+	assert.NoError(t, managed.PutSession(userID, Session{
+		AccessToken:  "old",
+		RefreshToken: "refresh1",
+		Scopes:       []discord.OAuth2Scope{discord.OAuth2ScopeIdentify},
+		Expiration:   time.Now().Add(30 * time.Second),
+	}))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			session, err := managed.Session(userID)
+			assert.NoError(t, err)
+			assert.Equal(t, "new", session.AccessToken)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), refreshes.Load())
+
+	invalidGrant.Store(true)
+	_, err = managed.RefreshSession(userID)
+	assert.ErrorIs(t, err, ErrSessionRevoked)
+	_, err = managed.Session(userID)
+	assert.ErrorIs(t, err, ErrSessionRevoked)
+
+	session, err := managed.SessionStore().Get(userID)
+	assert.NoError(t, err)
+	assert.True(t, session.Revoked)
+}
+
+func TestFileSessionStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sessions.json")
+	store, err := NewFileSessionStore(path)
+	assert.NoError(t, err)
+
+	session := Session{AccessToken: "token", RefreshToken: "refresh", Expiration: time.Now().Add(time.Hour).Round(0)}
+	assert.NoError(t, store.Put(1, session))
+	assert.NoError(t, store.Put(2, session))
+	assert.NoError(t, store.Delete(2))
+
+	store, err = NewFileSessionStore(path)
+	assert.NoError(t, err)
+	loaded, err := store.Get(1)
+	assert.NoError(t, err)
+	assert.Equal(t, session.AccessToken, loaded.AccessToken)
+	assert.True(t, session.Expiration.Equal(loaded.Expiration))
+	_, err = store.Get(2)
+	assert.ErrorIs(t, err, ErrSessionNotFound)
+}
+
+func TestManagedClient_RevokeSession(t *testing.T) {
+	var (
+		path  string
+		token string
+		hint  string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		path, token, hint = r.URL.Path, r.PostForm.Get("token"), r.PostForm.Get("token_type_hint")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	managed := NewManagedClient(New(1, "secret", WithRestClientConfigOpts(rest.WithURL(server.URL))))
+	assert.NoError(t, managed.PutSession(2, Session{AccessToken: "access", RefreshToken: "refresh", Expiration: time.Now().Add(time.Hour)}))
+
+	assert.NoError(t, managed.RevokeSession(2))
+	assert.Equal(t, "/oauth2/token/revoke", path)
+	assert.Equal(t, "refresh", token)
+	assert.Equal(t, string(discord.TokenTypeHintRefreshToken), hint)
+
+	_, err := managed.Session(2)
+	assert.ErrorIs(t, err, ErrSessionNotFound)
+}
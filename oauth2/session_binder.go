@@ -0,0 +1,140 @@
+package oauth2
+
+import (
+	"crypto/hmac"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+)
+
+var (
+	_ SessionBinder = (*CookieSessionBinder)(nil)
+
+	// ErrStateMismatch is returned when the state of a callback was not issued to the same browser.
+	ErrStateMismatch = errors.New("state does not belong to this browser")
+
+	// ErrNoBoundSession is returned when the request carries no valid session cookie.
+	ErrNoBoundSession = errors.New("no session bound to this browser")
+)
+
+// SessionBinder binds the OAuth2 flow & the resulting session to the browser of the user.
+type SessionBinder interface {
+	// BindState is called by the login handler to bind the state to the browser.
+	BindState(w http.ResponseWriter, r *http.Request, state string) error
+
+	// VerifyState is called by the callback handler & returns an error if the state was not bound to this browser.
+	VerifyState(w http.ResponseWriter, r *http.Request, state string) error
+
+	// BindSession is called by the callback handler after a successful login of the given user.
+	BindSession(w http.ResponseWriter, r *http.Request, userID snowflake.ID) error
+}
+
+// NewCookieSessionBinder returns a new CookieSessionBinder with sensible defaults.
+// The key signs the session cookie. If it's nil, no session cookie is set.
+func NewCookieSessionBinder(key []byte) *CookieSessionBinder {
+	return &CookieSessionBinder{
+		StateCookieName:   "disgo_oauth2_state",
+		SessionCookieName: "disgo_oauth2_session",
+		Key:               key,
+		Secure:            true,
+		StateMaxAge:       10 * time.Minute,
+		SessionMaxAge:     7 * 24 * time.Hour,
+	}
+}
+
+// CookieSessionBinder is a SessionBinder which stores the state in a short-lived cookie & the user ID in a HMAC signed cookie.
+type CookieSessionBinder struct {
+	StateCookieName   string
+	SessionCookieName string
+	Key               []byte
+	Secure            bool
+	StateMaxAge       time.Duration
+	SessionMaxAge     time.Duration
+}
+
+func (b *CookieSessionBinder) BindState(w http.ResponseWriter, _ *http.Request, state string) error {
+	http.SetCookie(w, b.cookie(b.StateCookieName, state, b.StateMaxAge))
+	return nil
+}
+
+func (b *CookieSessionBinder) VerifyState(w http.ResponseWriter, r *http.Request, state string) error {
+	cookie, err := r.Cookie(b.StateCookieName)
+	if err != nil || !hmac.Equal([]byte(cookie.Value), []byte(state)) {
+		return ErrStateMismatch
+	}
+	// the state can only be used once
+	http.SetCookie(w, b.cookie(b.StateCookieName, "", -1))
+	return nil
+}
+
+func (b *CookieSessionBinder) BindSession(w http.ResponseWriter, _ *http.Request, userID snowflake.ID) error {
+	if b.Key == nil {
+		return nil
+	}
+	value := userID.String() + "." + strconv.FormatInt(time.Now().Add(b.SessionMaxAge).Unix(), 10)
+	value += "." + base64.RawURLEncoding.EncodeToString(signState(b.Key, value))
+	http.SetCookie(w, b.cookie(b.SessionCookieName, value, b.SessionMaxAge))
+	return nil
+}
+
+// UserID returns the ID of the user bound to the browser by BindSession or ErrNoBoundSession.
+func (b *CookieSessionBinder) UserID(r *http.Request) (snowflake.ID, error) {
+	if b.Key == nil {
+		return 0, ErrNoBoundSession
+	}
+	cookie, err := r.Cookie(b.SessionCookieName)
+	if err != nil {
+		return 0, ErrNoBoundSession
+	}
+
+	i := strings.LastIndexByte(cookie.Value, '.')
+	if i < 0 {
+		return 0, ErrNoBoundSession
+	}
+	value, signature := cookie.Value[:i], cookie.Value[i+1:]
+	sig, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(signState(b.Key, value), sig) {
+		return 0, ErrNoBoundSession
+	}
+
+	rawUserID, rawExpiresAt, ok := strings.Cut(value, ".")
+	if !ok {
+		return 0, ErrNoBoundSession
+	}
+	expiresAt, err := strconv.ParseInt(rawExpiresAt, 10, 64)
+	if err != nil || time.Now().Unix() > expiresAt {
+		return 0, ErrNoBoundSession
+	}
+	userID, err := snowflake.Parse(rawUserID)
+	if err != nil {
+		return 0, ErrNoBoundSession
+	}
+	return userID, nil
+}
+
+// ClearSession removes the session cookie from the browser.
+func (b *CookieSessionBinder) ClearSession(w http.ResponseWriter) {
+	http.SetCookie(w, b.cookie(b.SessionCookieName, "", -1))
+}
+
+func (b *CookieSessionBinder) cookie(name string, value string, maxAge time.Duration) *http.Cookie {
+	cookie := &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     "/",
+		Secure:   b.Secure,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	}
+	if maxAge < 0 {
+		cookie.MaxAge = -1
+	} else {
+		cookie.MaxAge = int(maxAge.Seconds())
+	}
+	return cookie
+}
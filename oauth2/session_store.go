@@ -0,0 +1,184 @@
+package oauth2
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/disgoorg/json"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+var (
+	_ SessionStore = (*memorySessionStore)(nil)
+	_ SessionStore = (*fileSessionStore)(nil)
+)
+
+// SessionStore is responsible for persisting Session(s) keyed by the ID of the user they belong to.
+type SessionStore interface {
+	// Get returns the Session of the given user or ErrSessionNotFound.
+	Get(userID snowflake.ID) (Session, error)
+
+	// Put stores the Session of the given user.
+	Put(userID snowflake.ID, session Session) error
+
+	// Delete removes the Session of the given user.
+	Delete(userID snowflake.ID) error
+}
+
+// NewMemorySessionStore returns a new SessionStore which keeps all Session(s) in memory.
+func NewMemorySessionStore() SessionStore {
+	return &memorySessionStore{
+		sessions: map[snowflake.ID]Session{},
+	}
+}
+
+type memorySessionStore struct {
+	mu       sync.RWMutex
+	sessions map[snowflake.ID]Session
+}
+
+func (s *memorySessionStore) Get(userID snowflake.ID) (Session, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	session, ok := s.sessions[userID]
+	if !ok {
+		return Session{}, ErrSessionNotFound
+	}
+	return session, nil
+}
+
+func (s *memorySessionStore) Put(userID snowflake.ID, session Session) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sessions[userID] = session
+	return nil
+}
+
+// UserIDs returns the IDs of all users with a stored Session.
+func (s *memorySessionStore) UserIDs() ([]snowflake.ID, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	userIDs := make([]snowflake.ID, 0, len(s.sessions))
+	for userID := range s.sessions {
+		userIDs = append(userIDs, userID)
+	}
+	return userIDs, nil
+}
+
+func (s *memorySessionStore) Delete(userID snowflake.ID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.sessions, userID)
+	return nil
+}
+
+// NewFileSessionStore returns a new SessionStore which persists all Session(s) as JSON in the given file.
+// Existing Session(s) are loaded from the file. The file is rewritten atomically on every change.
+func NewFileSessionStore(path string) (SessionStore, error) {
+	sessions := map[snowflake.ID]Session{}
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read session file: %w", err)
+	}
+	if len(data) > 0 {
+		if err = json.Unmarshal(data, &sessions); err != nil {
+			return nil, fmt.Errorf("failed to decode session file: %w", err)
+		}
+	}
+
+	return &fileSessionStore{
+		path:     path,
+		sessions: sessions,
+	}, nil
+}
+
+type fileSessionStore struct {
+	path string
+
+	mu       sync.RWMutex
+	sessions map[snowflake.ID]Session
+}
+
+func (s *fileSessionStore) Get(userID snowflake.ID) (Session, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	session, ok := s.sessions[userID]
+	if !ok {
+		return Session{}, ErrSessionNotFound
+	}
+	return session, nil
+}
+
+func (s *fileSessionStore) Put(userID snowflake.ID, session Session) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	old, ok := s.sessions[userID]
+	s.sessions[userID] = session
+	if err := s.save(); err != nil {
+		if ok {
+			s.sessions[userID] = old
+		} else {
+			delete(s.sessions, userID)
+		}
+		return err
+	}
+	return nil
+}
+
+// UserIDs returns the IDs of all users with a stored Session.
+func (s *fileSessionStore) UserIDs() ([]snowflake.ID, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	userIDs := make([]snowflake.ID, 0, len(s.sessions))
+	for userID := range s.sessions {
+		userIDs = append(userIDs, userID)
+	}
+	return userIDs, nil
+}
+
+func (s *fileSessionStore) Delete(userID snowflake.ID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	old, ok := s.sessions[userID]
+	if !ok {
+		return nil
+	}
+	delete(s.sessions, userID)
+	if err := s.save(); err != nil {
+		s.sessions[userID] = old
+		return err
+	}
+	return nil
+}
+
+// save writes all sessions to a temporary file & renames it to the session file. s.mu must be held.
+func (s *fileSessionStore) save() error {
+	data, err := json.Marshal(s.sessions)
+	if err != nil {
+		return fmt.Errorf("failed to encode sessions: %w", err)
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary session file: %w", err)
+	}
+	defer func() {
+		_ = os.Remove(file.Name())
+	}()
+
+	if _, err = file.Write(data); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to write temporary session file: %w", err)
+	}
+	if err = file.Close(); err != nil {
+		return fmt.Errorf("failed to close temporary session file: %w", err)
+	}
+	if err = os.Rename(file.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to replace session file: %w", err)
+	}
+	return nil
+}
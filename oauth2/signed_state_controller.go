@@ -0,0 +1,187 @@
+package oauth2
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/json"
+)
+
+var (
+	_ SignedStateController = (*signedStateControllerImpl)(nil)
+
+	// ErrInvalidState is returned when a state is malformed or its signature doesn't match any key.
+	ErrInvalidState = errors.New("invalid state")
+
+	// ErrStateExpired is returned when a state is older than its TTL.
+	ErrStateExpired = errors.New("state expired")
+
+	// ErrInvalidSigningKeys is returned when a SignedStateController is created or rotated without keys or with an empty key.
+	ErrInvalidSigningKeys = errors.New("at least one signing key is required & keys must not be empty")
+)
+
+// PayloadStateController is a StateController which can embed an additional caller payload like a return path or guild ID in the state.
+type PayloadStateController interface {
+	StateController
+
+	// NewStateWithPayload generates a new state for the redirect uri which carries the given payload.
+	NewStateWithPayload(redirectURI string, payload []byte) string
+
+	// UseStatePayload validates a state and returns the redirect uri & payload it carries.
+	UseStatePayload(state string) (string, []byte, error)
+}
+
+// SignedStateController is a PayloadStateController which issues self-contained HMAC signed states.
+// States are verified without shared storage, so they work across multiple replicas as long as they share the keys.
+// As nothing is stored, a state can be used multiple times until it expired.
+type SignedStateController interface {
+	PayloadStateController
+
+	// SetKeys replaces the keys used to sign & verify states. The first key signs new states, all keys are accepted when verifying.
+	// To rotate keys, prepend the new key & remove the old key once all states signed by it expired.
+	// ErrInvalidSigningKeys is returned & the current keys are kept if no keys or an empty key is passed.
+	SetKeys(keys ...[]byte) error
+}
+
+// NewSignedStateController returns a new SignedStateController. At least one key has to be set via WithSigningKeys, otherwise ErrInvalidSigningKeys is returned.
+func NewSignedStateController(opts ...SignedStateControllerConfigOpt) (SignedStateController, error) {
+	config := DefaultSignedStateControllerConfig()
+	config.Apply(opts)
+	config.Logger = config.Logger.With(slog.String("name", "oauth2_signed_state_controller"))
+
+	if err := validateSigningKeys(config.Keys); err != nil {
+		return nil, err
+	}
+
+	return &signedStateControllerImpl{
+		logger: config.Logger,
+		keys:   config.Keys,
+		ttl:    config.TTL,
+		now:    time.Now,
+	}, nil
+}
+
+func validateSigningKeys(keys [][]byte) error {
+	if len(keys) == 0 {
+		return ErrInvalidSigningKeys
+	}
+	for i, key := range keys {
+		if len(key) == 0 {
+			return fmt.Errorf("%w: key %d is empty", ErrInvalidSigningKeys, i)
+		}
+	}
+	return nil
+}
+
+type signedState struct {
+	RedirectURI string `json:"r"`
+	Payload     []byte `json:"p,omitempty"`
+	ExpiresAt   int64  `json:"e"`
+	Nonce       []byte `json:"n"`
+}
+
+type signedStateControllerImpl struct {
+	logger *slog.Logger
+	ttl    time.Duration
+	now    func() time.Time
+
+	mu   sync.RWMutex
+	keys [][]byte
+}
+
+func (c *signedStateControllerImpl) SetKeys(keys ...[]byte) error {
+	if err := validateSigningKeys(keys); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.keys = keys
+	return nil
+}
+
+func (c *signedStateControllerImpl) NewState(redirectURI string) string {
+	return c.NewStateWithPayload(redirectURI, nil)
+}
+
+func (c *signedStateControllerImpl) NewStateWithPayload(redirectURI string, payload []byte) string {
+	nonce := make([]byte, 16)
+	_, _ = rand.Read(nonce)
+
+	data, err := json.Marshal(signedState{
+		RedirectURI: redirectURI,
+		Payload:     payload,
+		ExpiresAt:   c.now().Add(c.ttl).Unix(),
+		Nonce:       nonce,
+	})
+	if err != nil {
+		c.logger.Error("failed to encode state", slog.Any("err", err))
+		return ""
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	encoded := base64.RawURLEncoding.EncodeToString(data)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(signState(c.keys[0], encoded))
+}
+
+func (c *signedStateControllerImpl) UseState(state string) string {
+	redirectURI, _, err := c.UseStatePayload(state)
+	if err != nil {
+		c.logger.Debug("failed to use state", slog.Any("err", err))
+		return ""
+	}
+	return redirectURI
+}
+
+func (c *signedStateControllerImpl) UseStatePayload(state string) (string, []byte, error) {
+	encoded, signature, ok := strings.Cut(state, ".")
+	if !ok {
+		return "", nil, ErrInvalidState
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil {
+		return "", nil, ErrInvalidState
+	}
+	if !c.verify(encoded, sig) {
+		return "", nil, ErrInvalidState
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", nil, ErrInvalidState
+	}
+	var s signedState
+	if err = json.Unmarshal(data, &s); err != nil {
+		return "", nil, ErrInvalidState
+	}
+	if c.now().Unix() > s.ExpiresAt {
+		return "", nil, ErrStateExpired
+	}
+	return s.RedirectURI, s.Payload, nil
+}
+
+func (c *signedStateControllerImpl) verify(encoded string, signature []byte) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, key := range c.keys {
+		if hmac.Equal(signState(key, encoded), signature) {
+			return true
+		}
+	}
+	return false
+}
+
+func signState(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
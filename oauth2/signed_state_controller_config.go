@@ -0,0 +1,55 @@
+package oauth2
+
+import (
+	"log/slog"
+	"time"
+)
+
+// DefaultSignedStateControllerConfig is the default configuration for the SignedStateController
+func DefaultSignedStateControllerConfig() *SignedStateControllerConfig {
+	return &SignedStateControllerConfig{
+		Logger: slog.Default(),
+		TTL:    10 * time.Minute,
+	}
+}
+
+// SignedStateControllerConfig is the configuration for the SignedStateController
+type SignedStateControllerConfig struct {
+	Logger *slog.Logger
+	// Keys are the HMAC keys used to sign & verify states. The first key signs new states, all keys are accepted when verifying.
+	Keys [][]byte
+	// TTL is how long a state is valid after it was issued
+	TTL time.Duration
+}
+
+// SignedStateControllerConfigOpt is used to pass optional parameters to NewSignedStateController
+type SignedStateControllerConfigOpt func(config *SignedStateControllerConfig)
+
+// Apply applies the given SignedStateControllerConfigOpt(s) to the SignedStateControllerConfig
+func (c *SignedStateControllerConfig) Apply(opts []SignedStateControllerConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithSignedStateControllerLogger sets the logger for the SignedStateController
+func WithSignedStateControllerLogger(logger *slog.Logger) SignedStateControllerConfigOpt {
+	return func(config *SignedStateControllerConfig) {
+		config.Logger = logger
+	}
+}
+
+// WithSigningKeys sets the HMAC keys of the SignedStateController. The first key signs new states, all keys are accepted when verifying.
+// At least one key is required & keys must not be empty.
+func WithSigningKeys(keys ...[]byte) SignedStateControllerConfigOpt {
+	return func(config *SignedStateControllerConfig) {
+		config.Keys = keys
+	}
+}
+
+// WithStateTTL sets how long a state is valid after it was issued
+func WithStateTTL(ttl time.Duration) SignedStateControllerConfigOpt {
+	return func(config *SignedStateControllerConfig) {
+		config.TTL = ttl
+	}
+}
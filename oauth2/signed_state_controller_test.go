@@ -0,0 +1,69 @@
+package oauth2
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignedStateController(t *testing.T) {
+	oldKey, newKey := []byte("old-key"), []byte("new-key")
+	controller, err := NewSignedStateController(WithSigningKeys(oldKey), WithStateTTL(time.Minute))
+	if !assert.NoError(t, err) {
+		return
+	}
+	// a second replica sharing the keys
+	replica, err := NewSignedStateController(WithSigningKeys(oldKey))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	state := controller.NewStateWithPayload("https://example.com/callback", []byte("/dashboard"))
+	redirectURI, payload, err := replica.UseStatePayload(state)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.com/callback", redirectURI)
+	assert.Equal(t, []byte("/dashboard"), payload)
+	assert.Equal(t, "https://example.com/callback", replica.UseState(state))
+
+	assert.NotEqual(t, controller.NewState("https://example.com/callback"), controller.NewState("https://example.com/callback"))
+
+	_, _, err = replica.UseStatePayload(state[:len(state)-2] + "AA")
+	assert.ErrorIs(t, err, ErrInvalidState)
+	assert.Empty(t, replica.UseState("garbage"))
+
+	// rotate keys: states signed with the old key stay valid until the old key is removed
+	assert.NoError(t, controller.SetKeys(newKey, oldKey))
+	assert.NoError(t, replica.SetKeys(newKey, oldKey))
+	rotatedState := controller.NewState("https://example.com/callback")
+	assert.Equal(t, "https://example.com/callback", replica.UseState(state))
+	assert.NoError(t, replica.SetKeys(newKey))
+	assert.Empty(t, replica.UseState(state))
+	assert.Equal(t, "https://example.com/callback", replica.UseState(rotatedState))
+
+	impl := controller.(*signedStateControllerImpl)
+	impl.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+	_, _, err = controller.UseStatePayload(rotatedState)
+	assert.ErrorIs(t, err, ErrStateExpired)
+}
+
+func TestSignedStateController_InvalidKeys(t *testing.T) {
+	_, err := NewSignedStateController()
+	assert.ErrorIs(t, err, ErrInvalidSigningKeys)
+	_, err = NewSignedStateController(WithSigningKeys())
+	assert.ErrorIs(t, err, ErrInvalidSigningKeys)
+	_, err = NewSignedStateController(WithSigningKeys([]byte("key"), nil))
+	assert.ErrorIs(t, err, ErrInvalidSigningKeys)
+
+	controller, err := NewSignedStateController(WithSigningKeys([]byte("key")))
+	if !assert.NoError(t, err) {
+		return
+	}
+	state := controller.NewState("https://example.com/callback")
+	assert.NotEmpty(t, state)
+
+	// invalid rotations keep the current keys
+	assert.ErrorIs(t, controller.SetKeys(), ErrInvalidSigningKeys)
+	assert.ErrorIs(t, controller.SetKeys([]byte{}), ErrInvalidSigningKeys)
+	assert.Equal(t, "https://example.com/callback", controller.UseState(state))
+}
@@ -43,6 +43,9 @@ type Applications interface {
 	ConsumeEntitlement(applicationID snowflake.ID, entitlementID snowflake.ID, opts ...RequestOpt) error
 
 	GetSKUs(applicationID snowflake.ID, opts ...RequestOpt) ([]discord.SKU, error)
+
+	GetSKUSubscriptions(skuID snowflake.ID, userID snowflake.ID, before snowflake.ID, after snowflake.ID, limit int, opts ...RequestOpt) ([]discord.Subscription, error)
+	GetSKUSubscription(skuID snowflake.ID, subscriptionID snowflake.ID, opts ...RequestOpt) (*discord.Subscription, error)
 }
 
 type applicationsImpl struct {
@@ -219,6 +222,29 @@ func (s *applicationsImpl) GetSKUs(applicationID snowflake.ID, opts ...RequestOp
 	return
 }
 
+func (s *applicationsImpl) GetSKUSubscriptions(skuID snowflake.ID, userID snowflake.ID, before snowflake.ID, after snowflake.ID, limit int, opts ...RequestOpt) (subscriptions []discord.Subscription, err error) {
+	queryValues := discord.QueryValues{}
+	if userID != 0 {
+		queryValues["user_id"] = userID
+	}
+	if before != 0 {
+		queryValues["before"] = before
+	}
+	if after != 0 {
+		queryValues["after"] = after
+	}
+	if limit != 0 {
+		queryValues["limit"] = limit
+	}
+	err = s.client.Do(GetSKUSubscriptions.Compile(queryValues, skuID), nil, &subscriptions, opts...)
+	return
+}
+
+func (s *applicationsImpl) GetSKUSubscription(skuID snowflake.ID, subscriptionID snowflake.ID, opts ...RequestOpt) (subscription *discord.Subscription, err error) {
+	err = s.client.Do(GetSKUSubscription.Compile(nil, skuID, subscriptionID), nil, &subscription, opts...)
+	return
+}
+
 func unmarshalApplicationCommandsToApplicationCommands(unmarshalCommands []discord.UnmarshalApplicationCommand) []discord.ApplicationCommand {
 	commands := make([]discord.ApplicationCommand, len(unmarshalCommands))
 	for i := range unmarshalCommands {
@@ -31,6 +31,7 @@ type Channels interface {
 	GetMessages(channelID snowflake.ID, around snowflake.ID, before snowflake.ID, after snowflake.ID, limit int, opts ...RequestOpt) ([]discord.Message, error)
 	GetMessagesPage(channelID snowflake.ID, startID snowflake.ID, limit int, opts ...RequestOpt) Page[discord.Message]
 	CreateMessage(channelID snowflake.ID, messageCreate discord.MessageCreate, opts ...RequestOpt) (*discord.Message, error)
+	ForwardMessage(channelID snowflake.ID, sourceChannelID snowflake.ID, messageID snowflake.ID, opts ...RequestOpt) (*discord.Message, error)
 	UpdateMessage(channelID snowflake.ID, messageID snowflake.ID, messageUpdate discord.MessageUpdate, opts ...RequestOpt) (*discord.Message, error)
 	DeleteMessage(channelID snowflake.ID, messageID snowflake.ID, opts ...RequestOpt) error
 	BulkDeleteMessages(channelID snowflake.ID, messageIDs []snowflake.ID, opts ...RequestOpt) error
@@ -162,6 +163,10 @@ func (s *channelImpl) CreateMessage(channelID snowflake.ID, messageCreate discor
 	return
 }
 
+func (s *channelImpl) ForwardMessage(channelID snowflake.ID, sourceChannelID snowflake.ID, messageID snowflake.ID, opts ...RequestOpt) (*discord.Message, error) {
+	return s.CreateMessage(channelID, discord.NewMessageCreateBuilder().SetForwardedMessage(sourceChannelID, messageID).Build(), opts...)
+}
+
 func (s *channelImpl) UpdateMessage(channelID snowflake.ID, messageID snowflake.ID, messageUpdate discord.MessageUpdate, opts ...RequestOpt) (message *discord.Message, err error) {
 	body, err := messageUpdate.ToBody()
 	if err != nil {
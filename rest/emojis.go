@@ -18,6 +18,12 @@ type Emojis interface {
 	CreateEmoji(guildID snowflake.ID, emojiCreate discord.EmojiCreate, opts ...RequestOpt) (*discord.Emoji, error)
 	UpdateEmoji(guildID snowflake.ID, emojiID snowflake.ID, emojiUpdate discord.EmojiUpdate, opts ...RequestOpt) (*discord.Emoji, error)
 	DeleteEmoji(guildID snowflake.ID, emojiID snowflake.ID, opts ...RequestOpt) error
+
+	GetApplicationEmojis(applicationID snowflake.ID, opts ...RequestOpt) ([]discord.Emoji, error)
+	GetApplicationEmoji(applicationID snowflake.ID, emojiID snowflake.ID, opts ...RequestOpt) (*discord.Emoji, error)
+	CreateApplicationEmoji(applicationID snowflake.ID, emojiCreate discord.ApplicationEmojiCreate, opts ...RequestOpt) (*discord.Emoji, error)
+	UpdateApplicationEmoji(applicationID snowflake.ID, emojiID snowflake.ID, emojiUpdate discord.ApplicationEmojiUpdate, opts ...RequestOpt) (*discord.Emoji, error)
+	DeleteApplicationEmoji(applicationID snowflake.ID, emojiID snowflake.ID, opts ...RequestOpt) error
 }
 
 type emojiImpl struct {
@@ -59,3 +65,31 @@ func (s *emojiImpl) UpdateEmoji(guildID snowflake.ID, emojiID snowflake.ID, emoj
 func (s *emojiImpl) DeleteEmoji(guildID snowflake.ID, emojiID snowflake.ID, opts ...RequestOpt) error {
 	return s.client.Do(DeleteEmoji.Compile(nil, guildID, emojiID), nil, nil, opts...)
 }
+
+func (s *emojiImpl) GetApplicationEmojis(applicationID snowflake.ID, opts ...RequestOpt) (emojis []discord.Emoji, err error) {
+	var rs discord.ApplicationEmojis
+	err = s.client.Do(GetApplicationEmojis.Compile(nil, applicationID), nil, &rs, opts...)
+	if err == nil {
+		emojis = rs.Items
+	}
+	return
+}
+
+func (s *emojiImpl) GetApplicationEmoji(applicationID snowflake.ID, emojiID snowflake.ID, opts ...RequestOpt) (emoji *discord.Emoji, err error) {
+	err = s.client.Do(GetApplicationEmoji.Compile(nil, applicationID, emojiID), nil, &emoji, opts...)
+	return
+}
+
+func (s *emojiImpl) CreateApplicationEmoji(applicationID snowflake.ID, emojiCreate discord.ApplicationEmojiCreate, opts ...RequestOpt) (emoji *discord.Emoji, err error) {
+	err = s.client.Do(CreateApplicationEmoji.Compile(nil, applicationID), emojiCreate, &emoji, opts...)
+	return
+}
+
+func (s *emojiImpl) UpdateApplicationEmoji(applicationID snowflake.ID, emojiID snowflake.ID, emojiUpdate discord.ApplicationEmojiUpdate, opts ...RequestOpt) (emoji *discord.Emoji, err error) {
+	err = s.client.Do(UpdateApplicationEmoji.Compile(nil, applicationID, emojiID), emojiUpdate, &emoji, opts...)
+	return
+}
+
+func (s *emojiImpl) DeleteApplicationEmoji(applicationID snowflake.ID, emojiID snowflake.ID, opts ...RequestOpt) error {
+	return s.client.Do(DeleteApplicationEmoji.Compile(nil, applicationID, emojiID), nil, nil, opts...)
+}
@@ -60,6 +60,13 @@ type Guilds interface {
 
 	GetGuildOnboarding(guildID snowflake.ID, opts ...RequestOpt) (*discord.GuildOnboarding, error)
 	UpdateGuildOnboarding(guildID snowflake.ID, onboardingUpdate discord.GuildOnboardingUpdate, opts ...RequestOpt) (*discord.GuildOnboarding, error)
+
+	GetGuildWidgetSettings(guildID snowflake.ID, opts ...RequestOpt) (*discord.GuildWidgetSettings, error)
+	UpdateGuildWidgetSettings(guildID snowflake.ID, widgetUpdate discord.GuildWidgetSettingsUpdate, opts ...RequestOpt) (*discord.GuildWidgetSettings, error)
+	GetGuildWidget(guildID snowflake.ID, opts ...RequestOpt) (*discord.GuildWidget, error)
+
+	UpdateGuildMFALevel(guildID snowflake.ID, level discord.MFALevel, opts ...RequestOpt) (discord.MFALevel, error)
+	UpdateGuildIncidentActions(guildID snowflake.ID, incidentActionsUpdate discord.GuildIncidentActionsUpdate, opts ...RequestOpt) (*discord.GuildIncidentsData, error)
 }
 
 type guildImpl struct {
@@ -310,3 +317,31 @@ func (s *guildImpl) UpdateGuildOnboarding(guildID snowflake.ID, onboardingUpdate
 	err = s.client.Do(UpdateGuildOnboarding.Compile(nil, guildID), onboardingUpdate, &guildOnboarding, opts...)
 	return
 }
+
+func (s *guildImpl) GetGuildWidgetSettings(guildID snowflake.ID, opts ...RequestOpt) (settings *discord.GuildWidgetSettings, err error) {
+	err = s.client.Do(GetGuildWidgetSettings.Compile(nil, guildID), nil, &settings, opts...)
+	return
+}
+
+func (s *guildImpl) UpdateGuildWidgetSettings(guildID snowflake.ID, widgetUpdate discord.GuildWidgetSettingsUpdate, opts ...RequestOpt) (settings *discord.GuildWidgetSettings, err error) {
+	err = s.client.Do(UpdateGuildWidgetSettings.Compile(nil, guildID), widgetUpdate, &settings, opts...)
+	return
+}
+
+func (s *guildImpl) GetGuildWidget(guildID snowflake.ID, opts ...RequestOpt) (widget *discord.GuildWidget, err error) {
+	err = s.client.Do(GetGuildWidget.Compile(nil, guildID), nil, &widget, opts...)
+	return
+}
+
+func (s *guildImpl) UpdateGuildMFALevel(guildID snowflake.ID, level discord.MFALevel, opts ...RequestOpt) (discord.MFALevel, error) {
+	var mfaLevel discord.GuildMFALevelUpdate
+	if err := s.client.Do(UpdateGuildMFALevel.Compile(nil, guildID), discord.GuildMFALevelUpdate{Level: level}, &mfaLevel, opts...); err != nil {
+		return 0, err
+	}
+	return mfaLevel.Level, nil
+}
+
+func (s *guildImpl) UpdateGuildIncidentActions(guildID snowflake.ID, incidentActionsUpdate discord.GuildIncidentActionsUpdate, opts ...RequestOpt) (incidentsData *discord.GuildIncidentsData, err error) {
+	err = s.client.Do(UpdateGuildIncidentActions.Compile(nil, guildID), incidentActionsUpdate, &incidentsData, opts...)
+	return
+}
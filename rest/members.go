@@ -25,6 +25,8 @@ type Members interface {
 
 	UpdateCurrentMember(guildID snowflake.ID, nick string, opts ...RequestOpt) (*string, error)
 
+	GetCurrentUserVoiceState(guildID snowflake.ID, opts ...RequestOpt) (*discord.VoiceState, error)
+	GetUserVoiceState(guildID snowflake.ID, userID snowflake.ID, opts ...RequestOpt) (*discord.VoiceState, error)
 	UpdateCurrentUserVoiceState(guildID snowflake.ID, currentUserVoiceStateUpdate discord.CurrentUserVoiceStateUpdate, opts ...RequestOpt) error
 	UpdateUserVoiceState(guildID snowflake.ID, userID snowflake.ID, userVoiceStateUpdate discord.UserVoiceStateUpdate, opts ...RequestOpt) error
 }
@@ -105,6 +107,16 @@ func (s *memberImpl) UpdateCurrentMember(guildID snowflake.ID, nick string, opts
 	return
 }
 
+func (s *memberImpl) GetCurrentUserVoiceState(guildID snowflake.ID, opts ...RequestOpt) (voiceState *discord.VoiceState, err error) {
+	err = s.client.Do(GetCurrentUserVoiceState.Compile(nil, guildID), nil, &voiceState, opts...)
+	return
+}
+
+func (s *memberImpl) GetUserVoiceState(guildID snowflake.ID, userID snowflake.ID, opts ...RequestOpt) (voiceState *discord.VoiceState, err error) {
+	err = s.client.Do(GetUserVoiceState.Compile(nil, guildID, userID), nil, &voiceState, opts...)
+	return
+}
+
 func (s *memberImpl) UpdateCurrentUserVoiceState(guildID snowflake.ID, currentUserVoiceStateUpdate discord.CurrentUserVoiceStateUpdate, opts ...RequestOpt) error {
 	return s.client.Do(UpdateCurrentUserVoiceState.Compile(nil, guildID), currentUserVoiceStateUpdate, nil, opts...)
 }
@@ -41,6 +41,8 @@ type OAuth2 interface {
 
 	GetAccessToken(clientID snowflake.ID, clientSecret string, code string, redirectURI string, opts ...RequestOpt) (*discord.AccessTokenResponse, error)
 	RefreshAccessToken(clientID snowflake.ID, clientSecret string, refreshToken string, opts ...RequestOpt) (*discord.AccessTokenResponse, error)
+	GetClientCredentialsToken(clientID snowflake.ID, clientSecret string, scopes []discord.OAuth2Scope, opts ...RequestOpt) (*discord.AccessTokenResponse, error)
+	RevokeToken(clientID snowflake.ID, clientSecret string, token string, tokenTypeHint discord.TokenTypeHint, opts ...RequestOpt) error
 }
 
 type oAuth2Impl struct {
@@ -141,7 +143,8 @@ func (s *oAuth2Impl) UpdateCurrentUserApplicationRoleConnection(bearerToken stri
 	return
 }
 
-func (s *oAuth2Impl) exchangeAccessToken(clientID snowflake.ID, clientSecret string, grantType discord.GrantType, codeOrRefreshToken string, redirectURI string, opts ...RequestOpt) (exchange *discord.AccessTokenResponse, err error) {
+// exchangeAccessToken requests an access token. grant is the code, refresh token or scope depending on the discord.GrantType.
+func (s *oAuth2Impl) exchangeAccessToken(clientID snowflake.ID, clientSecret string, grantType discord.GrantType, grant string, redirectURI string, opts ...RequestOpt) (exchange *discord.AccessTokenResponse, err error) {
 	values := url.Values{
 		"client_id":     []string{clientID.String()},
 		"client_secret": []string{clientSecret},
@@ -149,11 +152,14 @@ func (s *oAuth2Impl) exchangeAccessToken(clientID snowflake.ID, clientSecret str
 	}
 	switch grantType {
 	case discord.GrantTypeAuthorizationCode:
-		values["code"] = []string{codeOrRefreshToken}
+		values["code"] = []string{grant}
 		values["redirect_uri"] = []string{redirectURI}
 
 	case discord.GrantTypeRefreshToken:
-		values["refresh_token"] = []string{codeOrRefreshToken}
+		values["refresh_token"] = []string{grant}
+
+	case discord.GrantTypeClientCredentials:
+		values["scope"] = []string{grant}
 	}
 	err = s.client.Do(Token.Compile(nil), values, &exchange, opts...)
 	return
@@ -166,3 +172,19 @@ func (s *oAuth2Impl) GetAccessToken(clientID snowflake.ID, clientSecret string,
 func (s *oAuth2Impl) RefreshAccessToken(clientID snowflake.ID, clientSecret string, refreshToken string, opts ...RequestOpt) (exchange *discord.AccessTokenResponse, err error) {
 	return s.exchangeAccessToken(clientID, clientSecret, discord.GrantTypeRefreshToken, refreshToken, "", opts...)
 }
+
+func (s *oAuth2Impl) GetClientCredentialsToken(clientID snowflake.ID, clientSecret string, scopes []discord.OAuth2Scope, opts ...RequestOpt) (exchange *discord.AccessTokenResponse, err error) {
+	return s.exchangeAccessToken(clientID, clientSecret, discord.GrantTypeClientCredentials, discord.JoinScopes(scopes), "", opts...)
+}
+
+func (s *oAuth2Impl) RevokeToken(clientID snowflake.ID, clientSecret string, token string, tokenTypeHint discord.TokenTypeHint, opts ...RequestOpt) error {
+	values := url.Values{
+		"client_id":     []string{clientID.String()},
+		"client_secret": []string{clientSecret},
+		"token":         []string{token},
+	}
+	if tokenTypeHint != "" {
+		values["token_type_hint"] = []string{tokenTypeHint.String()}
+	}
+	return s.client.Do(RevokeToken.Compile(nil), values, nil, opts...)
+}
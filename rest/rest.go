@@ -22,6 +22,7 @@ type Rest interface {
 	Emojis
 	Stickers
 	GuildScheduledEvents
+	SoundboardSounds
 }
 
 var _ Rest = (*restImpl)(nil)
@@ -48,6 +49,7 @@ func New(client Client) Rest {
 		Emojis:               NewEmojis(client),
 		Stickers:             NewStickers(client),
 		GuildScheduledEvents: NewGuildScheduledEvents(client),
+		SoundboardSounds:     NewSoundboardSounds(client),
 	}
 }
 
@@ -72,4 +74,5 @@ type restImpl struct {
 	Emojis
 	Stickers
 	GuildScheduledEvents
+	SoundboardSounds
 }
@@ -31,6 +31,7 @@ var (
 	GetBotApplicationInfo = NewEndpoint(http.MethodGet, "/oauth2/applications/@me")
 	GetAuthorizationInfo  = NewNoBotAuthEndpoint(http.MethodGet, "/oauth2/@me")
 	Token                 = NewEndpoint(http.MethodPost, "/oauth2/token")
+	RevokeToken           = NewEndpoint(http.MethodPost, "/oauth2/token/revoke")
 )
 
 // Users
@@ -93,6 +94,15 @@ var (
 	GetGuildOnboarding    = NewEndpoint(http.MethodGet, "/guilds/{guild.id}/onboarding")
 	UpdateGuildOnboarding = NewEndpoint(http.MethodPut, "/guilds/{guild.id}/onboarding")
 
+	GetGuildWidgetSettings    = NewEndpoint(http.MethodGet, "/guilds/{guild.id}/widget")
+	UpdateGuildWidgetSettings = NewEndpoint(http.MethodPatch, "/guilds/{guild.id}/widget")
+	GetGuildWidget            = NewNoBotAuthEndpoint(http.MethodGet, "/guilds/{guild.id}/widget.json")
+
+	UpdateGuildMFALevel        = NewEndpoint(http.MethodPost, "/guilds/{guild.id}/mfa")
+	UpdateGuildIncidentActions = NewEndpoint(http.MethodPut, "/guilds/{guild.id}/incident-actions")
+
+	GetCurrentUserVoiceState    = NewEndpoint(http.MethodGet, "/guilds/{guild.id}/voice-states/@me")
+	GetUserVoiceState           = NewEndpoint(http.MethodGet, "/guilds/{guild.id}/voice-states/{user.id}")
 	UpdateCurrentUserVoiceState = NewEndpoint(http.MethodPatch, "/guilds/{guild.id}/voice-states/@me")
 	UpdateUserVoiceState        = NewEndpoint(http.MethodPatch, "/guilds/{guild.id}/voice-states/{user.id}")
 )
@@ -187,6 +197,8 @@ var (
 	GetThreadMember         = NewEndpoint(http.MethodGet, "/channels/{channel.id}/thread-members/{user.id}")
 	GetThreadMembers        = NewEndpoint(http.MethodGet, "/channels/{channel.id}/thread-members")
 
+	GetActiveGuildThreads = NewEndpoint(http.MethodGet, "/guilds/{guild.id}/threads/active")
+
 	GetPublicArchivedThreads        = NewEndpoint(http.MethodGet, "/channels/{channel.id}/threads/archived/public")
 	GetPrivateArchivedThreads       = NewEndpoint(http.MethodGet, "/channels/{channel.id}/threads/archived/private")
 	GetJoinedPrivateArchivedThreads = NewEndpoint(http.MethodGet, "/channels/{channel.id}/users/@me/threads/archived/private")
@@ -222,6 +234,12 @@ var (
 	CreateEmoji = NewEndpoint(http.MethodPost, "/guilds/{guild.id}/emojis")
 	UpdateEmoji = NewEndpoint(http.MethodPatch, "/guilds/{guild.id}/emojis/{emote.id}")
 	DeleteEmoji = NewEndpoint(http.MethodDelete, "/guilds/{guild.id}/emojis/{emote.id}")
+
+	GetApplicationEmojis   = NewEndpoint(http.MethodGet, "/applications/{application.id}/emojis")
+	GetApplicationEmoji    = NewEndpoint(http.MethodGet, "/applications/{application.id}/emojis/{emoji.id}")
+	CreateApplicationEmoji = NewEndpoint(http.MethodPost, "/applications/{application.id}/emojis")
+	UpdateApplicationEmoji = NewEndpoint(http.MethodPatch, "/applications/{application.id}/emojis/{emoji.id}")
+	DeleteApplicationEmoji = NewEndpoint(http.MethodDelete, "/applications/{application.id}/emojis/{emoji.id}")
 )
 
 // Stickers
@@ -234,6 +252,17 @@ var (
 	DeleteGuildSticker   = NewEndpoint(http.MethodDelete, "/guilds/{guild.id}/stickers/{sticker.id}")
 )
 
+// Soundboard
+var (
+	GetSoundboardDefaultSounds = NewEndpoint(http.MethodGet, "/soundboard-default-sounds")
+	GetGuildSoundboardSounds   = NewEndpoint(http.MethodGet, "/guilds/{guild.id}/soundboard-sounds")
+	GetGuildSoundboardSound    = NewEndpoint(http.MethodGet, "/guilds/{guild.id}/soundboard-sounds/{sound.id}")
+	CreateGuildSoundboardSound = NewEndpoint(http.MethodPost, "/guilds/{guild.id}/soundboard-sounds")
+	UpdateGuildSoundboardSound = NewEndpoint(http.MethodPatch, "/guilds/{guild.id}/soundboard-sounds/{sound.id}")
+	DeleteGuildSoundboardSound = NewEndpoint(http.MethodDelete, "/guilds/{guild.id}/soundboard-sounds/{sound.id}")
+	SendSoundboardSound        = NewEndpoint(http.MethodPost, "/channels/{channel.id}/send-soundboard-sound")
+)
+
 // Webhooks
 var (
 	GetWebhook    = NewEndpoint(http.MethodGet, "/webhooks/{webhook.id}")
@@ -303,6 +332,9 @@ var (
 	ConsumeEntitlement    = NewEndpoint(http.MethodPost, "/applications/{application.id}/entitlements/{entitlement.id}/consume")
 
 	GetSKUs = NewEndpoint(http.MethodGet, "/applications/{application.id}/skus")
+
+	GetSKUSubscriptions = NewEndpoint(http.MethodGet, "/skus/{sku.id}/subscriptions")
+	GetSKUSubscription  = NewEndpoint(http.MethodGet, "/skus/{sku.id}/subscriptions/{subscription.id}")
 )
 
 // NewEndpoint returns a new Endpoint which requires bot auth with the given http method & route.
@@ -12,6 +12,13 @@ import (
 // See https://discord.com/developers/docs/topics/opcodes-and-status-codes#json-json-error-codes
 type JSONErrorCode int
 
+const (
+	// JSONErrorCodeUnknownMessage is returned when the message does not exist, for example because it was already deleted.
+	JSONErrorCodeUnknownMessage JSONErrorCode = 10008
+	// JSONErrorCodeUnknownWebhook is returned when the webhook does not exist or the token is invalid, for example because it was deleted.
+	JSONErrorCodeUnknownWebhook JSONErrorCode = 10015
+)
+
 var _ error = (*Error)(nil)
 
 // Error holds the http.Response & an error related to a REST request
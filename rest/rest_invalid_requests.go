@@ -0,0 +1,107 @@
+package rest
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// InvalidRequestLimit is the amount of invalid requests (401, 403 & 429) Discord allows in InvalidRequestWindow before temporarily banning the IP
+	InvalidRequestLimit = 10000
+	// InvalidRequestWindow is the window in which Discord counts invalid requests
+	InvalidRequestWindow = time.Minute * 10
+	// InvalidRequestDelayThreshold is the amount of invalid requests after which the rate limiter starts to delay requests
+	InvalidRequestDelayThreshold = 5000
+	// InvalidRequestMaxDelay is the maximum delay the rate limiter adds to requests right before hitting the InvalidRequestLimit
+	InvalidRequestMaxDelay = time.Second * 10
+
+	invalidRequestSlots = 60
+)
+
+// isInvalidRequest returns whether the given response counts towards Discord's invalid request limit.
+// 429 responses with a shared scope are excluded as they are not caused by us.
+func isInvalidRequest(rs *http.Response) bool {
+	switch rs.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return true
+	case http.StatusTooManyRequests:
+		return rs.Header.Get("X-RateLimit-Scope") != "shared"
+	}
+	return false
+}
+
+func newInvalidRequestCounter(window time.Duration) *invalidRequestCounter {
+	slotSize := window / invalidRequestSlots
+	if slotSize <= 0 {
+		slotSize = time.Nanosecond
+	}
+	return &invalidRequestCounter{
+		slotSize: slotSize,
+		slots:    make([]int, invalidRequestSlots),
+	}
+}
+
+// invalidRequestCounter is a sliding window counter which splits the window into a fixed amount of slots.
+type invalidRequestCounter struct {
+	mu       sync.Mutex
+	slotSize time.Duration
+	slots    []int
+	head     int
+	headTime time.Time
+	count    int
+}
+
+// advance moves the head of the window to the slot of now and drops all slots which left the window.
+func (c *invalidRequestCounter) advance(now time.Time) {
+	if c.headTime.IsZero() {
+		c.headTime = now.Truncate(c.slotSize)
+		return
+	}
+
+	elapsed := int(now.Sub(c.headTime) / c.slotSize)
+	if elapsed <= 0 {
+		return
+	}
+
+	if elapsed >= len(c.slots) {
+		clear(c.slots)
+		c.count = 0
+		c.head = 0
+	} else {
+		for i := 0; i < elapsed; i++ {
+			c.head = (c.head + 1) % len(c.slots)
+			c.count -= c.slots[c.head]
+			c.slots[c.head] = 0
+		}
+	}
+	c.headTime = c.headTime.Add(time.Duration(elapsed) * c.slotSize)
+}
+
+// Add records an invalid request at the given time and returns the new count.
+func (c *invalidRequestCounter) Add(now time.Time) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.advance(now)
+	c.slots[c.head]++
+	c.count++
+	return c.count
+}
+
+// Count returns the amount of invalid requests in the window ending at the given time.
+func (c *invalidRequestCounter) Count(now time.Time) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.advance(now)
+	return c.count
+}
+
+// Reset clears all recorded invalid requests.
+func (c *invalidRequestCounter) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.slots)
+	c.head = 0
+	c.headTime = time.Time{}
+	c.count = 0
+}
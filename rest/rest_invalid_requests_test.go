@@ -0,0 +1,110 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInvalidRequestCounter(t *testing.T) {
+	c := newInvalidRequestCounter(time.Minute)
+	now := time.Unix(0, 0)
+
+	assert.Equal(t, 1, c.Add(now))
+	assert.Equal(t, 2, c.Add(now.Add(10*time.Second)))
+	assert.Equal(t, 3, c.Add(now.Add(30*time.Second)))
+
+	assert.Equal(t, 3, c.Count(now.Add(59*time.Second)))
+	assert.Equal(t, 2, c.Count(now.Add(61*time.Second)))
+	assert.Equal(t, 1, c.Count(now.Add(80*time.Second)))
+	assert.Equal(t, 0, c.Count(now.Add(91*time.Second)))
+
+	c.Add(now.Add(100 * time.Second))
+	assert.Equal(t, 0, c.Count(now.Add(time.Hour)))
+
+	c.Add(now)
+	c.Reset()
+	assert.Equal(t, 0, c.Count(now))
+}
+
+func TestRateLimiter_InvalidRequests(t *testing.T) {
+	rl := NewRateLimiter(
+		WithInvalidRequestLimit(4, time.Minute),
+		WithInvalidRequestDelay(2, 200*time.Millisecond),
+	).(*rateLimiterImpl)
+	endpoint := GetChannel.Compile(nil, 123)
+
+	request := func(statusCode int, header map[string]string) time.Duration {
+		start := time.Now()
+		assert.NoError(t, rl.WaitBucket(context.Background(), endpoint))
+		elapsed := time.Since(start)
+		assert.NoError(t, rl.UnlockBucket(endpoint, rateLimitResponse(statusCode, header)))
+		return elapsed
+	}
+	rateLimited := func(scope string) map[string]string {
+		return map[string]string{
+			"X-RateLimit-Bucket": "abc",
+			"X-RateLimit-Scope":  scope,
+			"Retry-After":        "0",
+			"Via":                "1.1 google",
+		}
+	}
+
+	// successful & shared rate limited requests are not counted
+	request(http.StatusOK, nil)
+	request(http.StatusTooManyRequests, rateLimited("shared"))
+	assert.Equal(t, 0, rl.InvalidRequests())
+
+	request(http.StatusUnauthorized, nil)
+	assert.Equal(t, 1, rl.InvalidRequests())
+	delay, err := rl.invalidRequestDelay()
+	assert.NoError(t, err)
+	assert.Zero(t, delay)
+
+	// the threshold is reached & requests are delayed progressively
+	elapsed := request(http.StatusForbidden, nil)
+	assert.Less(t, elapsed, 50*time.Millisecond)
+	delay, err = rl.invalidRequestDelay()
+	assert.NoError(t, err)
+	assert.Equal(t, 100*time.Millisecond, delay)
+
+	elapsed = request(http.StatusTooManyRequests, rateLimited("user"))
+	assert.GreaterOrEqual(t, elapsed, 100*time.Millisecond)
+	assert.Equal(t, 3, rl.InvalidRequests())
+	delay, err = rl.invalidRequestDelay()
+	assert.NoError(t, err)
+	// right before reaching the limit the max delay is used
+	assert.Equal(t, 200*time.Millisecond, delay)
+
+	// the delay respects the context
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, rl.WaitBucket(ctx, endpoint), context.DeadlineExceeded)
+
+	elapsed = request(http.StatusUnauthorized, nil)
+	assert.GreaterOrEqual(t, elapsed, 200*time.Millisecond)
+
+	// the limit is reached & all requests fail
+	assert.Equal(t, 4, rl.InvalidRequests())
+	assert.ErrorIs(t, rl.WaitBucket(context.Background(), endpoint), ErrInvalidRequestLimitExceeded)
+	assert.ErrorIs(t, rl.WaitBucket(context.Background(), GetGateway.Compile(nil)), ErrInvalidRequestLimitExceeded)
+
+	// resetting the rate limiter lifts the limit
+	rl.Reset()
+	assert.Equal(t, 0, rl.InvalidRequests())
+	assert.Zero(t, request(http.StatusOK, nil).Round(time.Second))
+}
+
+func TestRateLimiter_InvalidRequestsDisabled(t *testing.T) {
+	rl := NewRateLimiter(WithInvalidRequestLimit(0, time.Minute))
+	endpoint := GetChannel.Compile(nil, 123)
+
+	for i := 0; i < 20; i++ {
+		assert.NoError(t, rl.WaitBucket(context.Background(), endpoint))
+		assert.NoError(t, rl.UnlockBucket(endpoint, rateLimitResponse(http.StatusUnauthorized, nil)))
+	}
+	assert.Equal(t, 0, rl.InvalidRequests())
+}
@@ -2,11 +2,13 @@ package rest
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
 	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/sasha-s/go-csync"
@@ -19,6 +21,10 @@ const (
 	CleanupInterval = time.Second * 10
 )
 
+// ErrInvalidRequestLimitExceeded is returned by the RateLimiter when too many invalid requests (401, 403 & 429) were made in the configured window.
+// This protects from getting temporarily banned by Cloudflare.
+var ErrInvalidRequestLimitExceeded = errors.New("invalid request limit exceeded, refusing to send requests to prevent a cloudflare ban")
+
 // RateLimiter can be used to supply your own rate limit implementation
 type RateLimiter interface {
 	// MaxRetries returns the maximum number of retries the client should do
@@ -36,6 +42,16 @@ type RateLimiter interface {
 
 	// UnlockBucket unlocks the given bucket and calculates the rate limit for the next request
 	UnlockBucket(endpoint *CompiledEndpoint, rs *http.Response) error
+
+	// InvalidRequests returns the amount of invalid requests (401, 403 & 429) in the current window
+	InvalidRequests() int
+
+	// State returns a snapshot of the currently known buckets & the global rate limit
+	State() RateLimiterState
+
+	// Restore restores buckets & the global rate limit from a previously taken RateLimiterState.
+	// Buckets which have already been reset are ignored.
+	Restore(state RateLimiterState)
 }
 
 // NewRateLimiter return a new default RateLimiter with the given RateLimiterConfigOpt(s).
@@ -45,9 +61,10 @@ func NewRateLimiter(opts ...RateLimiterConfigOpt) RateLimiter {
 	config.Logger = config.Logger.With(slog.String("name", "rest_rate_limiter"))
 
 	rateLimiter := &rateLimiterImpl{
-		config:  *config,
-		hashes:  map[*Endpoint]string{},
-		buckets: map[string]*bucket{},
+		config:          *config,
+		hashes:          map[*Endpoint]string{},
+		buckets:         map[string]*bucket{},
+		invalidRequests: newInvalidRequestCounter(config.InvalidRequestWindow),
 	}
 
 	go rateLimiter.cleanup()
@@ -60,7 +77,8 @@ type (
 		config RateLimiterConfig
 
 		// global Rate Limit
-		global time.Time
+		global   time.Time
+		globalMu sync.Mutex
 
 		// APIRoute -> Hash
 		hashes   map[*Endpoint]string
@@ -68,6 +86,8 @@ type (
 		// Hash + Major Parameter -> bucket
 		buckets   map[string]*bucket
 		bucketsMu sync.Mutex
+
+		invalidRequests *invalidRequestCounter
 	}
 )
 
@@ -118,9 +138,122 @@ func (l *rateLimiterImpl) Close(ctx context.Context) {
 func (l *rateLimiterImpl) Reset() {
 	l.buckets = map[string]*bucket{}
 	l.bucketsMu = sync.Mutex{}
-	l.global = time.Time{}
 	l.hashes = map[*Endpoint]string{}
 	l.hashesMu = sync.Mutex{}
+	l.setGlobal(time.Time{})
+	l.invalidRequests.Reset()
+}
+
+func (l *rateLimiterImpl) getGlobal() time.Time {
+	l.globalMu.Lock()
+	defer l.globalMu.Unlock()
+	return l.global
+}
+
+func (l *rateLimiterImpl) setGlobal(global time.Time) {
+	l.globalMu.Lock()
+	defer l.globalMu.Unlock()
+	l.global = global
+}
+
+func (l *rateLimiterImpl) InvalidRequests() int {
+	return l.invalidRequests.Count(time.Now())
+}
+
+// invalidRequestDelay returns how long a request should be delayed based on the current invalid request count or ErrInvalidRequestLimitExceeded if the limit is reached.
+func (l *rateLimiterImpl) invalidRequestDelay() (time.Duration, error) {
+	if l.config.InvalidRequestLimit <= 0 {
+		return 0, nil
+	}
+
+	count := l.InvalidRequests()
+	if count >= l.config.InvalidRequestLimit {
+		return 0, fmt.Errorf("%w: %d invalid requests in the last %s", ErrInvalidRequestLimitExceeded, count, l.config.InvalidRequestWindow)
+	}
+
+	threshold := l.config.InvalidRequestDelayThreshold
+	if threshold <= 0 || count < threshold || threshold >= l.config.InvalidRequestLimit {
+		return 0, nil
+	}
+
+	return l.config.InvalidRequestMaxDelay * time.Duration(count-threshold+1) / time.Duration(l.config.InvalidRequestLimit-threshold), nil
+}
+
+func (l *rateLimiterImpl) trackInvalidRequest(endpoint *CompiledEndpoint, rs *http.Response) {
+	if l.config.InvalidRequestLimit <= 0 || rs == nil || !isInvalidRequest(rs) {
+		return
+	}
+
+	count := l.invalidRequests.Add(time.Now())
+	if count == l.config.InvalidRequestDelayThreshold || count == l.config.InvalidRequestLimit {
+		l.config.Logger.Warn("invalid request threshold reached", slog.String("endpoint", endpoint.URL), slog.Int("code", rs.StatusCode), slog.Int("count", count), slog.Int("limit", l.config.InvalidRequestLimit))
+	}
+}
+
+func (l *rateLimiterImpl) State() RateLimiterState {
+	l.bucketsMu.Lock()
+	defer l.bucketsMu.Unlock()
+
+	state := RateLimiterState{
+		Buckets: make([]RateLimitBucket, 0, len(l.buckets)),
+	}
+	if global := l.getGlobal(); global.After(time.Now()) {
+		state.Global = global
+	}
+	for _, b := range l.buckets {
+		b.stateMu.RLock()
+		state.Buckets = append(state.Buckets, RateLimitBucket{
+			Route:       b.route,
+			MajorParams: b.majorParams,
+			ID:          b.ID,
+			Limit:       b.Limit,
+			Remaining:   b.Remaining,
+			Reset:       b.Reset,
+			Pending:     int(b.pending.Load()),
+		})
+		b.stateMu.RUnlock()
+	}
+	return state
+}
+
+func (l *rateLimiterImpl) Restore(state RateLimiterState) {
+	now := time.Now()
+	if state.Global.After(now) {
+		l.globalMu.Lock()
+		if state.Global.After(l.global) {
+			l.global = state.Global
+		}
+		l.globalMu.Unlock()
+	}
+
+	l.bucketsMu.Lock()
+	defer l.bucketsMu.Unlock()
+	for _, rb := range state.Buckets {
+		if !rb.Reset.After(now) {
+			continue
+		}
+		key := rb.Key()
+		b, ok := l.buckets[key]
+		if !ok {
+			b = &bucket{
+				route:       rb.Route,
+				majorParams: rb.MajorParams,
+			}
+			l.buckets[key] = b
+		}
+		// skip buckets which are currently in use, they will be updated by the in-flight request
+		if !b.mu.TryLock() {
+			continue
+		}
+		b.stateMu.Lock()
+		b.ID = rb.ID
+		b.Limit = rb.Limit
+		b.Remaining = rb.Remaining
+		b.Reset = rb.Reset
+		b.stateMu.Unlock()
+		b.mu.Unlock()
+	}
+	l.config.Logger.Debug("restored rate limiter state", slog.Int("buckets", len(state.Buckets)), slog.Time("global", state.Global))
 }
 
 func (l *rateLimiterImpl) getRouteHash(endpoint *CompiledEndpoint) string {
@@ -132,14 +265,15 @@ func (l *rateLimiterImpl) getRouteHash(endpoint *CompiledEndpoint) string {
 		l.hashes[endpoint.Endpoint] = hash
 	}
 	l.hashesMu.Unlock()
-	if endpoint.MajorParams != "" {
-		hash += "+" + endpoint.MajorParams
-	}
 	return hash
 }
 
 func (l *rateLimiterImpl) getBucket(endpoint *CompiledEndpoint, create bool) *bucket {
-	hash := l.getRouteHash(endpoint)
+	route := l.getRouteHash(endpoint)
+	hash := route
+	if endpoint.MajorParams != "" {
+		hash += "+" + endpoint.MajorParams
+	}
 
 	l.config.Logger.Debug("locking buckets")
 	l.bucketsMu.Lock()
@@ -154,7 +288,9 @@ func (l *rateLimiterImpl) getBucket(endpoint *CompiledEndpoint, create bool) *bu
 		}
 
 		b = &bucket{
-			Remaining: 1,
+			route:       route,
+			majorParams: endpoint.MajorParams,
+			Remaining:   1,
 			// we don't know the limit yet
 			Limit: -1,
 		}
@@ -164,9 +300,27 @@ func (l *rateLimiterImpl) getBucket(endpoint *CompiledEndpoint, create bool) *bu
 }
 
 func (l *rateLimiterImpl) WaitBucket(ctx context.Context, endpoint *CompiledEndpoint) error {
+	delay, err := l.invalidRequestDelay()
+	if err != nil {
+		return err
+	}
+	if delay > 0 {
+		l.config.Logger.Debug("delaying request due to invalid requests", slog.String("endpoint", endpoint.URL), slog.Duration("delay", delay))
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+
 	b := l.getBucket(endpoint, true)
 	l.config.Logger.Debug("locking rest bucket", slog.String("id", b.ID), slog.Int("limit", b.Limit), slog.Int("remaining", b.Remaining), slog.Time("reset", b.Reset))
-	if err := b.mu.CLock(ctx); err != nil {
+	b.pending.Add(1)
+	err = b.mu.CLock(ctx)
+	b.pending.Add(-1)
+	if err != nil {
 		return err
 	}
 
@@ -176,7 +330,7 @@ func (l *rateLimiterImpl) WaitBucket(ctx context.Context, endpoint *CompiledEndp
 	if b.Remaining == 0 && b.Reset.After(now) {
 		until = b.Reset
 	} else {
-		until = l.global
+		until = l.getGlobal()
 	}
 
 	if until.After(now) {
@@ -196,12 +350,16 @@ func (l *rateLimiterImpl) WaitBucket(ctx context.Context, endpoint *CompiledEndp
 }
 
 func (l *rateLimiterImpl) UnlockBucket(endpoint *CompiledEndpoint, rs *http.Response) error {
+	l.trackInvalidRequest(endpoint, rs)
+
 	b := l.getBucket(endpoint, false)
 	if b == nil {
 		return nil
 	}
+	b.stateMu.Lock()
 	defer func() {
 		l.config.Logger.Debug("unlocking rest bucket", slog.String("id", b.ID), slog.Int("limit", b.Limit), slog.Int("remaining", b.Remaining), slog.Time("reset", b.Reset))
+		b.stateMu.Unlock()
 		b.mu.Unlock()
 	}()
 
@@ -236,10 +394,10 @@ func (l *rateLimiterImpl) UnlockBucket(endpoint *CompiledEndpoint, rs *http.Resp
 		}
 		reset := time.Now().Add(time.Second * time.Duration(retryAfter))
 		if global {
-			l.global = reset
+			l.setGlobal(reset)
 			l.config.Logger.Warn("global rate limit exceeded", slog.Int("retry_after", retryAfter))
 		} else if cloudflare {
-			l.global = reset
+			l.setGlobal(reset)
 			l.config.Logger.Warn("cloudflare rate limit exceeded", slog.Int("retry_after", retryAfter))
 		} else {
 			b.Remaining = 0
@@ -288,7 +446,13 @@ func (l *rateLimiterImpl) UnlockBucket(endpoint *CompiledEndpoint, rs *http.Resp
 }
 
 type bucket struct {
-	mu        csync.Mutex
+	mu csync.Mutex
+	// stateMu guards the exported fields for readers which don't hold mu
+	stateMu     sync.RWMutex
+	pending     atomic.Int32
+	route       string
+	majorParams string
+
 	ID        string
 	Reset     time.Time
 	Remaining int
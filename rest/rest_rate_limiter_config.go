@@ -8,9 +8,13 @@ import (
 // DefaultRateLimiterConfig is the configuration which is used by default.
 func DefaultRateLimiterConfig() *RateLimiterConfig {
 	return &RateLimiterConfig{
-		Logger:          slog.Default(),
-		MaxRetries:      MaxRetries,
-		CleanupInterval: CleanupInterval,
+		Logger:                       slog.Default(),
+		MaxRetries:                   MaxRetries,
+		CleanupInterval:              CleanupInterval,
+		InvalidRequestLimit:          InvalidRequestLimit,
+		InvalidRequestWindow:         InvalidRequestWindow,
+		InvalidRequestDelayThreshold: InvalidRequestDelayThreshold,
+		InvalidRequestMaxDelay:       InvalidRequestMaxDelay,
 	}
 }
 
@@ -19,6 +23,15 @@ type RateLimiterConfig struct {
 	Logger          *slog.Logger
 	MaxRetries      int
 	CleanupInterval time.Duration
+
+	// InvalidRequestLimit is the amount of invalid requests in InvalidRequestWindow after which all requests fail with ErrInvalidRequestLimitExceeded. A value <= 0 disables the limit.
+	InvalidRequestLimit int
+	// InvalidRequestWindow is the sliding window in which invalid requests are counted.
+	InvalidRequestWindow time.Duration
+	// InvalidRequestDelayThreshold is the amount of invalid requests after which requests are progressively delayed up to InvalidRequestMaxDelay.
+	InvalidRequestDelayThreshold int
+	// InvalidRequestMaxDelay is the delay added to requests right before reaching InvalidRequestLimit.
+	InvalidRequestMaxDelay time.Duration
 }
 
 // RateLimiterConfigOpt can be used to supply optional parameters to NewRateLimiter.
@@ -51,3 +64,20 @@ func WithCleanupInterval(cleanupInterval time.Duration) RateLimiterConfigOpt {
 		config.CleanupInterval = cleanupInterval
 	}
 }
+
+// WithInvalidRequestLimit tells the rest rate limiter to fail all requests with ErrInvalidRequestLimitExceeded once the given amount of invalid requests (401, 403 & 429) happened in the given window.
+// A limit <= 0 disables the invalid request tracking.
+func WithInvalidRequestLimit(limit int, window time.Duration) RateLimiterConfigOpt {
+	return func(config *RateLimiterConfig) {
+		config.InvalidRequestLimit = limit
+		config.InvalidRequestWindow = window
+	}
+}
+
+// WithInvalidRequestDelay tells the rest rate limiter to progressively delay requests up to maxDelay once the given amount of invalid requests is reached.
+func WithInvalidRequestDelay(threshold int, maxDelay time.Duration) RateLimiterConfigOpt {
+	return func(config *RateLimiterConfig) {
+		config.InvalidRequestDelayThreshold = threshold
+		config.InvalidRequestMaxDelay = maxDelay
+	}
+}
@@ -21,3 +21,9 @@ func (l *noopRateLimiter) Reset() {}
 func (l *noopRateLimiter) WaitBucket(_ context.Context, _ *CompiledEndpoint) error { return nil }
 
 func (l *noopRateLimiter) UnlockBucket(_ *CompiledEndpoint, _ *http.Response) error { return nil }
+
+func (l *noopRateLimiter) InvalidRequests() int { return 0 }
+
+func (l *noopRateLimiter) State() RateLimiterState { return RateLimiterState{} }
+
+func (l *noopRateLimiter) Restore(_ RateLimiterState) {}
@@ -0,0 +1,66 @@
+package rest
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/disgoorg/json"
+)
+
+// RateLimiterState is a snapshot of the learned rate limit buckets & the global rate limit of a RateLimiter.
+// It can be persisted with SaveRateLimiterState & restored with RateLimiter.Restore to not send the first requests after a restart blind into exhausted buckets.
+type RateLimiterState struct {
+	// Global is the time at which the global rate limit resets. A zero value means no global rate limit is active.
+	Global  time.Time         `json:"global"`
+	Buckets []RateLimitBucket `json:"buckets"`
+}
+
+// RateLimitBucket is a snapshot of a single rate limit bucket.
+type RateLimitBucket struct {
+	// Route is the method & route of the Endpoint the bucket belongs to
+	Route string `json:"route"`
+	// MajorParams are the major parameters of the bucket
+	MajorParams string `json:"major_params,omitempty"`
+	// ID is the bucket hash Discord sent in the X-RateLimit-Bucket header
+	ID string `json:"id,omitempty"`
+	// Limit is the amount of requests allowed per reset or -1 if it's not known yet
+	Limit     int       `json:"limit"`
+	Remaining int       `json:"remaining"`
+	Reset     time.Time `json:"reset"`
+	// Pending is the amount of requests waiting for the bucket. It is not restored.
+	Pending int `json:"pending,omitempty"`
+}
+
+// Key returns the key which the RateLimiter uses to identify the bucket.
+func (b RateLimitBucket) Key() string {
+	if b.MajorParams == "" {
+		return b.Route
+	}
+	return b.Route + "+" + b.MajorParams
+}
+
+// SaveRateLimiterState writes the given RateLimiterState as json to the given file.
+func SaveRateLimiterState(path string, state RateLimiterState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to marshal rate limiter state: %w", err)
+	}
+	if err = os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write rate limiter state: %w", err)
+	}
+	return nil
+}
+
+// LoadRateLimiterState reads a RateLimiterState previously written by SaveRateLimiterState from the given file.
+func LoadRateLimiterState(path string) (*RateLimiterState, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rate limiter state: %w", err)
+	}
+	var state RateLimiterState
+	if err = json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal rate limiter state: %w", err)
+	}
+	return &state, nil
+}
@@ -0,0 +1,183 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/json"
+	"github.com/stretchr/testify/assert"
+)
+
+func rateLimitResponse(statusCode int, header map[string]string) *http.Response {
+	rs := &http.Response{
+		StatusCode: statusCode,
+		Header:     http.Header{},
+	}
+	for k, v := range header {
+		rs.Header.Set(k, v)
+	}
+	return rs
+}
+
+func TestRateLimiterState_RoundTrip(t *testing.T) {
+	rl := NewRateLimiter()
+	endpoint := GetChannel.Compile(nil, 123)
+
+	assert.NoError(t, rl.WaitBucket(context.Background(), endpoint))
+	assert.NoError(t, rl.UnlockBucket(endpoint, rateLimitResponse(http.StatusOK, map[string]string{
+		"X-RateLimit-Bucket":      "abc",
+		"X-RateLimit-Limit":       "5",
+		"X-RateLimit-Remaining":   "0",
+		"X-RateLimit-Reset-After": "60",
+	})))
+
+	// a 429 without the via header is treated as a cloudflare rate limit
+	other := GetGateway.Compile(nil)
+	assert.NoError(t, rl.WaitBucket(context.Background(), other))
+	assert.NoError(t, rl.UnlockBucket(other, rateLimitResponse(http.StatusTooManyRequests, map[string]string{
+		"X-RateLimit-Bucket": "def",
+		"Retry-After":        "60",
+	})))
+
+	state := rl.State()
+	assert.True(t, state.Global.After(time.Now()))
+	assert.Len(t, state.Buckets, 2)
+
+	path := filepath.Join(t.TempDir(), "rate_limits.json")
+	assert.NoError(t, SaveRateLimiterState(path, state))
+	loaded, err := LoadRateLimiterState(path)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.True(t, state.Global.Equal(loaded.Global))
+
+	restored := NewRateLimiter()
+	restored.Restore(*loaded)
+	restoredState := restored.State()
+	assert.True(t, state.Global.Equal(restoredState.Global))
+	// the gateway bucket has no known reset & is skipped
+	if !assert.Len(t, restoredState.Buckets, 1) {
+		return
+	}
+
+	b := restoredState.Buckets[0]
+	assert.Equal(t, http.MethodGet+"+"+GetChannel.Route+"+channel.id=123", b.Key())
+	assert.Equal(t, "abc", b.ID)
+	assert.Equal(t, 5, b.Limit)
+	assert.Equal(t, 0, b.Remaining)
+	assert.True(t, b.Reset.After(time.Now().Add(50*time.Second)))
+
+	// the restored bucket is exhausted & must not be used before its reset
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, restored.WaitBucket(ctx, endpoint), context.DeadlineExceeded)
+}
+
+func TestRateLimiterState_RestoreSkipsExpired(t *testing.T) {
+	data, err := json.Marshal(RateLimiterState{
+		Global: time.Now().Add(-time.Second),
+		Buckets: []RateLimitBucket{
+			{Route: "GET+/channels/{channel.id}", MajorParams: "1", ID: "expired", Limit: 5, Remaining: 0, Reset: time.Now().Add(-time.Second)},
+			{Route: "GET+/channels/{channel.id}", MajorParams: "2", ID: "active", Limit: 5, Remaining: 3, Reset: time.Now().Add(time.Minute)},
+		},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	var state RateLimiterState
+	if !assert.NoError(t, json.Unmarshal(data, &state)) {
+		return
+	}
+
+	rl := NewRateLimiter()
+	rl.Restore(state)
+
+	restored := rl.State()
+	assert.True(t, restored.Global.IsZero())
+	if assert.Len(t, restored.Buckets, 1) {
+		assert.Equal(t, "active", restored.Buckets[0].ID)
+		assert.Equal(t, 3, restored.Buckets[0].Remaining)
+	}
+}
+
+func TestRateLimiterState_Concurrent(t *testing.T) {
+	rl := NewRateLimiter()
+	reset := time.Now().Add(time.Minute).Round(0)
+	state := RateLimiterState{Global: time.Now().Add(10 * time.Millisecond)}
+	for i := 0; i < 10; i++ {
+		state.Buckets = append(state.Buckets, RateLimitBucket{
+			Route:       http.MethodGet + "+" + GetChannel.Route,
+			MajorParams: "channel.id=" + strconv.Itoa(i),
+			ID:          "restored",
+			Limit:       5,
+			Remaining:   3,
+			Reset:       reset,
+		})
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(3)
+		endpoint := GetChannel.Compile(nil, 100+i)
+		header := map[string]string{
+			"X-RateLimit-Bucket":      "requested",
+			"X-RateLimit-Limit":       "5",
+			"X-RateLimit-Remaining":   "4",
+			"X-RateLimit-Reset-After": "60",
+		}
+		statusCode := http.StatusOK
+		if i%2 == 1 {
+			statusCode = http.StatusTooManyRequests
+			header = map[string]string{
+				"X-RateLimit-Bucket": "global",
+				"X-RateLimit-Global": "true",
+				"Retry-After":        "0",
+			}
+		}
+		go func() {
+			defer wg.Done()
+			rl.Restore(state)
+		}()
+		go func() {
+			defer wg.Done()
+			_ = rl.State()
+		}()
+		go func() {
+			defer wg.Done()
+			if err := rl.WaitBucket(context.Background(), endpoint); err != nil {
+				return
+			}
+			_ = rl.UnlockBucket(endpoint, rateLimitResponse(statusCode, header))
+		}()
+	}
+	wg.Wait()
+
+	buckets := map[string]RateLimitBucket{}
+	for _, b := range rl.State().Buckets {
+		buckets[b.Key()] = b
+	}
+	assert.Len(t, buckets, 20)
+	for i := 0; i < 10; i++ {
+		restored := buckets[http.MethodGet+"+"+GetChannel.Route+"+channel.id="+strconv.Itoa(i)]
+		assert.Equal(t, "restored", restored.ID)
+		assert.Equal(t, 3, restored.Remaining)
+		assert.True(t, reset.Equal(restored.Reset))
+
+		requested := buckets[http.MethodGet+"+"+GetChannel.Route+"+channel.id="+strconv.Itoa(100+i)]
+		assert.Zero(t, requested.Pending)
+		if i%2 == 1 {
+			assert.Equal(t, "global", requested.ID)
+			continue
+		}
+		assert.Equal(t, "requested", requested.ID)
+		assert.Equal(t, 4, requested.Remaining)
+		assert.True(t, requested.Reset.After(time.Now().Add(50*time.Second)))
+	}
+	// the global rate limit of the state & the responses is already over
+	assert.False(t, rl.State().Global.After(time.Now().Add(time.Second)))
+}
@@ -0,0 +1,64 @@
+package rest
+
+import (
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+var _ SoundboardSounds = (*soundboardSoundImpl)(nil)
+
+func NewSoundboardSounds(client Client) SoundboardSounds {
+	return &soundboardSoundImpl{client: client}
+}
+
+type SoundboardSounds interface {
+	GetSoundboardDefaultSounds(opts ...RequestOpt) ([]discord.SoundboardSound, error)
+	GetGuildSoundboardSounds(guildID snowflake.ID, opts ...RequestOpt) ([]discord.SoundboardSound, error)
+	GetGuildSoundboardSound(guildID snowflake.ID, soundID snowflake.ID, opts ...RequestOpt) (*discord.SoundboardSound, error)
+	CreateGuildSoundboardSound(guildID snowflake.ID, soundCreate discord.SoundboardSoundCreate, opts ...RequestOpt) (*discord.SoundboardSound, error)
+	UpdateGuildSoundboardSound(guildID snowflake.ID, soundID snowflake.ID, soundUpdate discord.SoundboardSoundUpdate, opts ...RequestOpt) (*discord.SoundboardSound, error)
+	DeleteGuildSoundboardSound(guildID snowflake.ID, soundID snowflake.ID, opts ...RequestOpt) error
+	SendSoundboardSound(channelID snowflake.ID, sendSoundboardSound discord.SendSoundboardSound, opts ...RequestOpt) error
+}
+
+type soundboardSoundImpl struct {
+	client Client
+}
+
+func (s *soundboardSoundImpl) GetSoundboardDefaultSounds(opts ...RequestOpt) (sounds []discord.SoundboardSound, err error) {
+	err = s.client.Do(GetSoundboardDefaultSounds.Compile(nil), nil, &sounds, opts...)
+	return
+}
+
+func (s *soundboardSoundImpl) GetGuildSoundboardSounds(guildID snowflake.ID, opts ...RequestOpt) (sounds []discord.SoundboardSound, err error) {
+	var rs discord.SoundboardSounds
+	err = s.client.Do(GetGuildSoundboardSounds.Compile(nil, guildID), nil, &rs, opts...)
+	if err == nil {
+		sounds = rs.Items
+	}
+	return
+}
+
+func (s *soundboardSoundImpl) GetGuildSoundboardSound(guildID snowflake.ID, soundID snowflake.ID, opts ...RequestOpt) (sound *discord.SoundboardSound, err error) {
+	err = s.client.Do(GetGuildSoundboardSound.Compile(nil, guildID, soundID), nil, &sound, opts...)
+	return
+}
+
+func (s *soundboardSoundImpl) CreateGuildSoundboardSound(guildID snowflake.ID, soundCreate discord.SoundboardSoundCreate, opts ...RequestOpt) (sound *discord.SoundboardSound, err error) {
+	err = s.client.Do(CreateGuildSoundboardSound.Compile(nil, guildID), soundCreate, &sound, opts...)
+	return
+}
+
+func (s *soundboardSoundImpl) UpdateGuildSoundboardSound(guildID snowflake.ID, soundID snowflake.ID, soundUpdate discord.SoundboardSoundUpdate, opts ...RequestOpt) (sound *discord.SoundboardSound, err error) {
+	err = s.client.Do(UpdateGuildSoundboardSound.Compile(nil, guildID, soundID), soundUpdate, &sound, opts...)
+	return
+}
+
+func (s *soundboardSoundImpl) DeleteGuildSoundboardSound(guildID snowflake.ID, soundID snowflake.ID, opts ...RequestOpt) error {
+	return s.client.Do(DeleteGuildSoundboardSound.Compile(nil, guildID, soundID), nil, nil, opts...)
+}
+
+func (s *soundboardSoundImpl) SendSoundboardSound(channelID snowflake.ID, sendSoundboardSound discord.SendSoundboardSound, opts ...RequestOpt) error {
+	return s.client.Do(SendSoundboardSound.Compile(nil, channelID), sendSoundboardSound, nil, opts...)
+}
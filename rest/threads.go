@@ -27,6 +27,8 @@ type Threads interface {
 	GetThreadMembers(threadID snowflake.ID, opts ...RequestOpt) (threadMembers []discord.ThreadMember, err error)
 	GetThreadMembersPage(threadID snowflake.ID, startID snowflake.ID, limit int, opts ...RequestOpt) ThreadMemberPage
 
+	GetActiveGuildThreads(guildID snowflake.ID, opts ...RequestOpt) (threads *discord.GetAllThreads, err error)
+
 	GetPublicArchivedThreads(channelID snowflake.ID, before time.Time, limit int, opts ...RequestOpt) (threads *discord.GetThreads, err error)
 	GetPrivateArchivedThreads(channelID snowflake.ID, before time.Time, limit int, opts ...RequestOpt) (threads *discord.GetThreads, err error)
 	GetJoinedPrivateArchivedThreads(channelID snowflake.ID, before time.Time, limit int, opts ...RequestOpt) (threads *discord.GetThreads, err error)
@@ -97,6 +99,11 @@ func (s *threadImpl) GetThreadMembersPage(threadID snowflake.ID, startID snowfla
 	}
 }
 
+func (s *threadImpl) GetActiveGuildThreads(guildID snowflake.ID, opts ...RequestOpt) (threads *discord.GetAllThreads, err error) {
+	err = s.client.Do(GetActiveGuildThreads.Compile(nil, guildID), nil, &threads, opts...)
+	return
+}
+
 func (s *threadImpl) GetPublicArchivedThreads(channelID snowflake.ID, before time.Time, limit int, opts ...RequestOpt) (threads *discord.GetThreads, err error) {
 	queryValues := discord.QueryValues{}
 	if !before.IsZero() {
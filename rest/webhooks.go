@@ -78,7 +78,7 @@ func (s *webhookImpl) DeleteWebhookWithToken(webhookID snowflake.ID, webhookToke
 	return
 }
 
-func (s *webhookImpl) createWebhookMessage(webhookID snowflake.ID, webhookToken string, messageCreate discord.Payload, wait bool, threadID snowflake.ID, endpoint *Endpoint, opts []RequestOpt) (message *discord.Message, err error) {
+func (s *webhookImpl) createWebhookMessage(webhookID snowflake.ID, webhookToken string, messageCreate discord.Payload, wait bool, threadID snowflake.ID, withComponents bool, endpoint *Endpoint, opts []RequestOpt) (message *discord.Message, err error) {
 	params := discord.QueryValues{}
 	if wait {
 		params["wait"] = true
@@ -86,6 +86,10 @@ func (s *webhookImpl) createWebhookMessage(webhookID snowflake.ID, webhookToken
 	if threadID != 0 {
 		params["thread_id"] = threadID
 	}
+	if withComponents {
+		// non-application-owned webhooks only send components with this set
+		params["with_components"] = true
+	}
 	compiledEndpoint := endpoint.Compile(params, webhookID, webhookToken)
 
 	body, err := messageCreate.ToBody()
@@ -102,15 +106,15 @@ func (s *webhookImpl) createWebhookMessage(webhookID snowflake.ID, webhookToken
 }
 
 func (s *webhookImpl) CreateWebhookMessage(webhookID snowflake.ID, webhookToken string, messageCreate discord.WebhookMessageCreate, wait bool, threadID snowflake.ID, opts ...RequestOpt) (*discord.Message, error) {
-	return s.createWebhookMessage(webhookID, webhookToken, messageCreate, wait, threadID, CreateWebhookMessage, opts)
+	return s.createWebhookMessage(webhookID, webhookToken, messageCreate, wait, threadID, len(messageCreate.Components) > 0, CreateWebhookMessage, opts)
 }
 
 func (s *webhookImpl) CreateWebhookMessageSlack(webhookID snowflake.ID, webhookToken string, messageCreate discord.Payload, wait bool, threadID snowflake.ID, opts ...RequestOpt) (*discord.Message, error) {
-	return s.createWebhookMessage(webhookID, webhookToken, messageCreate, wait, threadID, CreateWebhookMessageSlack, opts)
+	return s.createWebhookMessage(webhookID, webhookToken, messageCreate, wait, threadID, false, CreateWebhookMessageSlack, opts)
 }
 
 func (s *webhookImpl) CreateWebhookMessageGitHub(webhookID snowflake.ID, webhookToken string, messageCreate discord.Payload, wait bool, threadID snowflake.ID, opts ...RequestOpt) (*discord.Message, error) {
-	return s.createWebhookMessage(webhookID, webhookToken, messageCreate, wait, threadID, CreateWebhookMessageGitHub, opts)
+	return s.createWebhookMessage(webhookID, webhookToken, messageCreate, wait, threadID, false, CreateWebhookMessageGitHub, opts)
 }
 
 func (s *webhookImpl) UpdateWebhookMessage(webhookID snowflake.ID, webhookToken string, messageID snowflake.ID, messageUpdate discord.WebhookMessageUpdate, threadID snowflake.ID, opts ...RequestOpt) (message *discord.Message, err error) {
@@ -118,6 +122,9 @@ func (s *webhookImpl) UpdateWebhookMessage(webhookID snowflake.ID, webhookToken
 	if threadID != 0 {
 		params["thread_id"] = threadID
 	}
+	if messageUpdate.Components != nil && len(*messageUpdate.Components) > 0 {
+		params["with_components"] = true
+	}
 	body, err := messageUpdate.ToBody()
 	if err != nil {
 		return
@@ -92,7 +92,12 @@ func (s *defaultAudioReceiver) receive() {
 		return
 	}
 	if s.opusReceiver != nil {
-		if err = s.opusReceiver.ReceiveOpusFrame(s.conn.UserIDBySSRC(packet.SSRC), packet); err != nil {
+		userID := s.conn.UserIDBySSRC(packet.SSRC)
+		if packet.Opus, err = s.conn.DAVE().DecryptFrame(userID, packet.Opus); err != nil {
+			s.logger.Debug("error while decrypting opus frame", slog.Any("err", err), slog.String("user_id", userID.String()))
+			return
+		}
+		if err = s.opusReceiver.ReceiveOpusFrame(userID, packet); err != nil {
 			s.logger.Error("error while receiving opus frame", slog.Any("err", err))
 		}
 	}
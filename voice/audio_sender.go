@@ -133,6 +133,13 @@ func (s *defaultAudioSender) send() {
 		s.silentFrames = 5
 	}
 
+	if opus, err = s.conn.DAVE().EncryptFrame(opus); err != nil {
+		// frames can't be sent until the DAVE MLS group was joined
+		if !errors.Is(err, ErrDAVENotReady) {
+			s.handleErr(err)
+		}
+		return
+	}
 	if _, err = s.conn.UDP().Write(opus); err != nil {
 		s.handleErr(err)
 	}
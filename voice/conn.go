@@ -23,6 +23,9 @@ type (
 		// UDP returns the voice UDPConn conn used by the voice Conn.
 		UDP() UDPConn
 
+		// DAVE returns the DAVE used to end-to-end encrypt & decrypt opus frames.
+		DAVE() DAVE
+
 		// ChannelID returns the ID of the voice channel the voice Conn is openedChan to.
 		ChannelID() *snowflake.ID
 
@@ -77,8 +80,15 @@ func NewConn(guildID snowflake.ID, userID snowflake.ID, voiceStateUpdateFunc Sta
 		ssrcs:      map[uint32]snowflake.ID{},
 	}
 
-	conn.gateway = config.GatewayCreateFunc(conn.handleMessage, conn.handleGatewayClose, append([]GatewayConfigOpt{WithGatewayLogger(config.Logger)}, config.GatewayConfigOpts...)...)
+	gatewayConfigOpts := []GatewayConfigOpt{WithGatewayLogger(config.Logger)}
+	if config.DAVESessionCreateFunc != nil {
+		gatewayConfigOpts = append(gatewayConfigOpts, WithGatewayMaxDAVEProtocolVersion(DAVEProtocolVersion))
+	}
+	conn.gateway = config.GatewayCreateFunc(conn.handleMessage, conn.handleGatewayClose, append(gatewayConfigOpts, config.GatewayConfigOpts...)...)
 	conn.udp = config.UDPConnCreateFunc(append([]UDPConnConfigOpt{WithUDPConnLogger(config.Logger)}, config.UDPConnConfigOpts...)...)
+	if config.DAVESessionCreateFunc != nil {
+		conn.dave = newDAVE(config.Logger, config.DAVESessionCreateFunc(), conn.gateway, userID, conn.ChannelID)
+	}
 
 	return conn
 }
@@ -93,6 +103,7 @@ type connImpl struct {
 
 	gateway Gateway
 	udp     UDPConn
+	dave    *daveImpl
 
 	audioSender   AudioSender
 	audioReceiver AudioReceiver
@@ -133,6 +144,13 @@ func (c *connImpl) UDP() UDPConn {
 	return c.udp
 }
 
+func (c *connImpl) DAVE() DAVE {
+	if c.dave == nil {
+		return noDAVE{}
+	}
+	return c.dave
+}
+
 func (c *connImpl) SetOpusFrameProvider(provider OpusFrameProvider) {
 	if c.audioSender != nil {
 		c.audioSender.Close()
@@ -196,10 +214,18 @@ func (c *connImpl) HandleVoiceServerUpdate(update botgateway.EventVoiceServerUpd
 }
 
 func (c *connImpl) handleMessage(op Opcode, data GatewayMessageData) {
+	if c.dave != nil {
+		c.dave.handleMessage(data)
+	}
 	switch d := data.(type) {
 	case GatewayMessageDataReady:
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
+		mode, err := ChooseEncryptionMode(d.Modes)
+		if err != nil {
+			c.config.Logger.Error("voice: failed to choose encryption mode", slog.Any("err", err))
+			break
+		}
 		ourAddress, ourPort, err := c.udp.Open(ctx, d.IP, d.Port, d.SSRC)
 		if err != nil {
 			c.config.Logger.Error("voice: failed to open voiceudp conn", slog.Any("err", err))
@@ -210,14 +236,17 @@ func (c *connImpl) handleMessage(op Opcode, data GatewayMessageData) {
 			Data: GatewayMessageDataSelectProtocolData{
 				Address: ourAddress,
 				Port:    ourPort,
-				Mode:    EncryptionModeNormal,
+				Mode:    mode,
 			},
 		}); err != nil {
 			c.config.Logger.Error("voice: failed to send select protocol", slog.Any("err", err))
 		}
 
 	case GatewayMessageDataSessionDescription:
-		c.udp.SetSecretKey(d.SecretKey)
+		if err := c.udp.SetSecretKey(d.Mode, d.SecretKey); err != nil {
+			c.config.Logger.Error("voice: failed to set secret key", slog.Any("err", err))
+			break
+		}
 		c.openedChan <- struct{}{}
 
 	case GatewayMessageDataSpeaking:
@@ -12,6 +12,7 @@ func DefaultConnConfig() *ConnConfig {
 		UDPConnCreateFunc:       NewUDPConn,
 		AudioSenderCreateFunc:   NewAudioSender,
 		AudioReceiverCreateFunc: NewAudioReceiver,
+		DAVESessionCreateFunc:   NewDAVESession,
 	}
 }
 
@@ -28,6 +29,9 @@ type ConnConfig struct {
 	AudioSenderCreateFunc   AudioSenderCreateFunc
 	AudioReceiverCreateFunc AudioReceiverCreateFunc
 
+	// DAVESessionCreateFunc is used to create the DAVESession of the Conn. Defaults to NewDAVESession. If nil, the DAVE protocol is not supported & E2EE calls can't be joined.
+	DAVESessionCreateFunc DAVESessionCreateFunc
+
 	EventHandlerFunc EventHandlerFunc
 }
 
@@ -96,3 +100,10 @@ func WithConnEventHandlerFunc(eventHandlerFunc EventHandlerFunc) ConnConfigOpt {
 		config.EventHandlerFunc = eventHandlerFunc
 	}
 }
+
+// WithConnDAVESessionCreateFunc sets the Conn(s) used DAVESessionCreateFunc which enables the DAVE protocol. Use nil to disable DAVE.
+func WithConnDAVESessionCreateFunc(daveSessionCreateFunc DAVESessionCreateFunc) ConnConfigOpt {
+	return func(config *ConnConfig) {
+		config.DAVESessionCreateFunc = daveSessionCreateFunc
+	}
+}
@@ -0,0 +1,370 @@
+package voice
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// DAVETransitionExpiry is the duration the keys of the previous epoch are kept to decrypt frames sent before a transition.
+const DAVETransitionExpiry = 10 * time.Second
+
+// ErrDAVENotReady is returned when frames are encrypted or decrypted before the MLS group was joined.
+var ErrDAVENotReady = errors.New("dave session not ready")
+
+type (
+	// DAVESessionCreateFunc is used to create a new DAVESession.
+	DAVESessionCreateFunc func() DAVESession
+
+	// DAVESession is the MLS group state of the DAVE protocol (https://daveprotocol.com).
+	// NewDAVESession returns the default implementation.
+	// The MLS cipher suite is MLS_128_DHKEMP256_AES128GCM_SHA256_P256 & the credential is a basic credential containing the big endian user ID.
+	DAVESession interface {
+		// Init resets the MLS group state for the given protocol version & returns the MLS key package of the own user.
+		// The group ID is the channel ID.
+		Init(protocolVersion int, groupID snowflake.ID, userID snowflake.ID) (keyPackage []byte, err error)
+
+		// SetExternalSender sets the MLS external sender of the voice gateway.
+		SetExternalSender(externalSender []byte) error
+
+		// ProcessProposals appends or revokes the proposals & returns the commit followed by the optional welcome if there are proposals left to commit.
+		// Proposals adding users which are not in the recognizedUserIDs must be rejected.
+		ProcessProposals(operation DAVEProposalsOperation, proposals []byte, recognizedUserIDs []snowflake.ID) (commitWelcome []byte, err error)
+
+		// ProcessCommit processes the commit of the next epoch.
+		// ErrDAVENotReady is returned if the own user is not in the group yet & is added by a welcome.
+		ProcessCommit(commit []byte) error
+
+		// ProcessWelcome joins the MLS group with the welcome. The group must only contain users in the recognizedUserIDs.
+		ProcessWelcome(welcome []byte, recognizedUserIDs []snowflake.ID) error
+
+		// ExportSenderBaseSecret returns the base secret of the user for the current epoch.
+		// It's the MLS exporter with the DAVEExporterLabel, the little endian user ID as context & a length of 16.
+		ExportSenderBaseSecret(userID snowflake.ID) ([]byte, error)
+	}
+
+	// DAVE handles the DAVE protocol of a Conn & end-to-end encrypts and decrypts opus frames.
+	DAVE interface {
+		// ProtocolVersion returns the current DAVE protocol version. 0 means frames are not end-to-end encrypted.
+		ProtocolVersion() int
+
+		// EncryptFrame encrypts the opus frame of the own user.
+		EncryptFrame(frame []byte) ([]byte, error)
+
+		// DecryptFrame decrypts the opus frame of the given user.
+		DecryptFrame(userID snowflake.ID, frame []byte) ([]byte, error)
+	}
+)
+
+// daveGateway is the part of the Gateway used by daveImpl.
+type daveGateway interface {
+	Send(ctx context.Context, opCode Opcode, data GatewayMessageData) error
+	SendBinary(ctx context.Context, opCode Opcode, data []byte) error
+}
+
+func newDAVE(logger *slog.Logger, session DAVESession, gateway daveGateway, userID snowflake.ID, channelID func() *snowflake.ID) *daveImpl {
+	return &daveImpl{
+		logger:             logger.With(slog.String("name", "voice_conn_dave")),
+		session:            session,
+		gateway:            gateway,
+		userID:             userID,
+		channelID:          channelID,
+		recognizedUserIDs:  map[snowflake.ID]struct{}{},
+		pendingTransitions: map[uint16]int{},
+		decryptors:         map[snowflake.ID]*daveDecryptor{},
+	}
+}
+
+type daveDecryptor struct {
+	*DAVEFrameDecryptor
+	epoch int
+}
+
+type daveImpl struct {
+	logger *slog.Logger
+	// sessionMu serializes calls to the DAVESession. It's always locked after mu.
+	session   DAVESession
+	sessionMu sync.Mutex
+	gateway   daveGateway
+	userID    snowflake.ID
+	channelID func() *snowflake.ID
+
+	mu                 sync.Mutex
+	protocolVersion    int
+	recognizedUserIDs  map[snowflake.ID]struct{}
+	pendingTransitions map[uint16]int
+	// epoch is incremented every time a commit or welcome was processed
+	epoch            int
+	encryptor        *DAVEFrameEncryptor
+	pendingEncryptor bool
+	decryptors       map[snowflake.ID]*daveDecryptor
+	passthroughUntil time.Time
+}
+
+func (d *daveImpl) ProtocolVersion() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.protocolVersion
+}
+
+func (d *daveImpl) EncryptFrame(frame []byte) ([]byte, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.protocolVersion == 0 {
+		return frame, nil
+	}
+	if d.encryptor == nil {
+		return nil, ErrDAVENotReady
+	}
+	return d.encryptor.Encrypt(frame)
+}
+
+func (d *daveImpl) DecryptFrame(userID snowflake.ID, frame []byte) ([]byte, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.protocolVersion == 0 {
+		return frame, nil
+	}
+	if _, err := parseDAVEFrame(frame); err != nil && time.Now().Before(d.passthroughUntil) {
+		// unencrypted frames are allowed while transitioning to DAVE
+		return frame, nil
+	}
+	if d.epoch == 0 {
+		return nil, ErrDAVENotReady
+	}
+
+	decryptor, ok := d.decryptors[userID]
+	if !ok || decryptor.epoch != d.epoch {
+		baseSecret, err := d.exportSenderBaseSecret(userID)
+		if err != nil {
+			return nil, err
+		}
+		ratchet := NewDAVEKeyRatchet(baseSecret)
+		if ok {
+			decryptor.TransitionToKeyRatchet(ratchet)
+			decryptor.epoch = d.epoch
+			time.AfterFunc(DAVETransitionExpiry, d.expireDecryptor(userID, d.epoch))
+		} else {
+			decryptor = &daveDecryptor{DAVEFrameDecryptor: NewDAVEFrameDecryptor(ratchet), epoch: d.epoch}
+			d.decryptors[userID] = decryptor
+		}
+	}
+	return decryptor.Decrypt(frame)
+}
+
+func (d *daveImpl) expireDecryptor(userID snowflake.ID, epoch int) func() {
+	return func() {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		if decryptor, ok := d.decryptors[userID]; ok && decryptor.epoch == epoch {
+			decryptor.ExpirePrevious()
+		}
+	}
+}
+
+// handleMessage handles the DAVE related voice gateway messages.
+func (d *daveImpl) handleMessage(data GatewayMessageData) {
+	switch m := data.(type) {
+	case GatewayMessageDataSessionDescription:
+		d.mu.Lock()
+		d.protocolVersion = m.DAVEProtocolVersion
+		d.mu.Unlock()
+		if m.DAVEProtocolVersion > 0 {
+			d.init(m.DAVEProtocolVersion)
+		}
+
+	case GatewayMessageDataClientsConnect:
+		d.mu.Lock()
+		for _, userID := range m.UserIDs {
+			d.recognizedUserIDs[userID] = struct{}{}
+		}
+		d.mu.Unlock()
+
+	case GatewayMessageDataClientDisconnect:
+		d.mu.Lock()
+		delete(d.recognizedUserIDs, m.UserID)
+		delete(d.decryptors, m.UserID)
+		d.mu.Unlock()
+
+	case GatewayMessageDataDAVEPrepareTransition:
+		d.prepareTransition(m.TransitionID, m.ProtocolVersion)
+
+	case GatewayMessageDataDAVEExecuteTransition:
+		d.executeTransition(m.TransitionID)
+
+	case GatewayMessageDataDAVEPrepareEpoch:
+		// epoch 1 means a new MLS group is created
+		if m.Epoch == 1 {
+			d.init(m.ProtocolVersion)
+		}
+
+	case GatewayMessageDataDAVEMLSExternalSenderPackage:
+		d.sessionMu.Lock()
+		err := d.session.SetExternalSender(m)
+		d.sessionMu.Unlock()
+		if err != nil {
+			d.logger.Error("failed to set dave external sender", slog.Any("err", err))
+		}
+
+	case GatewayMessageDataDAVEMLSProposals:
+		recognizedUserIDs := d.recognized()
+		d.sessionMu.Lock()
+		commitWelcome, err := d.session.ProcessProposals(m.Operation, m.Proposals, recognizedUserIDs)
+		d.sessionMu.Unlock()
+		if err != nil {
+			d.logger.Error("failed to process dave proposals", slog.Any("err", err))
+			return
+		}
+		if len(commitWelcome) > 0 {
+			d.sendBinary(OpcodeDAVEMLSCommitWelcome, commitWelcome)
+		}
+
+	case GatewayMessageDataDAVEMLSAnnounceCommitTransition:
+		d.sessionMu.Lock()
+		err := d.session.ProcessCommit(m.Commit)
+		d.sessionMu.Unlock()
+		if errors.Is(err, ErrDAVENotReady) {
+			// the own user is not in the group yet & joins with the welcome of the commit
+			d.logger.Debug("ignoring dave commit before joining the group", slog.Int("transition_id", int(m.TransitionID)))
+			return
+		}
+		d.handleEpoch(m.TransitionID, err)
+
+	case GatewayMessageDataDAVEMLSWelcome:
+		recognizedUserIDs := d.recognized()
+		d.sessionMu.Lock()
+		err := d.session.ProcessWelcome(m.Welcome, recognizedUserIDs)
+		d.sessionMu.Unlock()
+		d.handleEpoch(m.TransitionID, err)
+	}
+}
+
+// init resets the MLS group & sends the key package of the own user.
+func (d *daveImpl) init(protocolVersion int) {
+	channelID := d.channelID()
+	if channelID == nil {
+		return
+	}
+	d.sessionMu.Lock()
+	keyPackage, err := d.session.Init(protocolVersion, *channelID, d.userID)
+	d.sessionMu.Unlock()
+	if err != nil {
+		d.logger.Error("failed to init dave session", slog.Any("err", err))
+		return
+	}
+	d.sendBinary(OpcodeDAVEMLSKeyPackage, keyPackage)
+}
+
+// handleEpoch handles the result of a processed commit or welcome.
+func (d *daveImpl) handleEpoch(transitionID uint16, err error) {
+	if err != nil {
+		d.logger.Error("failed to process dave commit or welcome", slog.Any("err", err), slog.Int("transition_id", int(transitionID)))
+		d.send(OpcodeDAVEMLSInvalidCommitWelcome, GatewayMessageDataDAVEMLSInvalidCommitWelcome{TransitionID: transitionID})
+		protocolVersion := d.ProtocolVersion()
+		if protocolVersion == 0 {
+			protocolVersion = DAVEProtocolVersion
+		}
+		d.init(protocolVersion)
+		return
+	}
+
+	d.mu.Lock()
+	d.epoch++
+	d.pendingEncryptor = true
+	protocolVersion := d.protocolVersion
+	if protocolVersion == 0 {
+		protocolVersion = DAVEProtocolVersion
+	}
+	d.mu.Unlock()
+	d.prepareTransition(transitionID, protocolVersion)
+}
+
+func (d *daveImpl) prepareTransition(transitionID uint16, protocolVersion int) {
+	d.mu.Lock()
+	d.pendingTransitions[transitionID] = protocolVersion
+	d.mu.Unlock()
+
+	// transition 0 is the initial transition & executed immediately
+	if transitionID == 0 {
+		d.executeTransition(transitionID)
+		return
+	}
+	d.send(OpcodeDAVETransitionReady, GatewayMessageDataDAVETransitionReady{TransitionID: transitionID})
+}
+
+func (d *daveImpl) executeTransition(transitionID uint16) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	protocolVersion, ok := d.pendingTransitions[transitionID]
+	if !ok {
+		d.logger.Debug("received unknown dave transition", slog.Int("transition_id", int(transitionID)))
+		return
+	}
+	delete(d.pendingTransitions, transitionID)
+
+	if d.protocolVersion == 0 && protocolVersion > 0 {
+		d.passthroughUntil = time.Now().Add(DAVETransitionExpiry)
+	}
+	d.protocolVersion = protocolVersion
+	if protocolVersion == 0 {
+		d.encryptor = nil
+		d.decryptors = map[snowflake.ID]*daveDecryptor{}
+		return
+	}
+	if !d.pendingEncryptor {
+		return
+	}
+	baseSecret, err := d.exportSenderBaseSecret(d.userID)
+	if err != nil {
+		d.logger.Error("failed to export dave sender base secret", slog.Any("err", err))
+		return
+	}
+	d.encryptor = NewDAVEFrameEncryptor(NewDAVEKeyRatchet(baseSecret))
+	d.pendingEncryptor = false
+}
+
+func (d *daveImpl) exportSenderBaseSecret(userID snowflake.ID) ([]byte, error) {
+	d.sessionMu.Lock()
+	defer d.sessionMu.Unlock()
+	return d.session.ExportSenderBaseSecret(userID)
+}
+
+func (d *daveImpl) recognized() []snowflake.ID {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	userIDs := make([]snowflake.ID, 0, len(d.recognizedUserIDs)+1)
+	userIDs = append(userIDs, d.userID)
+	for userID := range d.recognizedUserIDs {
+		userIDs = append(userIDs, userID)
+	}
+	return userIDs
+}
+
+func (d *daveImpl) send(op Opcode, data GatewayMessageData) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := d.gateway.Send(ctx, op, data); err != nil {
+		d.logger.Error("failed to send dave message", slog.Int("op", int(op)), slog.Any("err", err))
+	}
+}
+
+func (d *daveImpl) sendBinary(op Opcode, data []byte) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := d.gateway.SendBinary(ctx, op, data); err != nil {
+		d.logger.Error("failed to send dave message", slog.Int("op", int(op)), slog.Any("err", err))
+	}
+}
+
+// noDAVE is used when no DAVESessionCreateFunc is configured. Frames are never end-to-end encrypted.
+type noDAVE struct{}
+
+func (noDAVE) ProtocolVersion() int { return 0 }
+
+func (noDAVE) EncryptFrame(frame []byte) ([]byte, error) { return frame, nil }
+
+func (noDAVE) DecryptFrame(_ snowflake.ID, frame []byte) ([]byte, error) { return frame, nil }
@@ -0,0 +1,393 @@
+package voice
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+const (
+	// DAVEProtocolVersion is the highest DAVE protocol version supported.
+	DAVEProtocolVersion = 1
+
+	// DAVEExporterLabel is the MLS exporter label used to derive the base secret of a sender.
+	// The exporter context is the little endian user ID of the sender & the length is 16.
+	DAVEExporterLabel = "Discord Secure Frames v0"
+
+	daveKeySize                = 16
+	daveTagSize                = 8
+	daveNonceSize              = 12
+	daveTruncatedNonceSize     = 4
+	daveTruncatedNonceOffset   = daveNonceSize - daveTruncatedNonceSize
+	daveGenerationShift        = 8 * (daveTruncatedNonceSize - 1)
+	daveMaxGenerationGap       = 250
+	daveMagicMarker            = 0xFAFA
+	daveMagicMarkerSize        = 2
+	daveSupplementalSizeSize   = 1
+	daveMinSupplementalSize    = daveTagSize + 1 + daveSupplementalSizeSize + daveMagicMarkerSize
+	daveRatchetSecretSize      = sha256.Size
+	daveExpandWithLabelVersion = "MLS 1.0 "
+)
+
+var (
+	// ErrDAVEFrameInvalid is returned when an encrypted DAVE frame can't be parsed.
+	ErrDAVEFrameInvalid = errors.New("invalid dave frame")
+	// ErrDAVEKeyExpired is returned when the key of a DAVE frame was already erased from the DAVEKeyRatchet.
+	ErrDAVEKeyExpired = errors.New("dave key expired")
+)
+
+// daveSilenceFrame is passed through unencrypted by DAVE.
+var daveSilenceFrame = SilenceAudioFrame
+
+// DAVEKeyRatchet derives the AES-128-GCM key of each generation from the base secret of a sender.
+// It's the MLS hash ratchet (https://www.rfc-editor.org/rfc/rfc9420.html#section-9.1) of the DAVE cipher suite MLS_128_DHKEMP256_AES128GCM_SHA256_P256.
+type DAVEKeyRatchet struct {
+	secret         []byte
+	nextGeneration uint32
+	keys           map[uint32][]byte
+}
+
+// NewDAVEKeyRatchet returns a new DAVEKeyRatchet for the given base secret.
+func NewDAVEKeyRatchet(baseSecret []byte) *DAVEKeyRatchet {
+	return &DAVEKeyRatchet{
+		secret: bytes.Clone(baseSecret),
+		keys:   map[uint32][]byte{},
+	}
+}
+
+// Key returns the key of the given generation. Keys of older generations can't be derived once they were erased.
+func (r *DAVEKeyRatchet) Key(generation uint32) ([]byte, error) {
+	if key, ok := r.keys[generation]; ok {
+		return key, nil
+	}
+	if generation < r.nextGeneration {
+		return nil, ErrDAVEKeyExpired
+	}
+	for r.nextGeneration <= generation {
+		var context [4]byte
+		binary.BigEndian.PutUint32(context[:], r.nextGeneration)
+
+		key, err := expandWithLabel(r.secret, "key", context[:], daveKeySize)
+		if err != nil {
+			return nil, err
+		}
+		secret, err := expandWithLabel(r.secret, "secret", context[:], daveRatchetSecretSize)
+		if err != nil {
+			return nil, err
+		}
+		r.keys[r.nextGeneration] = key
+		r.secret = secret
+		r.nextGeneration++
+	}
+	return r.keys[generation], nil
+}
+
+// Erase erases the keys of all generations older than the given generation.
+func (r *DAVEKeyRatchet) Erase(generation uint32) {
+	for g := range r.keys {
+		if g < generation {
+			delete(r.keys, g)
+		}
+	}
+}
+
+// expandWithLabel implements ExpandWithLabel of RFC 9420 (https://www.rfc-editor.org/rfc/rfc9420.html#section-8) with HKDF-SHA256.
+func expandWithLabel(secret []byte, label string, context []byte, length int) ([]byte, error) {
+	fullLabel := daveExpandWithLabelVersion + label
+
+	info := make([]byte, 0, 2+len(fullLabel)+len(context)+8)
+	info = binary.BigEndian.AppendUint16(info, uint16(length))
+	info = appendMLSVarint(info, uint64(len(fullLabel)))
+	info = append(info, fullLabel...)
+	info = appendMLSVarint(info, uint64(len(context)))
+	info = append(info, context...)
+
+	out := make([]byte, length)
+	if _, err := io.ReadFull(hkdf.Expand(sha256.New, secret, info), out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// appendMLSVarint appends the variable length integer used as length prefix of vectors in MLS (https://www.rfc-editor.org/rfc/rfc9420.html#section-2.1.2).
+func appendMLSVarint(b []byte, v uint64) []byte {
+	switch {
+	case v < 1<<6:
+		return append(b, byte(v))
+	case v < 1<<14:
+		return binary.BigEndian.AppendUint16(b, uint16(v)|0x4000)
+	default:
+		return binary.BigEndian.AppendUint32(b, uint32(v)|0x80000000)
+	}
+}
+
+// DAVEFrameEncryptor end-to-end encrypts opus frames of the own user.
+type DAVEFrameEncryptor struct {
+	ratchet    *DAVEKeyRatchet
+	generation uint32
+	aead       cipher.AEAD
+	nonce      uint32
+}
+
+// NewDAVEFrameEncryptor returns a new DAVEFrameEncryptor using the given DAVEKeyRatchet.
+func NewDAVEFrameEncryptor(ratchet *DAVEKeyRatchet) *DAVEFrameEncryptor {
+	return &DAVEFrameEncryptor{
+		ratchet: ratchet,
+	}
+}
+
+// Encrypt encrypts the whole opus frame & appends the DAVE supplemental data.
+// The silence frame is passed through unencrypted.
+func (e *DAVEFrameEncryptor) Encrypt(frame []byte) ([]byte, error) {
+	if bytes.Equal(frame, daveSilenceFrame) {
+		return frame, nil
+	}
+
+	truncatedNonce := e.nonce
+	e.nonce++
+	generation := truncatedNonce >> daveGenerationShift
+	if e.aead == nil || generation != e.generation {
+		key, err := e.ratchet.Key(generation)
+		if err != nil {
+			return nil, err
+		}
+		aead, err := newDAVEAEAD(key)
+		if err != nil {
+			return nil, err
+		}
+		e.ratchet.Erase(generation)
+		e.aead = aead
+		e.generation = generation
+	}
+
+	var nonce [daveNonceSize]byte
+	binary.LittleEndian.PutUint32(nonce[daveTruncatedNonceOffset:], truncatedNonce)
+
+	sealed := e.aead.Seal(make([]byte, 0, len(frame)+e.aead.Overhead()+16), nonce[:], frame, nil)
+	// DAVE truncates the GCM tag to 8 bytes
+	out := sealed[:len(frame)+daveTagSize]
+
+	supplementalStart := len(frame)
+	out = appendULEB128(out, uint64(truncatedNonce))
+	supplementalSize := len(out) - supplementalStart + daveSupplementalSizeSize + daveMagicMarkerSize
+	out = append(out, byte(supplementalSize))
+	return binary.BigEndian.AppendUint16(out, daveMagicMarker), nil
+}
+
+// DAVEFrameDecryptor decrypts opus frames of a single sender.
+// During transitions, the DAVEKeyRatchet of the previous epoch can be used until the transition expired.
+type DAVEFrameDecryptor struct {
+	ratchets         []*DAVEKeyRatchet
+	newestGeneration uint32
+}
+
+// NewDAVEFrameDecryptor returns a new DAVEFrameDecryptor using the given DAVEKeyRatchet.
+func NewDAVEFrameDecryptor(ratchet *DAVEKeyRatchet) *DAVEFrameDecryptor {
+	return &DAVEFrameDecryptor{
+		ratchets: []*DAVEKeyRatchet{ratchet},
+	}
+}
+
+// TransitionToKeyRatchet uses the given DAVEKeyRatchet of a new epoch. The previous DAVEKeyRatchet is kept until ExpirePrevious is called.
+func (d *DAVEFrameDecryptor) TransitionToKeyRatchet(ratchet *DAVEKeyRatchet) {
+	d.ratchets = []*DAVEKeyRatchet{ratchet, d.ratchets[0]}
+	d.newestGeneration = 0
+}
+
+// ExpirePrevious erases the DAVEKeyRatchet of the previous epoch.
+func (d *DAVEFrameDecryptor) ExpirePrevious() {
+	d.ratchets = d.ratchets[:1]
+}
+
+// Decrypt decrypts a DAVE frame. The silence frame is passed through.
+func (d *DAVEFrameDecryptor) Decrypt(frame []byte) ([]byte, error) {
+	if bytes.Equal(frame, daveSilenceFrame) {
+		return frame, nil
+	}
+	parsed, err := parseDAVEFrame(frame)
+	if err != nil {
+		return nil, err
+	}
+
+	var nonce [daveNonceSize]byte
+	binary.LittleEndian.PutUint32(nonce[daveTruncatedNonceOffset:], parsed.truncatedNonce)
+	generation := d.wrappedGeneration(parsed.truncatedNonce >> daveGenerationShift)
+
+	for _, ratchet := range d.ratchets {
+		key, err := ratchet.Key(generation)
+		if err != nil {
+			continue
+		}
+		plaintext, ok := openDAVEFrame(key, nonce[:], parsed)
+		if !ok {
+			continue
+		}
+		if generation > d.newestGeneration {
+			d.newestGeneration = generation
+		}
+		return plaintext, nil
+	}
+	return nil, ErrDecryptionFailed
+}
+
+// wrappedGeneration returns the full generation of the 8-bit generation of a truncated nonce.
+func (d *DAVEFrameDecryptor) wrappedGeneration(generation uint32) uint32 {
+	wrapped := d.newestGeneration&^0xFF | generation
+	if wrapped+daveMaxGenerationGap < d.newestGeneration {
+		wrapped += 1 << 8
+	}
+	return wrapped
+}
+
+type daveFrame struct {
+	truncatedNonce uint32
+	tag            []byte
+	// encrypted & unencrypted are the parts of the frame which are encrypted and authenticated only
+	encrypted   []byte
+	unencrypted []byte
+	ranges      []daveRange
+	size        int
+}
+
+type daveRange struct {
+	offset int
+	size   int
+}
+
+// parseDAVEFrame parses the supplemental data at the end of a DAVE frame:
+// [frame][8 byte tag][ULEB128 nonce][ULEB128 unencrypted ranges][1 byte supplemental data size][2 byte magic marker]
+func parseDAVEFrame(frame []byte) (daveFrame, error) {
+	if len(frame) < daveMinSupplementalSize || binary.BigEndian.Uint16(frame[len(frame)-daveMagicMarkerSize:]) != daveMagicMarker {
+		return daveFrame{}, ErrDAVEFrameInvalid
+	}
+	supplementalSize := int(frame[len(frame)-daveMagicMarkerSize-daveSupplementalSizeSize])
+	if supplementalSize < daveMinSupplementalSize || supplementalSize > len(frame) {
+		return daveFrame{}, ErrDAVEFrameInvalid
+	}
+
+	size := len(frame) - supplementalSize
+	supplemental := frame[size : len(frame)-daveMagicMarkerSize-daveSupplementalSizeSize]
+	parsed := daveFrame{
+		tag:  supplemental[:daveTagSize],
+		size: size,
+	}
+	rest := supplemental[daveTagSize:]
+
+	truncatedNonce, n := readULEB128(rest)
+	if n <= 0 || truncatedNonce > 0xFFFFFFFF {
+		return daveFrame{}, ErrDAVEFrameInvalid
+	}
+	parsed.truncatedNonce = uint32(truncatedNonce)
+	rest = rest[n:]
+
+	end := 0
+	for len(rest) > 0 {
+		offset, n := readULEB128(rest)
+		if n <= 0 {
+			return daveFrame{}, ErrDAVEFrameInvalid
+		}
+		rest = rest[n:]
+		rangeSize, n := readULEB128(rest)
+		if n <= 0 {
+			return daveFrame{}, ErrDAVEFrameInvalid
+		}
+		rest = rest[n:]
+		// validate before converting to int as offset+rangeSize can overflow
+		if offset < uint64(end) || offset > uint64(size) || rangeSize > uint64(size)-offset {
+			return daveFrame{}, ErrDAVEFrameInvalid
+		}
+		parsed.ranges = append(parsed.ranges, daveRange{offset: int(offset), size: int(rangeSize)})
+		end = int(offset + rangeSize)
+	}
+
+	position := 0
+	for _, r := range parsed.ranges {
+		parsed.encrypted = append(parsed.encrypted, frame[position:r.offset]...)
+		parsed.unencrypted = append(parsed.unencrypted, frame[r.offset:r.offset+r.size]...)
+		position = r.offset + r.size
+	}
+	parsed.encrypted = append(parsed.encrypted, frame[position:size]...)
+	return parsed, nil
+}
+
+// openDAVEFrame decrypts the frame & verifies its truncated tag.
+// The standard library does not support 8 byte GCM tags, so the plaintext is decrypted with CTR & sealed again to compute the full tag.
+func openDAVEFrame(key []byte, nonce []byte, frame daveFrame) ([]byte, bool) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, false
+	}
+
+	// GCM starts encrypting with counter 2 for 96-bit nonces
+	var counter [aes.BlockSize]byte
+	copy(counter[:], nonce)
+	counter[aes.BlockSize-1] = 2
+	plaintext := make([]byte, len(frame.encrypted))
+	cipher.NewCTR(block, counter[:]).XORKeyStream(plaintext, frame.encrypted)
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, false
+	}
+	sealed := aead.Seal(nil, nonce, plaintext, frame.unencrypted)
+	if subtle.ConstantTimeCompare(sealed[len(plaintext):len(plaintext)+daveTagSize], frame.tag) != 1 {
+		return nil, false
+	}
+
+	if len(frame.ranges) == 0 {
+		return plaintext, true
+	}
+	out := make([]byte, 0, frame.size)
+	position, unencryptedPosition := 0, 0
+	for _, r := range frame.ranges {
+		encryptedSize := r.offset - len(out)
+		out = append(out, plaintext[position:position+encryptedSize]...)
+		out = append(out, frame.unencrypted[unencryptedPosition:unencryptedPosition+r.size]...)
+		position += encryptedSize
+		unencryptedPosition += r.size
+	}
+	return append(out, plaintext[position:]...), true
+}
+
+func newDAVEAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dave cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+func appendULEB128(b []byte, v uint64) []byte {
+	for {
+		c := byte(v & 0x7F)
+		v >>= 7
+		if v == 0 {
+			return append(b, c)
+		}
+		b = append(b, c|0x80)
+	}
+}
+
+// readULEB128 reads an ULEB128 encoded integer & returns the amount of bytes read or 0 if the data is invalid or overflows an uint64.
+func readULEB128(b []byte) (uint64, int) {
+	var v uint64
+	for i := 0; i < len(b) && i < 10; i++ {
+		// only the lowest bit of the 10th byte fits into an uint64
+		if i == 9 && b[i] > 1 {
+			return 0, 0
+		}
+		v |= uint64(b[i]&0x7F) << (7 * i)
+		if b[i]&0x80 == 0 {
+			return v, i + 1
+		}
+	}
+	return 0, 0
+}
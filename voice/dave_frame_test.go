@@ -0,0 +1,296 @@
+package voice
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"encoding/binary"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/hkdf"
+)
+
+func TestULEB128(t *testing.T) {
+	for _, v := range []uint64{0, 1, 127, 128, 300, 1 << 24, 0xFFFFFFFF} {
+		b := appendULEB128(nil, v)
+		decoded, n := readULEB128(b)
+		assert.Equal(t, len(b), n)
+		assert.Equal(t, v, decoded)
+	}
+	assert.Equal(t, []byte{0xAC, 0x02}, appendULEB128(nil, 300))
+
+	_, n := readULEB128([]byte{0x80})
+	assert.Equal(t, 0, n)
+}
+
+func TestExpandWithLabel(t *testing.T) {
+	secret := []byte("0123456789abcdef0123456789abcdef")
+
+	// KDFLabel { uint16 length = 16; opaque label<V> = "MLS 1.0 key"; opaque context<V> = uint32 generation 1 }
+	info := []byte{0x00, 0x10, 0x0B}
+	info = append(info, "MLS 1.0 key"...)
+	info = append(info, 0x04, 0x00, 0x00, 0x00, 0x01)
+	expected := make([]byte, 16)
+	_, err := io.ReadFull(hkdf.Expand(sha256.New, secret, info), expected)
+	assert.NoError(t, err)
+
+	key, err := expandWithLabel(secret, "key", []byte{0x00, 0x00, 0x00, 0x01}, 16)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, key)
+
+	assert.Equal(t, []byte{0x40, 0x40}, appendMLSVarint(nil, 64))
+}
+
+func TestDAVEKeyRatchet(t *testing.T) {
+	baseSecret := make([]byte, 16)
+	ratchet := NewDAVEKeyRatchet(baseSecret)
+
+	key2, err := ratchet.Key(2)
+	assert.NoError(t, err)
+	key0, err := ratchet.Key(0)
+	assert.NoError(t, err)
+	assert.Len(t, key0, daveKeySize)
+	assert.NotEqual(t, key0, key2)
+
+	// keys are deterministic
+	other, err := NewDAVEKeyRatchet(baseSecret).Key(2)
+	assert.NoError(t, err)
+	assert.Equal(t, key2, other)
+
+	ratchet.Erase(2)
+	_, err = ratchet.Key(1)
+	assert.ErrorIs(t, err, ErrDAVEKeyExpired)
+}
+
+func TestDAVEFrame(t *testing.T) {
+	baseSecret := []byte("base secret 1234")
+	encryptor := NewDAVEFrameEncryptor(NewDAVEKeyRatchet(baseSecret))
+	decryptor := NewDAVEFrameDecryptor(NewDAVEKeyRatchet(baseSecret))
+
+	opus := []byte{0x78, 0x01, 0x02, 0x03, 0x04}
+	frame, err := encryptor.Encrypt(opus)
+	assert.NoError(t, err)
+	// [5 byte ciphertext][8 byte tag][1 byte nonce][1 byte supplemental size][2 byte magic marker]
+	assert.Len(t, frame, len(opus)+daveTagSize+1+1+2)
+	assert.Equal(t, byte(daveTagSize+1+1+2), frame[len(frame)-3])
+	assert.Equal(t, []byte{0xFA, 0xFA}, frame[len(frame)-2:])
+
+	// the truncated tag is the beginning of the standard GCM tag
+	key, err := NewDAVEKeyRatchet(baseSecret).Key(0)
+	assert.NoError(t, err)
+	block, _ := aes.NewCipher(key)
+	aead, _ := cipher.NewGCM(block)
+	sealed := aead.Seal(nil, make([]byte, daveNonceSize), opus, nil)
+	assert.Equal(t, sealed[:len(opus)+daveTagSize], frame[:len(opus)+daveTagSize])
+
+	decrypted, err := decryptor.Decrypt(frame)
+	assert.NoError(t, err)
+	assert.Equal(t, opus, decrypted)
+
+	frame[0] ^= 0xFF
+	_, err = decryptor.Decrypt(frame)
+	assert.ErrorIs(t, err, ErrDecryptionFailed)
+
+	_, err = decryptor.Decrypt(opus)
+	assert.ErrorIs(t, err, ErrDAVEFrameInvalid)
+
+	// silence frames are passed through
+	silence, err := encryptor.Encrypt(SilenceAudioFrame)
+	assert.NoError(t, err)
+	assert.Equal(t, SilenceAudioFrame, silence)
+	silence, err = decryptor.Decrypt(SilenceAudioFrame)
+	assert.NoError(t, err)
+	assert.Equal(t, SilenceAudioFrame, silence)
+}
+
+func TestDAVEFrame_Generations(t *testing.T) {
+	baseSecret := []byte("base secret 1234")
+	encryptor := NewDAVEFrameEncryptor(NewDAVEKeyRatchet(baseSecret))
+	decryptor := NewDAVEFrameDecryptor(NewDAVEKeyRatchet(baseSecret))
+
+	// the generation is the most significant byte of the truncated nonce
+	for _, nonce := range []uint32{0, 1<<24 - 1, 1 << 24, 3<<24 + 5} {
+		encryptor.nonce = nonce
+		frame, err := encryptor.Encrypt([]byte{0x01, 0x02})
+		assert.NoError(t, err)
+		decrypted, err := decryptor.Decrypt(frame)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x01, 0x02}, decrypted)
+	}
+	assert.Equal(t, uint32(3), decryptor.newestGeneration)
+
+	// generations wrap after 8 bits
+	decryptor.newestGeneration = 0x1FF
+	assert.Equal(t, uint32(0x201), decryptor.wrappedGeneration(0x01))
+	assert.Equal(t, uint32(0x1FE), decryptor.wrappedGeneration(0xFE))
+}
+
+func TestDAVEFrame_UnencryptedRanges(t *testing.T) {
+	key := make([]byte, daveKeySize)
+	block, _ := aes.NewCipher(key)
+	aead, _ := cipher.NewGCM(block)
+
+	// frame "AABBBCC" with the range [2, 5) unencrypted
+	plaintext := []byte("AABBBCC")
+	var nonce [daveNonceSize]byte
+	binary.LittleEndian.PutUint32(nonce[daveTruncatedNonceOffset:], 7)
+	sealed := aead.Seal(nil, nonce[:], []byte("AACC"), []byte("BBB"))
+
+	frame := append([]byte{}, sealed[0:2]...)
+	frame = append(frame, "BBB"...)
+	frame = append(frame, sealed[2:4]...)
+	frame = append(frame, sealed[4:4+daveTagSize]...)
+	frame = append(frame, 7, 2, 3)
+	frame = append(frame, byte(daveTagSize+3+1+2), 0xFA, 0xFA)
+
+	parsed, err := parseDAVEFrame(frame)
+	assert.NoError(t, err)
+	decrypted, ok := openDAVEFrame(key, nonce[:], parsed)
+	assert.True(t, ok)
+	assert.Equal(t, plaintext, decrypted)
+}
+
+// TestULEB128_KnownAnswers uses the examples of the DWARF 5 standard (section 7.6) & 624485 from the LEB128 article.
+func TestULEB128_KnownAnswers(t *testing.T) {
+	data := []struct {
+		value   uint64
+		encoded []byte
+	}{
+		{2, []byte{0x02}},
+		{127, []byte{0x7F}},
+		{128, []byte{0x80, 0x01}},
+		{129, []byte{0x81, 0x01}},
+		{130, []byte{0x82, 0x01}},
+		{12857, []byte{0xB9, 0x64}},
+		{624485, []byte{0xE5, 0x8E, 0x26}},
+		{1<<64 - 1, []byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0x01}},
+	}
+	for _, d := range data {
+		assert.Equal(t, d.encoded, appendULEB128(nil, d.value), "value %d", d.value)
+		value, n := readULEB128(d.encoded)
+		assert.Equal(t, len(d.encoded), n, "value %d", d.value)
+		assert.Equal(t, d.value, value)
+	}
+}
+
+func TestULEB128_Invalid(t *testing.T) {
+	for _, b := range [][]byte{
+		nil,
+		{0x80},
+		{0xFF, 0xFF},
+		// overflows an uint64
+		{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0x02},
+		{0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x01},
+	} {
+		_, n := readULEB128(b)
+		assert.Equal(t, 0, n, "data %x", b)
+	}
+}
+
+// TestDAVEFrame_KnownAnswer builds a frame from test case 2 of "The Galois/Counter Mode of Operation" (McGrew & Viega):
+// K = 0^128, IV = 0^96, P = 0^128 which matches a DAVE frame with an all zero key & truncated nonce 0.
+func TestDAVEFrame_KnownAnswer(t *testing.T) {
+	var (
+		key        = make([]byte, daveKeySize)
+		nonce      = make([]byte, daveNonceSize)
+		ciphertext = []byte{0x03, 0x88, 0xda, 0xce, 0x60, 0xb6, 0xa3, 0x92, 0xf3, 0x28, 0xc2, 0xb9, 0x71, 0xb2, 0xfe, 0x78}
+		fullTag    = []byte{0xab, 0x6e, 0x47, 0xd4, 0x2c, 0xec, 0x13, 0xbd, 0xf5, 0x3a, 0x67, 0xb2, 0x12, 0x57, 0xbd, 0xdf}
+	)
+
+	// [ciphertext][8 byte tag][ULEB128 nonce 0][supplemental size 12][0xFAFA]
+	frame := append([]byte{}, ciphertext...)
+	frame = append(frame, fullTag[:daveTagSize]...)
+	frame = append(frame, 0x00, 0x0C, 0xFA, 0xFA)
+
+	parsed, err := parseDAVEFrame(frame)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, uint32(0), parsed.truncatedNonce)
+	assert.Equal(t, len(ciphertext), parsed.size)
+	assert.Empty(t, parsed.ranges)
+
+	decrypted, ok := openDAVEFrame(key, nonce, parsed)
+	assert.True(t, ok)
+	assert.Equal(t, make([]byte, 16), decrypted)
+
+	// a modified truncated tag must be rejected
+	frame[len(ciphertext)] ^= 0x01
+	parsed, err = parseDAVEFrame(frame)
+	if assert.NoError(t, err) {
+		_, ok = openDAVEFrame(key, nonce, parsed)
+		assert.False(t, ok)
+	}
+}
+
+func TestParseDAVEFrame_Invalid(t *testing.T) {
+	tag := make([]byte, daveTagSize)
+	supplemental := func(nonceAndRanges ...byte) []byte {
+		b := append(append([]byte{}, tag...), nonceAndRanges...)
+		return append(b, byte(len(b)+3), 0xFA, 0xFA)
+	}
+	frame := func(payload []byte, nonceAndRanges ...byte) []byte {
+		return append(append([]byte{}, payload...), supplemental(nonceAndRanges...)...)
+	}
+	payload := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+
+	for name, data := range map[string][]byte{
+		"empty":                  nil,
+		"no magic marker":        append(append([]byte{}, payload...), 0x00, 0x00, 0x00, 0x00),
+		"supplemental too small": append(append([]byte{}, payload...), 0x02, 0xFA, 0xFA),
+		"supplemental too large": append(append([]byte{}, supplemental(0x00)...)[:daveTagSize+1], 0xFF, 0xFA, 0xFA),
+		"truncated nonce":        frame(payload, 0x80),
+		"nonce overflows uint32": frame(payload, 0xFF, 0xFF, 0xFF, 0xFF, 0x7F),
+		"truncated range":        frame(payload, 0x00, 0x02),
+		"range out of bounds":    frame(payload, 0x00, 0x02, 0x07),
+		"offset out of bounds":   frame(payload, 0x00, 0x09, 0x00),
+		"overlapping ranges":     frame(payload, 0x00, 0x02, 0x02, 0x03, 0x01),
+		// offset 5 & size 2^64-3 wrap around to 2 when added
+		"range size overflow": frame(payload, 0x00, 0x05, 0xFD, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0x01),
+		"offset overflow":     frame(payload, 0x00, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0x01, 0x02),
+	} {
+		_, err := parseDAVEFrame(data)
+		assert.ErrorIs(t, err, ErrDAVEFrameInvalid, name)
+	}
+
+	parsed, err := parseDAVEFrame(frame(payload, 0x00, 0x02, 0x03, 0x06, 0x02))
+	if assert.NoError(t, err) {
+		assert.Equal(t, []byte{3, 4, 5, 7, 8}, parsed.unencrypted)
+		assert.Equal(t, []byte{1, 2, 6}, parsed.encrypted)
+	}
+}
+
+func FuzzReadULEB128(f *testing.F) {
+	f.Add([]byte{0xE5, 0x8E, 0x26})
+	f.Add([]byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0x01})
+	f.Add([]byte{0x80, 0x80})
+	f.Fuzz(func(t *testing.T, b []byte) {
+		v, n := readULEB128(b)
+		if n == 0 {
+			return
+		}
+		if n > len(b) || n > 10 {
+			t.Fatalf("read %d bytes of %d", n, len(b))
+		}
+		if decoded, m := readULEB128(appendULEB128(nil, v)); decoded != v || m == 0 {
+			t.Fatalf("round trip of %d failed", v)
+		}
+	})
+}
+
+func FuzzParseDAVEFrame(f *testing.F) {
+	f.Add([]byte{1, 2, 3, 0, 0, 0, 0, 0, 0, 0, 0, 0x00, 0x01, 0x01, 0x0E, 0xFA, 0xFA})
+	f.Add([]byte{1, 2, 3, 4, 5, 6, 7, 8, 0, 0, 0, 0, 0, 0, 0, 0, 0x00, 0x05, 0xFD, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0x01, 0x16, 0xFA, 0xFA})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		parsed, err := parseDAVEFrame(data)
+		if err != nil {
+			return
+		}
+		if len(parsed.encrypted)+len(parsed.unencrypted) != parsed.size || parsed.size > len(data) {
+			t.Fatalf("invalid frame sizes: %d + %d != %d", len(parsed.encrypted), len(parsed.unencrypted), parsed.size)
+		}
+		_, _ = openDAVEFrame(make([]byte, daveKeySize), make([]byte, daveNonceSize), parsed)
+	})
+}
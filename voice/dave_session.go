@@ -0,0 +1,453 @@
+package voice
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+
+	"github.com/disgoorg/snowflake/v2"
+)
+
+var (
+	// ErrDAVEUnrecognizedUser is returned when a proposal or welcome contains a user which is not connected to the voice channel.
+	ErrDAVEUnrecognizedUser = errors.New("unrecognized dave user")
+	// ErrDAVERemoved is returned when the own user was removed from the MLS group by a commit.
+	ErrDAVERemoved = errors.New("removed from dave group")
+
+	errMLSInvalidCommit  = errors.New("invalid mls commit")
+	errMLSInvalidWelcome = errors.New("invalid mls welcome")
+)
+
+// NewDAVESession returns a new DAVESession which manages the MLS group of the DAVE protocol with the MLS_128_DHKEMP256_AES128GCM_SHA256_P256 cipher suite.
+// Proposals & commits are sent as public messages & welcomes contain the ratchet tree as extension.
+func NewDAVESession() DAVESession {
+	return &daveSession{}
+}
+
+// daveProposal is a proposal of the voice gateway which is committed with the next commit.
+type daveProposal struct {
+	ref      []byte
+	proposal *mlsProposal
+}
+
+type daveSession struct {
+	mu sync.Mutex
+
+	userID       snowflake.ID
+	groupID      []byte
+	signatureKey *ecdsa.PrivateKey
+	initKey      *ecdh.PrivateKey
+	leafKey      *ecdh.PrivateKey
+	keyPackage   *mlsKeyPackage
+
+	externalSender *mlsExternalSender
+	// externalSenders is the external_senders extension of the group
+	externalSenders []byte
+
+	// pendingGroup only contains the own user & is used to commit proposals until a group was joined.
+	pendingGroup *mlsGroup
+	group        *mlsGroup
+	proposals    []daveProposal
+	// commit is the last commit sent to the voice gateway & commitGroup the group state after it.
+	commit      []byte
+	commitGroup *mlsGroup
+}
+
+func (s *daveSession) Init(protocolVersion int, groupID snowflake.ID, userID snowflake.ID) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if protocolVersion < 1 || protocolVersion > DAVEProtocolVersion {
+		return nil, fmt.Errorf("unsupported dave protocol version: %d", protocolVersion)
+	}
+
+	signatureKey, err := newMLSSignatureKey()
+	if err != nil {
+		return nil, err
+	}
+	initKey, err := newHPKEKey()
+	if err != nil {
+		return nil, err
+	}
+	leafKey, err := newHPKEKey()
+	if err != nil {
+		return nil, err
+	}
+
+	leaf := &mlsLeafNode{
+		encryptionKey: leafKey.PublicKey().Bytes(),
+		signatureKey:  mlsSignaturePublicKey(signatureKey),
+		identity:      binary.BigEndian.AppendUint64(nil, uint64(userID)),
+		capabilities: mlsCapabilities{
+			versions:     []uint16{mlsVersion},
+			cipherSuites: []uint16{mlsCipherSuite},
+			credentials:  []uint16{mlsCredentialTypeBasic},
+		},
+		source:   mlsLeafNodeSourceKeyPackage,
+		notAfter: math.MaxUint64,
+	}
+	if err = leaf.sign(signatureKey, nil, 0); err != nil {
+		return nil, err
+	}
+	keyPackage := &mlsKeyPackage{
+		initKey:  initKey.PublicKey().Bytes(),
+		leafNode: leaf,
+	}
+	if err = keyPackage.sign(signatureKey); err != nil {
+		return nil, err
+	}
+
+	s.userID = userID
+	s.groupID = binary.BigEndian.AppendUint64(nil, uint64(groupID))
+	s.signatureKey = signatureKey
+	s.initKey = initKey
+	s.leafKey = leafKey
+	s.keyPackage = keyPackage
+	s.group = nil
+	s.resetProposals()
+	if err = s.createPendingGroup(); err != nil {
+		return nil, err
+	}
+	return keyPackage.bytes(), nil
+}
+
+func (s *daveSession) SetExternalSender(externalSender []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sender, err := parseMLSExternalSender(externalSender)
+	if err != nil {
+		return err
+	}
+	w := &mlsWriter{}
+	w.vector(func(w *mlsWriter) {
+		sender.write(w)
+	})
+	s.externalSender = sender
+	s.externalSenders = w.b
+	return s.createPendingGroup()
+}
+
+// createPendingGroup creates the group of the own user once the session was initialized & the external sender is known.
+func (s *daveSession) createPendingGroup() error {
+	s.pendingGroup = nil
+	if s.keyPackage == nil || s.externalSender == nil || s.group != nil {
+		return nil
+	}
+	group, err := newMLSGroup(s.groupID, s.signatureKey, s.keyPackage.leafNode, s.leafKey, []mlsExtension{
+		{extensionType: mlsExtensionTypeExternalSenders, data: s.externalSenders},
+	})
+	if err != nil {
+		return err
+	}
+	s.pendingGroup = group
+	return nil
+}
+
+// currentGroup returns the joined group or the pending group if no group was joined yet.
+func (s *daveSession) currentGroup() *mlsGroup {
+	if s.group != nil {
+		return s.group
+	}
+	return s.pendingGroup
+}
+
+func (s *daveSession) resetProposals() {
+	s.proposals = nil
+	s.commit = nil
+	s.commitGroup = nil
+}
+
+func (s *daveSession) ProcessProposals(operation DAVEProposalsOperation, proposals []byte, recognizedUserIDs []snowflake.ID) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	group := s.currentGroup()
+	if group == nil {
+		return nil, ErrDAVENotReady
+	}
+
+	r := newMLSReader(proposals)
+	switch operation {
+	case DAVEProposalsOperationAppend:
+		var messages []*mlsPublicMessage
+		r.vector(func(r *mlsReader) {
+			messages = append(messages, readMLSPublicMessage(r))
+		})
+		if err := r.end(); err != nil {
+			return nil, err
+		}
+		for _, message := range messages {
+			if err := s.verifyProposal(group, message, recognizedUserIDs); err != nil {
+				return nil, err
+			}
+		}
+		for _, message := range messages {
+			s.proposals = append(s.proposals, daveProposal{
+				ref:      mlsRefHash("MLS 1.0 Proposal Reference", message.authenticatedContent()),
+				proposal: message.content.proposal,
+			})
+		}
+
+	case DAVEProposalsOperationRevoke:
+		var refs [][]byte
+		r.vector(func(r *mlsReader) {
+			refs = append(refs, r.opaque())
+		})
+		if err := r.end(); err != nil {
+			return nil, err
+		}
+		s.proposals = slices.DeleteFunc(s.proposals, func(proposal daveProposal) bool {
+			return slices.ContainsFunc(refs, func(ref []byte) bool { return bytes.Equal(ref, proposal.ref) })
+		})
+
+	default:
+		return nil, fmt.Errorf("unknown dave proposals operation: %d", operation)
+	}
+
+	if len(s.proposals) == 0 {
+		s.commit = nil
+		s.commitGroup = nil
+		return nil, nil
+	}
+	commit, welcome, next, err := group.createCommit(s.proposals)
+	if errors.Is(err, ErrDAVERemoved) {
+		// the own user can't commit its removal & waits for the commit of another member
+		s.commit = nil
+		s.commitGroup = nil
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.commit = commit
+	s.commitGroup = next
+	return append(commit, welcome...), nil
+}
+
+// verifyProposal verifies that the proposal was sent by the voice gateway for the current epoch & only adds recognized users.
+func (s *daveSession) verifyProposal(group *mlsGroup, message *mlsPublicMessage, recognizedUserIDs []snowflake.ID) error {
+	content := message.content
+	if content.contentType != mlsContentTypeProposal || content.senderType != mlsSenderTypeExternal || content.senderIndex != 0 {
+		return fmt.Errorf("%w: proposal not sent by the external sender", ErrMLSMessageInvalid)
+	}
+	if !bytes.Equal(content.groupID, group.groupID) || content.epoch != group.epoch {
+		return fmt.Errorf("%w: proposal for group epoch %d, expected %d", ErrMLSMessageInvalid, content.epoch, group.epoch)
+	}
+	if err := mlsVerifyWithLabel(s.externalSender.signatureKey, "FramedContentTBS", content.tbs(nil), message.signature); err != nil {
+		return err
+	}
+
+	proposal := content.proposal
+	switch proposal.proposalType {
+	case mlsProposalTypeAdd:
+		if err := proposal.keyPackage.verify(); err != nil {
+			return err
+		}
+		if !daveRecognized(proposal.keyPackage.leafNode.identity, recognizedUserIDs) {
+			return ErrDAVEUnrecognizedUser
+		}
+	case mlsProposalTypeRemove:
+		if group.tree.leaf(proposal.removed) == nil {
+			return fmt.Errorf("%w: remove of unknown leaf %d", ErrMLSMessageInvalid, proposal.removed)
+		}
+	}
+	return nil
+}
+
+func (s *daveSession) ProcessCommit(commit []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.commit != nil && bytes.Equal(commit, s.commit) {
+		s.group = s.commitGroup
+		s.pendingGroup = nil
+		s.resetProposals()
+		return nil
+	}
+	if s.group == nil {
+		// the commit of another member won & the own user is added by a welcome
+		return ErrDAVENotReady
+	}
+
+	message, err := parseMLSPublicMessage(commit)
+	if err != nil {
+		return err
+	}
+	next, err := s.group.processCommit(message, s.proposals)
+	if errors.Is(err, ErrDAVERemoved) {
+		s.group = nil
+		s.resetProposals()
+		_ = s.createPendingGroup()
+	}
+	if err != nil {
+		return err
+	}
+	s.group = next
+	s.resetProposals()
+	return nil
+}
+
+func (s *daveSession) ProcessWelcome(welcome []byte, recognizedUserIDs []snowflake.ID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.keyPackage == nil {
+		return ErrDAVENotReady
+	}
+
+	r := newMLSReader(welcome)
+	message := readMLSWelcome(r)
+	if err := r.end(); err != nil {
+		return err
+	}
+	group, err := s.joinGroup(message, recognizedUserIDs)
+	if err != nil {
+		return err
+	}
+	s.group = group
+	s.pendingGroup = nil
+	s.resetProposals()
+	return nil
+}
+
+// joinGroup joins the group of the welcome (https://www.rfc-editor.org/rfc/rfc9420.html#section-12.4.3.1).
+func (s *daveSession) joinGroup(welcome *mlsWelcome, recognizedUserIDs []snowflake.ID) (*mlsGroup, error) {
+	ref := s.keyPackage.ref()
+	i := slices.IndexFunc(welcome.secrets, func(secrets mlsEncryptedGroupSecrets) bool {
+		return bytes.Equal(secrets.newMember, ref)
+	})
+	if i == -1 {
+		return nil, fmt.Errorf("%w: welcome is not for the own key package", errMLSInvalidWelcome)
+	}
+	encrypted := welcome.secrets[i].secrets
+	plaintext, err := mlsDecryptWithLabel(s.initKey, "Welcome", welcome.encryptedGroupInfo, encrypted.kemOutput, encrypted.ciphertext)
+	if err != nil {
+		return nil, err
+	}
+	groupSecrets, err := parseMLSGroupSecrets(plaintext)
+	if err != nil {
+		return nil, err
+	}
+
+	memberSecret := mlsExtract(groupSecrets.joinerSecret, make([]byte, mlsHashSize))
+	groupInfoBytes, err := openMLSWelcome(mlsDeriveSecret(memberSecret, "welcome"), welcome.encryptedGroupInfo)
+	if err != nil {
+		return nil, err
+	}
+	groupInfo, err := parseMLSGroupInfo(groupInfoBytes)
+	if err != nil {
+		return nil, err
+	}
+	groupContext := groupInfo.groupContext
+	if !bytes.Equal(groupContext.groupID, s.groupID) {
+		return nil, fmt.Errorf("%w: unexpected group id", errMLSInvalidWelcome)
+	}
+	if s.externalSenders != nil && !bytes.Equal(findMLSExtension(groupContext.extensions, mlsExtensionTypeExternalSenders), s.externalSenders) {
+		return nil, fmt.Errorf("%w: unexpected external sender", errMLSInvalidWelcome)
+	}
+
+	ratchetTree := findMLSExtension(groupInfo.extensions, mlsExtensionTypeRatchetTree)
+	if ratchetTree == nil {
+		return nil, fmt.Errorf("%w: missing ratchet tree", errMLSInvalidWelcome)
+	}
+	tree, err := parseMLSTree(ratchetTree)
+	if err != nil {
+		return nil, err
+	}
+	signer := tree.leaf(groupInfo.signer)
+	if signer == nil {
+		return nil, fmt.Errorf("%w: unknown signer", errMLSInvalidWelcome)
+	}
+	if err = mlsVerifyWithLabel(signer.signatureKey, "GroupInfoTBS", groupInfo.tbs(), groupInfo.signature); err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(tree.rootTreeHash(), groupContext.treeHash) {
+		return nil, fmt.Errorf("%w: tree hash mismatch", errMLSInvalidWelcome)
+	}
+	if err = tree.verifyLeaves(groupContext.groupID); err != nil {
+		return nil, err
+	}
+	if err = tree.verifyParentHashes(); err != nil {
+		return nil, err
+	}
+
+	ownLeaf := uint32(math.MaxUint32)
+	for leafIndex := uint32(0); leafIndex < tree.leafCount(); leafIndex++ {
+		leaf := tree.leaf(leafIndex)
+		if leaf == nil {
+			continue
+		}
+		if !daveRecognized(leaf.identity, recognizedUserIDs) {
+			return nil, ErrDAVEUnrecognizedUser
+		}
+		if bytes.Equal(leaf.encryptionKey, s.keyPackage.leafNode.encryptionKey) && bytes.Equal(leaf.signatureKey, s.keyPackage.leafNode.signatureKey) {
+			ownLeaf = leafIndex
+		}
+	}
+	if ownLeaf == math.MaxUint32 {
+		return nil, fmt.Errorf("%w: own leaf not found", errMLSInvalidWelcome)
+	}
+
+	privateKeys := map[uint32]*ecdh.PrivateKey{2 * ownLeaf: s.leafKey}
+	if groupSecrets.pathSecret != nil {
+		path := tree.filteredDirectPath(2 * groupInfo.signer)
+		i := slices.IndexFunc(path, func(node mlsPathNode) bool { return mlsInSubtree(node.node, 2*ownLeaf) })
+		if i == -1 {
+			return nil, fmt.Errorf("%w: unexpected path secret", errMLSInvalidWelcome)
+		}
+		keys := make([][]byte, len(path)-i)
+		for j := range keys {
+			keys[j] = tree.nodes[path[i+j].node].encryptionKey()
+		}
+		if _, err = derivePathKeys(groupSecrets.pathSecret, path[i:], keys, privateKeys); err != nil {
+			return nil, err
+		}
+	}
+
+	secrets := newMLSEpochSecrets(mlsExpandWithLabel(memberSecret, "epoch", groupContext.bytes()))
+	if !hmac.Equal(mlsMAC(secrets.confirmation, groupContext.confirmedTranscriptHash), groupInfo.confirmationTag) {
+		return nil, fmt.Errorf("%w: invalid confirmation tag", errMLSInvalidWelcome)
+	}
+	return &mlsGroup{
+		groupID:                 groupContext.groupID,
+		epoch:                   groupContext.epoch,
+		tree:                    tree,
+		treeHash:                groupContext.treeHash,
+		ownLeaf:                 ownLeaf,
+		extensions:              groupContext.extensions,
+		confirmedTranscriptHash: groupContext.confirmedTranscriptHash,
+		interimTranscriptHash:   mlsInterimTranscriptHash(groupContext.confirmedTranscriptHash, groupInfo.confirmationTag),
+		secrets:                 secrets,
+		privateKeys:             privateKeys,
+		signatureKey:            s.signatureKey,
+	}, nil
+}
+
+func (s *daveSession) ExportSenderBaseSecret(userID snowflake.ID) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.group == nil {
+		return nil, ErrDAVENotReady
+	}
+	return s.group.export(DAVEExporterLabel, binary.LittleEndian.AppendUint64(nil, uint64(userID)), 16), nil
+}
+
+// daveRecognized returns whether the basic credential identity is the big endian ID of one of the users.
+func daveRecognized(identity []byte, userIDs []snowflake.ID) bool {
+	if len(identity) != 8 {
+		return false
+	}
+	return slices.Contains(userIDs, snowflake.ID(binary.BigEndian.Uint64(identity)))
+}
+
+func newHPKEKey() (*ecdh.PrivateKey, error) {
+	ikm := make([]byte, mlsHashSize)
+	if _, err := rand.Read(ikm); err != nil {
+		return nil, err
+	}
+	return hpkeDeriveKeyPair(ikm)
+}
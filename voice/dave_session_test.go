@@ -0,0 +1,372 @@
+package voice
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/binary"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+const testDAVEChannelID = snowflake.ID(1234)
+
+// testDAVEGateway simulates the external sender of the voice gateway which proposes to add & remove members.
+type testDAVEGateway struct {
+	t   *testing.T
+	key *ecdsa.PrivateKey
+}
+
+func newTestDAVEGateway(t *testing.T) *testDAVEGateway {
+	key, err := newMLSSignatureKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testDAVEGateway{t: t, key: key}
+}
+
+func (g *testDAVEGateway) externalSender() []byte {
+	w := &mlsWriter{}
+	(&mlsExternalSender{signatureKey: mlsSignaturePublicKey(g.key), identity: []byte("voice gateway")}).write(w)
+	return w.b
+}
+
+func (g *testDAVEGateway) session(userID snowflake.ID) (DAVESession, []byte) {
+	g.t.Helper()
+	session := NewDAVESession()
+	keyPackage, err := session.Init(DAVEProtocolVersion, testDAVEChannelID, userID)
+	if err != nil {
+		g.t.Fatal(err)
+	}
+	if err = session.SetExternalSender(g.externalSender()); err != nil {
+		g.t.Fatal(err)
+	}
+	return session, keyPackage
+}
+
+// propose returns the signed proposal of the epoch.
+func (g *testDAVEGateway) propose(epoch uint64, proposal *mlsProposal) []byte {
+	g.t.Helper()
+	message := &mlsPublicMessage{
+		content: &mlsFramedContent{
+			groupID:     binary.BigEndian.AppendUint64(nil, uint64(testDAVEChannelID)),
+			epoch:       epoch,
+			senderType:  mlsSenderTypeExternal,
+			contentType: mlsContentTypeProposal,
+			proposal:    proposal,
+		},
+	}
+	signature, err := mlsSignWithLabel(g.key, "FramedContentTBS", message.content.tbs(nil))
+	if err != nil {
+		g.t.Fatal(err)
+	}
+	message.signature = signature
+	return message.bytes()
+}
+
+func (g *testDAVEGateway) add(epoch uint64, keyPackage []byte) []byte {
+	g.t.Helper()
+	r := newMLSReader(keyPackage)
+	p := readMLSKeyPackage(r)
+	if err := r.end(); err != nil {
+		g.t.Fatal(err)
+	}
+	return g.propose(epoch, &mlsProposal{proposalType: mlsProposalTypeAdd, keyPackage: p})
+}
+
+func (g *testDAVEGateway) remove(epoch uint64, leafIndex uint32) []byte {
+	return g.propose(epoch, &mlsProposal{proposalType: mlsProposalTypeRemove, removed: leafIndex})
+}
+
+func testDAVEProposals(proposals ...[]byte) []byte {
+	w := &mlsWriter{}
+	w.vector(func(w *mlsWriter) {
+		for _, proposal := range proposals {
+			w.raw(proposal)
+		}
+	})
+	return w.b
+}
+
+func testDAVEProposalRef(proposal []byte) []byte {
+	r := newMLSReader(proposal)
+	m := readMLSPublicMessage(r)
+	return mlsRefHash("MLS 1.0 Proposal Reference", m.authenticatedContent())
+}
+
+// splitTestDAVECommitWelcome splits the commit & the optional welcome of ProcessProposals.
+func splitTestDAVECommitWelcome(t *testing.T, commitWelcome []byte) ([]byte, []byte) {
+	t.Helper()
+	r := newMLSReader(commitWelcome)
+	readMLSPublicMessage(r)
+	if r.err != nil {
+		t.Fatal(r.err)
+	}
+	return commitWelcome[:len(commitWelcome)-len(r.b)], r.b
+}
+
+func assertDAVESecretsEqual(t *testing.T, userIDs []snowflake.ID, sessions ...DAVESession) {
+	t.Helper()
+	for _, userID := range userIDs {
+		expected, err := sessions[0].ExportSenderBaseSecret(userID)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Len(t, expected, 16)
+		for _, session := range sessions[1:] {
+			secret, err := session.ExportSenderBaseSecret(userID)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, secret)
+		}
+	}
+}
+
+func TestDAVESession(t *testing.T) {
+	gateway := newTestDAVEGateway(t)
+	users := []snowflake.ID{1, 2, 3}
+	a, _ := gateway.session(users[0])
+	b, keyPackageB := gateway.session(users[1])
+	c, keyPackageC := gateway.session(users[2])
+
+	_, err := b.ExportSenderBaseSecret(users[0])
+	assert.ErrorIs(t, err, ErrDAVENotReady)
+
+	// a creates the group & adds b
+	commitWelcome, err := a.ProcessProposals(DAVEProposalsOperationAppend, testDAVEProposals(gateway.add(0, keyPackageB)), users)
+	if !assert.NoError(t, err) {
+		return
+	}
+	commit, welcome := splitTestDAVECommitWelcome(t, commitWelcome)
+	assert.NotEmpty(t, welcome)
+	assert.NoError(t, a.ProcessCommit(commit))
+	assert.ErrorIs(t, b.ProcessCommit(commit), ErrDAVENotReady)
+	if !assert.NoError(t, b.ProcessWelcome(welcome, users)) {
+		return
+	}
+	assertDAVESecretsEqual(t, users[:2], a, b)
+
+	// a & b commit the add of c, the gateway announces the commit of b
+	addC := testDAVEProposals(gateway.add(1, keyPackageC))
+	_, err = a.ProcessProposals(DAVEProposalsOperationAppend, addC, users)
+	assert.NoError(t, err)
+	commitWelcome, err = b.ProcessProposals(DAVEProposalsOperationAppend, addC, users)
+	if !assert.NoError(t, err) {
+		return
+	}
+	commit, welcome = splitTestDAVECommitWelcome(t, commitWelcome)
+	assert.NoError(t, b.ProcessCommit(commit))
+	if !assert.NoError(t, a.ProcessCommit(commit)) {
+		return
+	}
+	if !assert.NoError(t, c.ProcessWelcome(welcome, users)) {
+		return
+	}
+	assertDAVESecretsEqual(t, users, a, b, c)
+
+	// c commits the removal of a, a is removed
+	removeA := testDAVEProposals(gateway.remove(2, 0))
+	_, err = b.ProcessProposals(DAVEProposalsOperationAppend, removeA, users)
+	assert.NoError(t, err)
+	_, err = a.ProcessProposals(DAVEProposalsOperationAppend, removeA, users)
+	assert.NoError(t, err)
+	commitWelcome, err = c.ProcessProposals(DAVEProposalsOperationAppend, removeA, users)
+	if !assert.NoError(t, err) {
+		return
+	}
+	commit, welcome = splitTestDAVECommitWelcome(t, commitWelcome)
+	assert.Empty(t, welcome)
+	assert.NoError(t, c.ProcessCommit(commit))
+	assert.NoError(t, b.ProcessCommit(commit))
+	assert.ErrorIs(t, a.ProcessCommit(commit), ErrDAVERemoved)
+	_, err = a.ExportSenderBaseSecret(users[0])
+	assert.ErrorIs(t, err, ErrDAVENotReady)
+	assertDAVESecretsEqual(t, users[1:], b, c)
+
+	secretB, _ := b.ExportSenderBaseSecret(users[1])
+	secretC, _ := b.ExportSenderBaseSecret(users[2])
+	assert.NotEqual(t, secretB, secretC)
+}
+
+func TestDAVESession_Revoke(t *testing.T) {
+	gateway := newTestDAVEGateway(t)
+	users := []snowflake.ID{1, 2}
+	a, _ := gateway.session(users[0])
+	_, keyPackageB := gateway.session(users[1])
+
+	proposal := gateway.add(0, keyPackageB)
+	commitWelcome, err := a.ProcessProposals(DAVEProposalsOperationAppend, testDAVEProposals(proposal), users)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, commitWelcome)
+
+	w := &mlsWriter{}
+	w.vector(func(w *mlsWriter) {
+		w.opaque(testDAVEProposalRef(proposal))
+	})
+	commitWelcome, err = a.ProcessProposals(DAVEProposalsOperationRevoke, w.b, users)
+	assert.NoError(t, err)
+	assert.Nil(t, commitWelcome)
+}
+
+func TestDAVESession_UnrecognizedUser(t *testing.T) {
+	gateway := newTestDAVEGateway(t)
+	a, _ := gateway.session(1)
+	b, keyPackageB := gateway.session(2)
+
+	_, err := a.ProcessProposals(DAVEProposalsOperationAppend, testDAVEProposals(gateway.add(0, keyPackageB)), []snowflake.ID{1})
+	assert.ErrorIs(t, err, ErrDAVEUnrecognizedUser)
+
+	commitWelcome, err := a.ProcessProposals(DAVEProposalsOperationAppend, testDAVEProposals(gateway.add(0, keyPackageB)), []snowflake.ID{1, 2})
+	if !assert.NoError(t, err) {
+		return
+	}
+	_, welcome := splitTestDAVECommitWelcome(t, commitWelcome)
+	assert.ErrorIs(t, b.ProcessWelcome(welcome, []snowflake.ID{2}), ErrDAVEUnrecognizedUser)
+
+	// welcomes for other key packages are rejected
+	other, _ := gateway.session(2)
+	assert.ErrorIs(t, other.ProcessWelcome(welcome, []snowflake.ID{1, 2}), errMLSInvalidWelcome)
+	assert.NoError(t, b.ProcessWelcome(welcome, []snowflake.ID{1, 2}))
+}
+
+func TestDAVESession_InvalidProposal(t *testing.T) {
+	gateway := newTestDAVEGateway(t)
+	a, _ := gateway.session(1)
+	_, keyPackageB := gateway.session(2)
+
+	other := newTestDAVEGateway(t)
+	_, err := a.ProcessProposals(DAVEProposalsOperationAppend, testDAVEProposals(other.add(0, keyPackageB)), []snowflake.ID{1, 2})
+	assert.ErrorIs(t, err, errMLSInvalidSignature)
+
+	_, err = a.ProcessProposals(DAVEProposalsOperationAppend, testDAVEProposals(gateway.add(1, keyPackageB)), []snowflake.ID{1, 2})
+	assert.ErrorIs(t, err, ErrMLSMessageInvalid)
+}
+
+func TestDAVESession_TamperedCommit(t *testing.T) {
+	gateway := newTestDAVEGateway(t)
+	users := []snowflake.ID{1, 2, 3}
+	a, _ := gateway.session(users[0])
+	b, keyPackageB := gateway.session(users[1])
+	_, keyPackageC := gateway.session(users[2])
+
+	commitWelcome, err := a.ProcessProposals(DAVEProposalsOperationAppend, testDAVEProposals(gateway.add(0, keyPackageB)), users)
+	if !assert.NoError(t, err) {
+		return
+	}
+	commit, welcome := splitTestDAVECommitWelcome(t, commitWelcome)
+	assert.NoError(t, a.ProcessCommit(commit))
+	if !assert.NoError(t, b.ProcessWelcome(welcome, users)) {
+		return
+	}
+
+	addC := testDAVEProposals(gateway.add(1, keyPackageC))
+	_, err = b.ProcessProposals(DAVEProposalsOperationAppend, addC, users)
+	assert.NoError(t, err)
+	commitWelcome, err = a.ProcessProposals(DAVEProposalsOperationAppend, addC, users)
+	if !assert.NoError(t, err) {
+		return
+	}
+	commit, _ = splitTestDAVECommitWelcome(t, commitWelcome)
+
+	tampered := append([]byte{}, commit...)
+	tampered[len(tampered)-1] ^= 0xff
+	assert.ErrorIs(t, b.ProcessCommit(tampered), errMLSInvalidCommit)
+
+	secret, _ := b.ExportSenderBaseSecret(users[0])
+	assert.NoError(t, b.ProcessCommit(commit))
+	assert.NoError(t, a.ProcessCommit(commit))
+	nextSecret, _ := b.ExportSenderBaseSecret(users[0])
+	assert.NotEqual(t, secret, nextSecret)
+	assertDAVESecretsEqual(t, users[:2], a, b)
+}
+
+func TestDAVESession_FakeGateway(t *testing.T) {
+	server, conns := fakeVoiceGateway(t)
+	daveGateway := newTestDAVEGateway(t)
+
+	var (
+		userID    = snowflake.ID(1)
+		otherID   = snowflake.ID(2)
+		channelID = testDAVEChannelID
+		dave      *daveImpl
+	)
+	gateway := NewGateway(func(_ Opcode, data GatewayMessageData) {
+		dave.handleMessage(data)
+	}, nil,
+		WithGatewayDialer(&websocket.Dialer{TLSClientConfig: server.Client().Transport.(*http.Transport).TLSClientConfig}),
+		WithGatewayAutoReconnect(false),
+		WithGatewayMaxDAVEProtocolVersion(DAVEProtocolVersion),
+		WithGatewayLogger(slog.New(slog.NewTextHandler(&strings.Builder{}, nil))),
+	)
+	dave = newDAVE(slog.New(slog.NewTextHandler(&strings.Builder{}, nil)), NewDAVESession(), gateway, userID, func() *snowflake.ID { return &channelID })
+	defer gateway.Close()
+
+	assert.NoError(t, gateway.Open(context.Background(), State{UserID: userID, Endpoint: strings.TrimPrefix(server.URL, "https://")}))
+	conn := <-conns
+
+	sendJSON(t, conn, OpcodeHello, 0, map[string]any{"heartbeat_interval": 60000})
+	op, _ := readJSON(t, conn)
+	assert.Equal(t, OpcodeIdentify, op)
+
+	sendJSON(t, conn, OpcodeSessionDescription, 1, map[string]any{"mode": EncryptionModeAEADAES256GCMRTPSize, "secret_key": make([]int, 32), "dave_protocol_version": 1})
+	messageType, data := readMessage(t, conn)
+	assert.Equal(t, websocket.BinaryMessage, messageType)
+	if !assert.Equal(t, byte(OpcodeDAVEMLSKeyPackage), data[0]) {
+		return
+	}
+	keyPackage := data[1:]
+
+	sendBinary(t, conn, OpcodeDAVEMLSExternalSenderPackage, 2, daveGateway.externalSender())
+	sendJSON(t, conn, OpcodeClientsConnect, 3, map[string]any{"user_ids": []string{otherID.String()}})
+
+	// the other user commits the add of the own user first
+	other, _ := daveGateway.session(otherID)
+	proposals := testDAVEProposals(daveGateway.add(0, keyPackage))
+	commitWelcome, err := other.ProcessProposals(DAVEProposalsOperationAppend, proposals, []snowflake.ID{userID, otherID})
+	if !assert.NoError(t, err) {
+		return
+	}
+	commit, welcome := splitTestDAVECommitWelcome(t, commitWelcome)
+	assert.NoError(t, other.ProcessCommit(commit))
+
+	// the own commit of the same proposals loses & the announced commit of the other user is ignored
+	sendBinary(t, conn, OpcodeDAVEMLSProposals, 4, append([]byte{byte(DAVEProposalsOperationAppend)}, proposals...))
+	messageType, data = readMessage(t, conn)
+	assert.Equal(t, websocket.BinaryMessage, messageType)
+	assert.Equal(t, byte(OpcodeDAVEMLSCommitWelcome), data[0])
+	sendBinary(t, conn, OpcodeDAVEMLSAnnounceCommitTransition, 5, append([]byte{0x00, 0x05}, commit...))
+
+	// the own user joins with the welcome
+	sendBinary(t, conn, OpcodeDAVEMLSWelcome, 6, append([]byte{0x00, 0x05}, welcome...))
+	op, d := readJSON(t, conn)
+	assert.Equal(t, OpcodeDAVETransitionReady, op)
+	assert.JSONEq(t, `{"transition_id":5}`, string(d))
+
+	sendJSON(t, conn, OpcodeDAVEExecuteTransition, 7, map[string]any{"transition_id": 5})
+	assert.Eventually(t, func() bool {
+		_, err := dave.EncryptFrame([]byte{0x01})
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// frames are encrypted with the base secrets exported by both members
+	otherSecret, err := other.ExportSenderBaseSecret(otherID)
+	assert.NoError(t, err)
+	frame, err := NewDAVEFrameEncryptor(NewDAVEKeyRatchet(otherSecret)).Encrypt([]byte("opus"))
+	assert.NoError(t, err)
+	decrypted, err := dave.DecryptFrame(otherID, frame)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("opus"), decrypted)
+
+	ownSecret, err := other.ExportSenderBaseSecret(userID)
+	assert.NoError(t, err)
+	frame, err = dave.EncryptFrame([]byte("opus"))
+	assert.NoError(t, err)
+	decrypted, err = NewDAVEFrameDecryptor(NewDAVEKeyRatchet(ownSecret)).Decrypt(frame)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("opus"), decrypted)
+}
@@ -0,0 +1,221 @@
+package voice
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/json"
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeDAVESession struct {
+	mu             sync.Mutex
+	externalSender []byte
+	groupID        snowflake.ID
+	epoch          int
+}
+
+func (s *fakeDAVESession) Init(_ int, groupID snowflake.ID, _ snowflake.ID) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.groupID = groupID
+	return []byte("key package"), nil
+}
+
+func (s *fakeDAVESession) SetExternalSender(externalSender []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.externalSender = bytes.Clone(externalSender)
+	return nil
+}
+
+func (s *fakeDAVESession) ProcessProposals(_ DAVEProposalsOperation, proposals []byte, _ []snowflake.ID) ([]byte, error) {
+	return append([]byte("commit:"), proposals...), nil
+}
+
+func (s *fakeDAVESession) ProcessCommit(_ []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.epoch++
+	return nil
+}
+
+func (s *fakeDAVESession) ProcessWelcome(_ []byte, _ []snowflake.ID) error {
+	return s.ProcessCommit(nil)
+}
+
+func (s *fakeDAVESession) ExportSenderBaseSecret(userID snowflake.ID) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	secret := make([]byte, 16)
+	binary.LittleEndian.PutUint64(secret, uint64(userID))
+	secret[15] = byte(s.epoch)
+	return secret, nil
+}
+
+// fakeVoiceGateway is a local voice gateway which hands out the server side of the websocket connection.
+func fakeVoiceGateway(t *testing.T) (*httptest.Server, <-chan *websocket.Conn) {
+	conns := make(chan *websocket.Conn, 1)
+	done := make(chan struct{})
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		conns <- conn
+		<-done
+	}))
+	t.Cleanup(func() {
+		close(done)
+		server.Close()
+	})
+	return server, conns
+}
+
+func sendJSON(t *testing.T, conn *websocket.Conn, op Opcode, seq int, d any) {
+	data, err := json.Marshal(map[string]any{"op": op, "seq": seq, "d": d})
+	assert.NoError(t, err)
+	assert.NoError(t, conn.WriteMessage(websocket.TextMessage, data))
+}
+
+func sendBinary(t *testing.T, conn *websocket.Conn, op Opcode, seq uint16, payload []byte) {
+	data := binary.BigEndian.AppendUint16(nil, seq)
+	data = append(data, byte(op))
+	assert.NoError(t, conn.WriteMessage(websocket.BinaryMessage, append(data, payload...)))
+}
+
+func readMessage(t *testing.T, conn *websocket.Conn) (int, []byte) {
+	assert.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	messageType, data, err := conn.ReadMessage()
+	assert.NoError(t, err)
+	return messageType, data
+}
+
+func readJSON(t *testing.T, conn *websocket.Conn) (Opcode, json.RawMessage) {
+	messageType, data := readMessage(t, conn)
+	assert.Equal(t, websocket.TextMessage, messageType)
+	var v struct {
+		Op Opcode          `json:"op"`
+		D  json.RawMessage `json:"d"`
+	}
+	assert.NoError(t, json.Unmarshal(data, &v))
+	return v.Op, v.D
+}
+
+func TestDAVE_FakeGateway(t *testing.T) {
+	server, conns := fakeVoiceGateway(t)
+
+	var (
+		userID    = snowflake.ID(1)
+		otherID   = snowflake.ID(2)
+		channelID = snowflake.ID(3)
+		session   = &fakeDAVESession{}
+		dave      *daveImpl
+	)
+	gateway := NewGateway(func(_ Opcode, data GatewayMessageData) {
+		dave.handleMessage(data)
+	}, nil,
+		WithGatewayDialer(&websocket.Dialer{TLSClientConfig: server.Client().Transport.(*http.Transport).TLSClientConfig}),
+		WithGatewayAutoReconnect(false),
+		WithGatewayMaxDAVEProtocolVersion(DAVEProtocolVersion),
+		WithGatewayLogger(slog.New(slog.NewTextHandler(&strings.Builder{}, nil))),
+	)
+	dave = newDAVE(slog.Default(), session, gateway, userID, func() *snowflake.ID { return &channelID })
+	defer gateway.Close()
+
+	assert.NoError(t, gateway.Open(context.Background(), State{UserID: userID, Endpoint: strings.TrimPrefix(server.URL, "https://")}))
+	conn := <-conns
+
+	sendJSON(t, conn, OpcodeHello, 0, map[string]any{"heartbeat_interval": 60000})
+	op, d := readJSON(t, conn)
+	assert.Equal(t, OpcodeIdentify, op)
+	var identify GatewayMessageDataIdentify
+	assert.NoError(t, json.Unmarshal(d, &identify))
+	assert.Equal(t, DAVEProtocolVersion, identify.MaxDAVEProtocolVersion)
+
+	// the session description starts the DAVE session & the key package is sent
+	sendJSON(t, conn, OpcodeSessionDescription, 1, map[string]any{"mode": EncryptionModeAEADAES256GCMRTPSize, "secret_key": make([]int, 32), "dave_protocol_version": 1})
+	messageType, data := readMessage(t, conn)
+	assert.Equal(t, websocket.BinaryMessage, messageType)
+	assert.Equal(t, append([]byte{byte(OpcodeDAVEMLSKeyPackage)}, "key package"...), data)
+
+	_, err := dave.EncryptFrame([]byte{0x01})
+	assert.ErrorIs(t, err, ErrDAVENotReady)
+
+	sendBinary(t, conn, OpcodeDAVEMLSExternalSenderPackage, 2, []byte("external sender"))
+	sendJSON(t, conn, OpcodeClientsConnect, 3, map[string]any{"user_ids": []string{otherID.String()}})
+
+	// proposals are committed
+	sendBinary(t, conn, OpcodeDAVEMLSProposals, 4, append([]byte{byte(DAVEProposalsOperationAppend)}, "add"...))
+	messageType, data = readMessage(t, conn)
+	assert.Equal(t, websocket.BinaryMessage, messageType)
+	assert.Equal(t, append([]byte{byte(OpcodeDAVEMLSCommitWelcome)}, "commit:add"...), data)
+
+	// the announced commit is processed & the transition is prepared
+	sendBinary(t, conn, OpcodeDAVEMLSAnnounceCommitTransition, 5, append([]byte{0x00, 0x05}, "commit"...))
+	op, d = readJSON(t, conn)
+	assert.Equal(t, OpcodeDAVETransitionReady, op)
+	assert.JSONEq(t, `{"transition_id":5}`, string(d))
+
+	sendJSON(t, conn, OpcodeDAVEExecuteTransition, 6, map[string]any{"transition_id": 5})
+	assert.Eventually(t, func() bool {
+		_, err := dave.EncryptFrame([]byte{0x01})
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	session.mu.Lock()
+	assert.Equal(t, []byte("external sender"), session.externalSender)
+	assert.Equal(t, channelID, session.groupID)
+	session.mu.Unlock()
+	assert.Equal(t, 1, dave.ProtocolVersion())
+
+	// frames of other users are decrypted with their base secret
+	otherSecret, _ := session.ExportSenderBaseSecret(otherID)
+	frame, err := NewDAVEFrameEncryptor(NewDAVEKeyRatchet(otherSecret)).Encrypt([]byte("opus"))
+	assert.NoError(t, err)
+	decrypted, err := dave.DecryptFrame(otherID, frame)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("opus"), decrypted)
+
+	// a failed welcome is reported & the key package is sent again
+	dave.sessionMu.Lock()
+	dave.session = &failingDAVESession{fakeDAVESession: session}
+	dave.sessionMu.Unlock()
+	sendBinary(t, conn, OpcodeDAVEMLSWelcome, 7, append([]byte{0x00, 0x06}, "welcome"...))
+	op, d = readJSON(t, conn)
+	assert.Equal(t, OpcodeDAVEMLSInvalidCommitWelcome, op)
+	assert.JSONEq(t, `{"transition_id":6}`, string(d))
+	messageType, data = readMessage(t, conn)
+	assert.Equal(t, websocket.BinaryMessage, messageType)
+	assert.Equal(t, byte(OpcodeDAVEMLSKeyPackage), data[0])
+}
+
+type failingDAVESession struct {
+	*fakeDAVESession
+}
+
+func (s *failingDAVESession) ProcessWelcome(_ []byte, _ []snowflake.ID) error {
+	return ErrDecryptionFailed
+}
+
+func TestGatewayMessage_UnmarshalBinary(t *testing.T) {
+	var message GatewayMessage
+	assert.NoError(t, message.UnmarshalBinary([]byte{0x00, 0x0A, byte(OpcodeDAVEMLSWelcome), 0x00, 0x02, 0xFF}))
+	assert.Equal(t, OpcodeDAVEMLSWelcome, message.Op)
+	assert.Equal(t, 10, message.Seq)
+	assert.Equal(t, GatewayMessageDataDAVEMLSWelcome{TransitionID: 2, Welcome: []byte{0xFF}}, message.D)
+
+	assert.Error(t, message.UnmarshalBinary([]byte{0x00, 0x01}))
+	assert.Error(t, message.UnmarshalBinary([]byte{0x00, 0x01, byte(OpcodeHello)}))
+}
@@ -0,0 +1,183 @@
+package voice
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"slices"
+
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/nacl/secretbox"
+)
+
+// ErrUnsupportedEncryptionMode is returned when none of the EncryptionMode(s) offered by the voice server are supported.
+var ErrUnsupportedEncryptionMode = errors.New("unsupported encryption mode")
+
+// SupportedEncryptionModes are the EncryptionMode(s) supported by the UDPConn in order of preference.
+var SupportedEncryptionModes = []EncryptionMode{
+	EncryptionModeAEADAES256GCMRTPSize,
+	EncryptionModeAEADXChaCha20Poly1305RTPSize,
+	EncryptionModeNormal,
+}
+
+// ChooseEncryptionMode returns the most preferred of the SupportedEncryptionModes which is offered by the voice server in the GatewayMessageDataReady.
+func ChooseEncryptionMode(modes []EncryptionMode) (EncryptionMode, error) {
+	for _, mode := range SupportedEncryptionModes {
+		if slices.Contains(modes, mode) {
+			return mode, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %v", ErrUnsupportedEncryptionMode, modes)
+}
+
+const (
+	// rtpHeaderExtensionSize is the size of the RTP header extension profile & length which are not encrypted in rtpsize modes.
+	rtpHeaderExtensionSize = 4
+	// rtpSizeNonceSize is the size of the incrementing nonce appended to packets in rtpsize modes.
+	rtpSizeNonceSize = 4
+)
+
+// packetCipher encrypts & decrypts RTP packets for an EncryptionMode.
+type packetCipher interface {
+	// seal appends the RTP header & the encrypted opus data to dst.
+	seal(dst []byte, header []byte, opus []byte) []byte
+	// open decrypts the RTP packet & returns the opus data without the RTP header extension.
+	open(packet []byte) ([]byte, error)
+}
+
+func newPacketCipher(mode EncryptionMode, secretKey [32]byte) (packetCipher, error) {
+	switch mode {
+	case EncryptionModeAEADAES256GCMRTPSize:
+		block, err := aes.NewCipher(secretKey[:])
+		if err != nil {
+			return nil, err
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+		return &rtpSizeCipher{aead: aead}, nil
+
+	case EncryptionModeAEADXChaCha20Poly1305RTPSize:
+		aead, err := chacha20poly1305.NewX(secretKey[:])
+		if err != nil {
+			return nil, err
+		}
+		return &rtpSizeCipher{aead: aead}, nil
+
+	case EncryptionModeNormal:
+		return &xsalsa20Poly1305Cipher{secretKey: secretKey}, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnsupportedEncryptionMode, mode)
+}
+
+// rtpSizeCipher implements the aead_*_rtpsize modes.
+// The RTP header including the header extension profile & length is used as additional data & the 32-bit nonce counter is appended to the packet.
+// See https://discord.com/developers/docs/topics/voice-connections#transport-encryption-modes
+type rtpSizeCipher struct {
+	aead  cipher.AEAD
+	nonce uint32
+}
+
+func (c *rtpSizeCipher) seal(dst []byte, header []byte, opus []byte) []byte {
+	var nonce [chacha20poly1305.NonceSizeX]byte
+	binary.BigEndian.PutUint32(nonce[:rtpSizeNonceSize], c.nonce)
+
+	dst = append(dst, header...)
+	dst = c.aead.Seal(dst, nonce[:c.aead.NonceSize()], opus, header)
+	dst = append(dst, nonce[:rtpSizeNonceSize]...)
+	c.nonce++
+	return dst
+}
+
+func (c *rtpSizeCipher) open(packet []byte) ([]byte, error) {
+	headerSize, hasExtension, ok := rtpHeaderSize(packet)
+	if !ok {
+		return nil, ErrDecryptionFailed
+	}
+	if hasExtension {
+		headerSize += rtpHeaderExtensionSize
+	}
+	if len(packet) < headerSize+c.aead.Overhead()+rtpSizeNonceSize {
+		return nil, ErrDecryptionFailed
+	}
+
+	var nonce [chacha20poly1305.NonceSizeX]byte
+	copy(nonce[:rtpSizeNonceSize], packet[len(packet)-rtpSizeNonceSize:])
+
+	opus, err := c.aead.Open(nil, nonce[:c.aead.NonceSize()], packet[headerSize:len(packet)-rtpSizeNonceSize], packet[:headerSize])
+	if err != nil {
+		return nil, ErrDecryptionFailed
+	}
+
+	if hasExtension {
+		// the extension data is encrypted, only its length is part of the unencrypted header
+		extensionSize := 4 * int(binary.BigEndian.Uint16(packet[headerSize-2:headerSize]))
+		if extensionSize > len(opus) {
+			return nil, ErrDecryptionFailed
+		}
+		opus = opus[extensionSize:]
+	}
+	return stripRTPPadding(packet, opus)
+}
+
+// xsalsa20Poly1305Cipher implements the deprecated xsalsa20_poly1305 mode. The RTP header is used as nonce & the header extension is encrypted.
+type xsalsa20Poly1305Cipher struct {
+	secretKey [32]byte
+}
+
+func (c *xsalsa20Poly1305Cipher) seal(dst []byte, header []byte, opus []byte) []byte {
+	var nonce [24]byte
+	copy(nonce[:], header)
+	return secretbox.Seal(append(dst, header...), opus, &nonce, &c.secretKey)
+}
+
+func (c *xsalsa20Poly1305Cipher) open(packet []byte) ([]byte, error) {
+	headerSize, hasExtension, ok := rtpHeaderSize(packet)
+	if !ok {
+		return nil, ErrDecryptionFailed
+	}
+
+	var nonce [24]byte
+	copy(nonce[:], packet[:OpusPacketHeaderSize])
+
+	opus, ok := secretbox.Open(nil, packet[headerSize:], &nonce, &c.secretKey)
+	if !ok {
+		return nil, ErrDecryptionFailed
+	}
+
+	if hasExtension && len(opus) >= rtpHeaderExtensionSize {
+		shift := rtpHeaderExtensionSize + 4*int(binary.BigEndian.Uint16(opus[2:4]))
+		if len(opus) > shift {
+			opus = opus[shift:]
+		}
+	}
+	return stripRTPPadding(packet, opus)
+}
+
+// rtpHeaderSize returns the size of the RTP header including CSRCs & whether the packet has a header extension.
+func rtpHeaderSize(packet []byte) (int, bool, bool) {
+	if len(packet) < OpusPacketHeaderSize {
+		return 0, false, false
+	}
+	size := OpusPacketHeaderSize + 4*int(packet[0]&0x0F)
+	hasExtension := packet[0]&0x10 != 0
+	if len(packet) < size || (hasExtension && len(packet) < size+rtpHeaderExtensionSize) {
+		return 0, false, false
+	}
+	return size, hasExtension, true
+}
+
+// stripRTPPadding removes the RTP padding from the decrypted payload if the padding bit is set.
+func stripRTPPadding(packet []byte, payload []byte) ([]byte, error) {
+	if packet[0]&0x20 == 0 || len(payload) == 0 {
+		return payload, nil
+	}
+	padding := int(payload[len(payload)-1])
+	if padding > len(payload) {
+		return nil, ErrDecryptionFailed
+	}
+	return payload[:len(payload)-padding], nil
+}
@@ -0,0 +1,156 @@
+package voice
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChooseEncryptionMode(t *testing.T) {
+	mode, err := ChooseEncryptionMode([]EncryptionMode{EncryptionModeNormal, EncryptionModeAEADXChaCha20Poly1305RTPSize, EncryptionModeAEADAES256GCMRTPSize})
+	assert.NoError(t, err)
+	assert.Equal(t, EncryptionModeAEADAES256GCMRTPSize, mode)
+
+	mode, err = ChooseEncryptionMode([]EncryptionMode{EncryptionModeLite, EncryptionModeAEADXChaCha20Poly1305RTPSize})
+	assert.NoError(t, err)
+	assert.Equal(t, EncryptionModeAEADXChaCha20Poly1305RTPSize, mode)
+
+	_, err = ChooseEncryptionMode([]EncryptionMode{EncryptionModeLite})
+	assert.ErrorIs(t, err, ErrUnsupportedEncryptionMode)
+}
+
+func testRTPHeader(sequence uint16) []byte {
+	header := []byte{0x80, 0x78, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1}
+	binary.BigEndian.PutUint16(header[2:4], sequence)
+	return header
+}
+
+func TestPacketCipher(t *testing.T) {
+	var secretKey [32]byte
+	for i := range secretKey {
+		secretKey[i] = byte(i)
+	}
+
+	for _, mode := range SupportedEncryptionModes {
+		t.Run(string(mode), func(t *testing.T) {
+			sender, err := newPacketCipher(mode, secretKey)
+			if !assert.NoError(t, err) {
+				return
+			}
+			receiver, err := newPacketCipher(mode, secretKey)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			for i := 0; i < 3; i++ {
+				opus := []byte{0xF8, 0xFF, 0xFE, byte(i)}
+				packet := sender.seal(nil, testRTPHeader(uint16(i)), opus)
+				assert.Equal(t, testRTPHeader(uint16(i)), packet[:OpusPacketHeaderSize])
+
+				decrypted, err := receiver.open(packet)
+				assert.NoError(t, err)
+				assert.Equal(t, opus, decrypted)
+
+				packet[len(packet)/2] ^= 0xFF
+				_, err = receiver.open(packet)
+				assert.ErrorIs(t, err, ErrDecryptionFailed)
+			}
+		})
+	}
+}
+
+func TestPacketCipher_RTPSizeHeaderExtension(t *testing.T) {
+	var secretKey [32]byte
+	for _, mode := range []EncryptionMode{EncryptionModeAEADAES256GCMRTPSize, EncryptionModeAEADXChaCha20Poly1305RTPSize} {
+		t.Run(string(mode), func(t *testing.T) {
+			c, err := newPacketCipher(mode, secretKey)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			// the extension profile & length are part of the unencrypted header, the extension data is encrypted
+			header := append(testRTPHeader(1), 0xBE, 0xDE, 0x00, 0x01)
+			header[0] |= 0x10
+			opus := []byte{0xF8, 0xFF, 0xFE}
+			packet := c.seal(nil, header, append([]byte{0x10, 0x01, 0x02, 0x00}, opus...))
+
+			decrypted, err := c.open(packet)
+			assert.NoError(t, err)
+			assert.Equal(t, opus, decrypted)
+		})
+	}
+}
+
+// TestPacketCipher_RTPSizeKnownAnswer checks the rtpsize modes against packets generated with libsodium's
+// crypto_aead_aes256gcm_encrypt & crypto_aead_xchacha20poly1305_ietf_encrypt using the packet layout of discord.js.
+// The key is 0x00..0x1f & the opus data is F8 FF FE. Packets with a header extension encrypt the extension data 10 01 02 00.
+func TestPacketCipher_RTPSizeKnownAnswer(t *testing.T) {
+	var secretKey [32]byte
+	for i := range secretKey {
+		secretKey[i] = byte(i)
+	}
+	opus := []byte{0xF8, 0xFF, 0xFE}
+	extension := []byte{0x10, 0x01, 0x02, 0x00}
+
+	tt := []struct {
+		name       string
+		mode       EncryptionMode
+		headerSize int
+		nonce      uint32
+		packet     string
+	}{
+		{
+			name:       "aes256_gcm",
+			mode:       EncryptionModeAEADAES256GCMRTPSize,
+			headerSize: OpusPacketHeaderSize,
+			nonce:      0x12345678,
+			packet:     "80780001000003c0000000015e42bfa1b2e526f580653d09a906091b65d29612345678",
+		},
+		{
+			name:       "aes256_gcm_extension",
+			mode:       EncryptionModeAEADAES256GCMRTPSize,
+			headerSize: OpusPacketHeaderSize + rtpHeaderExtensionSize,
+			nonce:      0x12345679,
+			packet:     "907800020000078000000001bede0001c2952abcb03cd62f405121e0607a3a7b5a16603268589912345679",
+		},
+		{
+			name:       "xchacha20_poly1305",
+			mode:       EncryptionModeAEADXChaCha20Poly1305RTPSize,
+			headerSize: OpusPacketHeaderSize,
+			nonce:      0x12345678,
+			packet:     "80780001000003c0000000010edaf69f3a85bddbdd7ff71971d2fabae7fa4412345678",
+		},
+		{
+			name:       "xchacha20_poly1305_extension",
+			mode:       EncryptionModeAEADXChaCha20Poly1305RTPSize,
+			headerSize: OpusPacketHeaderSize + rtpHeaderExtensionSize,
+			nonce:      0x12345679,
+			packet:     "907800020000078000000001bede0001d6d494e843e6f02c65a0b9b3c9f4ed7229e2b9b64f6dba12345679",
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := newPacketCipher(tc.mode, secretKey)
+			if !assert.NoError(t, err) {
+				return
+			}
+			packet, err := hex.DecodeString(tc.packet)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			payload := opus
+			if tc.headerSize > OpusPacketHeaderSize {
+				payload = append(extension, opus...)
+			}
+			c.(*rtpSizeCipher).nonce = tc.nonce
+			assert.Equal(t, packet, c.seal(nil, packet[:tc.headerSize], payload))
+
+			decrypted, err := c.open(packet)
+			assert.NoError(t, err)
+			assert.Equal(t, opus, decrypted)
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"io"
 	"log/slog"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -27,7 +28,7 @@ var (
 )
 
 // GatewayVersion is the version of the voice gateway we are using.
-const GatewayVersion = 4
+const GatewayVersion = 8
 
 // Status returns the current status of the gateway.
 type Status int
@@ -87,6 +88,9 @@ type Gateway interface {
 
 	// Send sends a message to the voice gateway.
 	Send(ctx context.Context, opCode Opcode, data GatewayMessageData) error
+
+	// SendBinary sends a binary message to the voice gateway. This is used by the DAVE MLS opcodes.
+	SendBinary(ctx context.Context, opCode Opcode, data []byte) error
 }
 
 // NewGateway creates a new voice Gateway.
@@ -114,11 +118,12 @@ type gatewayImpl struct {
 	connMu sync.Mutex
 	status Status
 
-	heartbeatTicker       *time.Ticker
+	heartbeatCancel       context.CancelFunc
 	heartbeatInterval     time.Duration
 	lastHeartbeatSent     time.Time
 	lastHeartbeatReceived time.Time
 	lastNonce             int64
+	lastSeq               atomic.Int64
 }
 
 func (g *gatewayImpl) SSRC() uint32 {
@@ -162,14 +167,13 @@ func (g *gatewayImpl) Close() {
 }
 
 func (g *gatewayImpl) CloseWithCode(code int, message string) {
-	if g.heartbeatTicker != nil {
-		g.config.Logger.Debug("closing heartbeat goroutines")
-		g.heartbeatTicker.Stop()
-		g.heartbeatTicker = nil
-	}
-
 	g.connMu.Lock()
 	defer g.connMu.Unlock()
+	if g.heartbeatCancel != nil {
+		g.config.Logger.Debug("closing heartbeat goroutines")
+		g.heartbeatCancel()
+		g.heartbeatCancel = nil
+	}
 	if g.conn != nil {
 		g.config.Logger.Debug("closing voice gateway connection", slog.Int("code", code), slog.String("message", message))
 		if err := g.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(code, message)); err != nil && !errors.Is(err, websocket.ErrCloseSent) {
@@ -185,13 +189,18 @@ func (g *gatewayImpl) CloseWithCode(code int, message string) {
 	}
 }
 
-func (g *gatewayImpl) heartbeat() {
-	g.heartbeatTicker = time.NewTicker(g.heartbeatInterval)
-	defer g.heartbeatTicker.Stop()
+func (g *gatewayImpl) heartbeat(ctx context.Context, interval time.Duration) {
+	heartbeatTicker := time.NewTicker(interval)
+	defer heartbeatTicker.Stop()
 	defer g.config.Logger.Debug("exiting voice heartbeat goroutine")
 
-	for range g.heartbeatTicker.C {
-		g.sendHeartbeat()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeatTicker.C:
+			g.sendHeartbeat()
+		}
 	}
 }
 
@@ -200,7 +209,10 @@ func (g *gatewayImpl) sendHeartbeat() {
 	ctx, cancel := context.WithTimeout(context.Background(), g.heartbeatInterval)
 	defer cancel()
 
-	if err := g.Send(ctx, OpcodeHeartbeat, GatewayMessageDataHeartbeat(g.lastNonce)); err != nil {
+	if err := g.Send(ctx, OpcodeHeartbeat, GatewayMessageDataHeartbeat{
+		T:      g.lastNonce,
+		SeqAck: int(g.lastSeq.Load()),
+	}); err != nil {
 		if !errors.Is(err, ErrGatewayNotConnected) || errors.Is(err, syscall.EPIPE) {
 			return
 		}
@@ -216,7 +228,7 @@ func (g *gatewayImpl) listen(conn *websocket.Conn) {
 	defer g.config.Logger.Debug("exiting listen goroutine")
 loop:
 	for {
-		_, reader, err := conn.NextReader()
+		messageType, reader, err := conn.NextReader()
 		if err != nil {
 			g.connMu.Lock()
 			sameConn := g.conn == conn
@@ -242,27 +254,39 @@ loop:
 			break loop
 		}
 
-		message, err := g.parseMessage(reader)
+		message, err := g.parseMessage(messageType, reader)
 		if err != nil {
 			g.config.Logger.Error("error while parsing voice gateway event", slog.Any("err", err))
 			continue
 		}
+		if message.Seq > 0 {
+			g.lastSeq.Store(int64(message.Seq))
+		}
 
 		switch d := message.D.(type) {
 		case GatewayMessageDataHello:
 			g.status = StatusWaitingForReady
 			g.lastHeartbeatReceived = time.Now().UTC()
 			g.heartbeatInterval = time.Duration(d.HeartbeatInterval) * time.Millisecond
-			go g.heartbeat()
+			g.connMu.Lock()
+			if g.heartbeatCancel != nil {
+				g.heartbeatCancel()
+			}
+			heartbeatCtx, heartbeatCancel := context.WithCancel(context.Background())
+			g.heartbeatCancel = heartbeatCancel
+			g.connMu.Unlock()
+			go g.heartbeat(heartbeatCtx, g.heartbeatInterval)
 
 			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 			if g.ssrc == 0 {
 				g.status = StatusIdentifying
+				g.lastSeq.Store(0)
 				err = g.Send(ctx, OpcodeIdentify, GatewayMessageDataIdentify{
-					GuildID:   g.state.GuildID,
-					UserID:    g.state.UserID,
-					SessionID: g.state.SessionID,
-					Token:     g.state.Token,
+					GuildID:                g.state.GuildID,
+					UserID:                 g.state.UserID,
+					SessionID:              g.state.SessionID,
+					Token:                  g.state.Token,
+					MaxDAVEProtocolVersion: g.config.MaxDAVEProtocolVersion,
 				})
 			} else {
 				g.status = StatusResuming
@@ -270,6 +294,7 @@ loop:
 					GuildID:   g.state.GuildID,
 					SessionID: g.state.SessionID,
 					Token:     g.state.Token,
+					SeqAck:    int(g.lastSeq.Load()),
 				})
 			}
 			cancel()
@@ -284,8 +309,8 @@ loop:
 			g.ssrc = d.SSRC
 
 		case GatewayMessageDataHeartbeatACK:
-			if int64(d) != g.lastNonce {
-				g.config.Logger.Error("received heartbeat ack with nonce", slog.Int64("nonce", int64(d)), slog.Int64("last_nonce", g.lastNonce))
+			if d.T != g.lastNonce {
+				g.config.Logger.Error("received heartbeat ack with nonce", slog.Int64("nonce", d.T), slog.Int64("last_nonce", g.lastNonce))
 				go g.reconnect()
 				break loop
 			}
@@ -310,6 +335,14 @@ func (g *gatewayImpl) Send(ctx context.Context, op Opcode, d GatewayMessageData)
 	return g.send(ctx, websocket.TextMessage, data)
 }
 
+func (g *gatewayImpl) SendBinary(ctx context.Context, op Opcode, d []byte) error {
+	// binary messages sent by the client only start with the 1 byte opcode
+	data := make([]byte, 0, len(d)+1)
+	data = append(data, byte(op))
+	data = append(data, d...)
+	return g.send(ctx, websocket.BinaryMessage, data)
+}
+
 func (g *gatewayImpl) send(ctx context.Context, messageType int, data []byte) error {
 	g.connMu.Lock()
 	defer g.connMu.Unlock()
@@ -317,7 +350,11 @@ func (g *gatewayImpl) send(ctx context.Context, messageType int, data []byte) er
 		return ErrGatewayNotConnected
 	}
 
-	g.config.Logger.Debug("sending message to voice gateway", slog.String("data", string(data)))
+	if messageType == websocket.BinaryMessage {
+		g.config.Logger.Debug("sending binary message to voice gateway", slog.Int("op", int(data[0])), slog.Int("len", len(data)))
+	} else {
+		g.config.Logger.Debug("sending message to voice gateway", slog.String("data", string(data)))
+	}
 	deadline, ok := ctx.Deadline()
 	if ok {
 		if err := g.conn.SetWriteDeadline(deadline); err != nil {
@@ -363,7 +400,21 @@ func (g *gatewayImpl) reconnect() {
 	}
 }
 
-func (g *gatewayImpl) parseMessage(r io.Reader) (GatewayMessage, error) {
+func (g *gatewayImpl) parseMessage(messageType int, r io.Reader) (GatewayMessage, error) {
+	if messageType == websocket.BinaryMessage {
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return GatewayMessage{}, err
+		}
+		g.config.Logger.Debug("received binary message from voice gateway", slog.Int("len", len(data)))
+
+		var message GatewayMessage
+		if err = message.UnmarshalBinary(data); err != nil {
+			return GatewayMessage{}, err
+		}
+		return message, nil
+	}
+
 	buff := &bytes.Buffer{}
 	data, _ := io.ReadAll(io.TeeReader(r, buff))
 	g.config.Logger.Debug("received message from voice gateway", slog.String("data", string(data)))
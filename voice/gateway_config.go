@@ -20,6 +20,8 @@ type GatewayConfig struct {
 	Logger        *slog.Logger
 	Dialer        *websocket.Dialer
 	AutoReconnect bool
+	// MaxDAVEProtocolVersion is the highest DAVE protocol version sent in the identify. 0 means DAVE is not supported.
+	MaxDAVEProtocolVersion int
 }
 
 // GatewayConfigOpt is used to functionally configure a GatewayConfig.
@@ -52,3 +54,10 @@ func WithGatewayAutoReconnect(autoReconnect bool) GatewayConfigOpt {
 		config.AutoReconnect = autoReconnect
 	}
 }
+
+// WithGatewayMaxDAVEProtocolVersion sets the Gateway(s) used MaxDAVEProtocolVersion.
+func WithGatewayMaxDAVEProtocolVersion(version int) GatewayConfigOpt {
+	return func(config *GatewayConfig) {
+		config.MaxDAVEProtocolVersion = version
+	}
+}
@@ -1,21 +1,26 @@
 package voice
 
 import (
+	"encoding/binary"
+	"fmt"
+
 	"github.com/disgoorg/json"
 	"github.com/disgoorg/snowflake/v2"
 )
 
 // GatewayMessage represents a voice gateway message
 type GatewayMessage struct {
-	Op Opcode             `json:"op"`
-	D  GatewayMessageData `json:"d,omitempty"`
+	Op  Opcode             `json:"op"`
+	D   GatewayMessageData `json:"d,omitempty"`
+	Seq int                `json:"seq,omitempty"`
 }
 
 // UnmarshalJSON unmarshalls the GatewayMessage from json
 func (m *GatewayMessage) UnmarshalJSON(data []byte) error {
 	var v struct {
-		Op Opcode          `json:"op"`
-		D  json.RawMessage `json:"d"`
+		Op  Opcode          `json:"op"`
+		D   json.RawMessage `json:"d"`
+		Seq int             `json:"seq"`
 	}
 	if err := json.Unmarshal(data, &v); err != nil {
 		return err
@@ -75,6 +80,11 @@ func (m *GatewayMessage) UnmarshalJSON(data []byte) error {
 	case OpcodeResumed:
 		// no data
 
+	case OpcodeClientsConnect:
+		var d GatewayMessageDataClientsConnect
+		err = json.Unmarshal(v.D, &d)
+		messageData = d
+
 	case OpcodeClientDisconnect:
 		var d GatewayMessageDataClientDisconnect
 		err = json.Unmarshal(v.D, &d)
@@ -83,6 +93,31 @@ func (m *GatewayMessage) UnmarshalJSON(data []byte) error {
 	case OpcodeGuildSync:
 		// ignore this opcode
 
+	case OpcodeDAVEPrepareTransition:
+		var d GatewayMessageDataDAVEPrepareTransition
+		err = json.Unmarshal(v.D, &d)
+		messageData = d
+
+	case OpcodeDAVEExecuteTransition:
+		var d GatewayMessageDataDAVEExecuteTransition
+		err = json.Unmarshal(v.D, &d)
+		messageData = d
+
+	case OpcodeDAVETransitionReady:
+		var d GatewayMessageDataDAVETransitionReady
+		err = json.Unmarshal(v.D, &d)
+		messageData = d
+
+	case OpcodeDAVEPrepareEpoch:
+		var d GatewayMessageDataDAVEPrepareEpoch
+		err = json.Unmarshal(v.D, &d)
+		messageData = d
+
+	case OpcodeDAVEMLSInvalidCommitWelcome:
+		var d GatewayMessageDataDAVEMLSInvalidCommitWelcome
+		err = json.Unmarshal(v.D, &d)
+		messageData = d
+
 	default:
 		var d GatewayMessageDataUnknown
 		err = json.Unmarshal(v.D, &d)
@@ -93,6 +128,58 @@ func (m *GatewayMessage) UnmarshalJSON(data []byte) error {
 	}
 	m.Op = v.Op
 	m.D = messageData
+	m.Seq = v.Seq
+	return nil
+}
+
+// UnmarshalBinary unmarshalls a binary GatewayMessage which is used by the DAVE MLS opcodes.
+// Binary messages sent by the voice gateway start with a 2 byte sequence number followed by the 1 byte opcode.
+func (m *GatewayMessage) UnmarshalBinary(data []byte) error {
+	if len(data) < 3 {
+		return fmt.Errorf("binary voice gateway message too short: %d", len(data))
+	}
+	seq := int(binary.BigEndian.Uint16(data[0:2]))
+	op := Opcode(data[2])
+	payload := data[3:]
+
+	var messageData GatewayMessageData
+	switch op {
+	case OpcodeDAVEMLSExternalSenderPackage:
+		messageData = GatewayMessageDataDAVEMLSExternalSenderPackage(payload)
+
+	case OpcodeDAVEMLSProposals:
+		if len(payload) < 1 {
+			return fmt.Errorf("invalid dave mls proposals message")
+		}
+		messageData = GatewayMessageDataDAVEMLSProposals{
+			Operation: DAVEProposalsOperation(payload[0]),
+			Proposals: payload[1:],
+		}
+
+	case OpcodeDAVEMLSAnnounceCommitTransition:
+		if len(payload) < 2 {
+			return fmt.Errorf("invalid dave mls announce commit transition message")
+		}
+		messageData = GatewayMessageDataDAVEMLSAnnounceCommitTransition{
+			TransitionID: binary.BigEndian.Uint16(payload[0:2]),
+			Commit:       payload[2:],
+		}
+
+	case OpcodeDAVEMLSWelcome:
+		if len(payload) < 2 {
+			return fmt.Errorf("invalid dave mls welcome message")
+		}
+		messageData = GatewayMessageDataDAVEMLSWelcome{
+			TransitionID: binary.BigEndian.Uint16(payload[0:2]),
+			Welcome:      payload[2:],
+		}
+
+	default:
+		return fmt.Errorf("unknown binary voice gateway opcode: %d", op)
+	}
+	m.Op = op
+	m.D = messageData
+	m.Seq = seq
 	return nil
 }
 
@@ -102,19 +189,20 @@ type GatewayMessageData interface {
 }
 
 type GatewayMessageDataIdentify struct {
-	GuildID   snowflake.ID `json:"server_id"`
-	UserID    snowflake.ID `json:"user_id"`
-	SessionID string       `json:"session_id"`
-	Token     string       `json:"token"`
+	GuildID                snowflake.ID `json:"server_id"`
+	UserID                 snowflake.ID `json:"user_id"`
+	SessionID              string       `json:"session_id"`
+	Token                  string       `json:"token"`
+	MaxDAVEProtocolVersion int          `json:"max_dave_protocol_version"`
 }
 
 func (GatewayMessageDataIdentify) voiceGatewayMessageData() {}
 
 type GatewayMessageDataReady struct {
-	SSRC  uint32   `json:"ssrc"`
-	IP    string   `json:"ip"`
-	Port  int      `json:"port"`
-	Modes []string `json:"modes"`
+	SSRC  uint32           `json:"ssrc"`
+	IP    string           `json:"ip"`
+	Port  int              `json:"port"`
+	Modes []EncryptionMode `json:"modes"`
 }
 
 func (GatewayMessageDataReady) voiceGatewayMessageData() {}
@@ -125,13 +213,17 @@ type GatewayMessageDataHello struct {
 
 func (GatewayMessageDataHello) voiceGatewayMessageData() {}
 
-type GatewayMessageDataHeartbeat int64
+type GatewayMessageDataHeartbeat struct {
+	T      int64 `json:"t"`
+	SeqAck int   `json:"seq_ack"`
+}
 
 func (GatewayMessageDataHeartbeat) voiceGatewayMessageData() {}
 
 type GatewayMessageDataSessionDescription struct {
-	Mode      string   `json:"mode"`
-	SecretKey [32]byte `json:"secret_key"`
+	Mode                EncryptionMode `json:"mode"`
+	SecretKey           [32]byte       `json:"secret_key"`
+	DAVEProtocolVersion int            `json:"dave_protocol_version"`
 }
 
 func (GatewayMessageDataSessionDescription) voiceGatewayMessageData() {}
@@ -160,9 +252,15 @@ type EncryptionMode string
 
 // All possible EncryptionMode(s) https://discord.com/developers/docs/topics/voice-connections#establishing-a-voice-udp-connection-encryption-modes.
 const (
+	EncryptionModeAEADAES256GCMRTPSize         EncryptionMode = "aead_aes256_gcm_rtpsize"
+	EncryptionModeAEADXChaCha20Poly1305RTPSize EncryptionMode = "aead_xchacha20_poly1305_rtpsize"
+
+	// Deprecated: Discord is removing this mode, use EncryptionModeAEADAES256GCMRTPSize or EncryptionModeAEADXChaCha20Poly1305RTPSize instead.
 	EncryptionModeNormal EncryptionMode = "xsalsa20_poly1305"
+	// Deprecated: Discord is removing this mode, use EncryptionModeAEADAES256GCMRTPSize or EncryptionModeAEADXChaCha20Poly1305RTPSize instead.
 	EncryptionModeSuffix EncryptionMode = "xsalsa20_poly1305_suffix"
-	EncryptionModeLite   EncryptionMode = "xsalsa20_poly1305_lite"
+	// Deprecated: Discord is removing this mode, use EncryptionModeAEADAES256GCMRTPSize or EncryptionModeAEADXChaCha20Poly1305RTPSize instead.
+	EncryptionModeLite EncryptionMode = "xsalsa20_poly1305_lite"
 )
 
 type GatewayMessageDataSpeaking struct {
@@ -187,11 +285,14 @@ type GatewayMessageDataResume struct {
 	GuildID   snowflake.ID `json:"server_id"` // wtf is this?
 	SessionID string       `json:"session_id"`
 	Token     string       `json:"token"`
+	SeqAck    int          `json:"seq_ack"`
 }
 
 func (GatewayMessageDataResume) voiceGatewayMessageData() {}
 
-type GatewayMessageDataHeartbeatACK int64
+type GatewayMessageDataHeartbeatACK struct {
+	T int64 `json:"t"`
+}
 
 func (GatewayMessageDataHeartbeatACK) voiceGatewayMessageData() {}
 
@@ -203,6 +304,12 @@ type GatewayMessageDataClientConnect struct {
 
 func (GatewayMessageDataClientConnect) voiceGatewayMessageData() {}
 
+type GatewayMessageDataClientsConnect struct {
+	UserIDs []snowflake.ID `json:"user_ids"`
+}
+
+func (GatewayMessageDataClientsConnect) voiceGatewayMessageData() {}
+
 type GatewayMessageDataClientDisconnect struct {
 	UserID snowflake.ID `json:"user_id"`
 }
@@ -220,3 +327,77 @@ func (m GatewayMessageDataUnknown) MarshalJSON() ([]byte, error) {
 func (m *GatewayMessageDataUnknown) UnmarshalJSON(data []byte) error {
 	return (*json.RawMessage)(m).UnmarshalJSON(data)
 }
+
+// GatewayMessageDataDAVEPrepareTransition announces an upcoming downgrade from or upgrade to the DAVE protocol.
+type GatewayMessageDataDAVEPrepareTransition struct {
+	ProtocolVersion int    `json:"protocol_version"`
+	TransitionID    uint16 `json:"transition_id"`
+}
+
+func (GatewayMessageDataDAVEPrepareTransition) voiceGatewayMessageData() {}
+
+// GatewayMessageDataDAVEExecuteTransition executes a previously announced transition.
+type GatewayMessageDataDAVEExecuteTransition struct {
+	TransitionID uint16 `json:"transition_id"`
+}
+
+func (GatewayMessageDataDAVEExecuteTransition) voiceGatewayMessageData() {}
+
+// GatewayMessageDataDAVETransitionReady is sent when the client is ready to execute a transition.
+type GatewayMessageDataDAVETransitionReady struct {
+	TransitionID uint16 `json:"transition_id"`
+}
+
+func (GatewayMessageDataDAVETransitionReady) voiceGatewayMessageData() {}
+
+// GatewayMessageDataDAVEPrepareEpoch announces an upcoming MLS epoch. Epoch 1 means a new MLS group is created.
+type GatewayMessageDataDAVEPrepareEpoch struct {
+	ProtocolVersion int `json:"protocol_version"`
+	Epoch           int `json:"epoch"`
+}
+
+func (GatewayMessageDataDAVEPrepareEpoch) voiceGatewayMessageData() {}
+
+// GatewayMessageDataDAVEMLSInvalidCommitWelcome is sent when a commit or welcome could not be processed.
+type GatewayMessageDataDAVEMLSInvalidCommitWelcome struct {
+	TransitionID uint16 `json:"transition_id"`
+}
+
+func (GatewayMessageDataDAVEMLSInvalidCommitWelcome) voiceGatewayMessageData() {}
+
+// GatewayMessageDataDAVEMLSExternalSenderPackage is the MLS external sender (credential & public key) of the voice gateway.
+type GatewayMessageDataDAVEMLSExternalSenderPackage []byte
+
+func (GatewayMessageDataDAVEMLSExternalSenderPackage) voiceGatewayMessageData() {}
+
+// DAVEProposalsOperation is the operation of GatewayMessageDataDAVEMLSProposals
+type DAVEProposalsOperation uint8
+
+const (
+	DAVEProposalsOperationAppend DAVEProposalsOperation = iota
+	DAVEProposalsOperationRevoke
+)
+
+// GatewayMessageDataDAVEMLSProposals contains MLS proposals to append or the refs of proposals to revoke.
+type GatewayMessageDataDAVEMLSProposals struct {
+	Operation DAVEProposalsOperation
+	Proposals []byte
+}
+
+func (GatewayMessageDataDAVEMLSProposals) voiceGatewayMessageData() {}
+
+// GatewayMessageDataDAVEMLSAnnounceCommitTransition contains the MLS commit of the next transition.
+type GatewayMessageDataDAVEMLSAnnounceCommitTransition struct {
+	TransitionID uint16
+	Commit       []byte
+}
+
+func (GatewayMessageDataDAVEMLSAnnounceCommitTransition) voiceGatewayMessageData() {}
+
+// GatewayMessageDataDAVEMLSWelcome contains the MLS welcome to join the group of the next transition.
+type GatewayMessageDataDAVEMLSWelcome struct {
+	TransitionID uint16
+	Welcome      []byte
+}
+
+func (GatewayMessageDataDAVEMLSWelcome) voiceGatewayMessageData() {}
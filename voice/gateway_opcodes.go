@@ -14,12 +14,27 @@ const (
 	OpcodeHello
 	OpcodeResumed
 	_
-	_
+	OpcodeClientsConnect
 	_
 	OpcodeClientDisconnect
 	OpcodeGuildSync
 )
 
+// DAVE opcodes (https://daveprotocol.com/#voice-gateway-opcodes). Opcodes 25 to 30 are sent as binary messages.
+const (
+	OpcodeDAVEPrepareTransition Opcode = iota + 21
+	OpcodeDAVEExecuteTransition
+	OpcodeDAVETransitionReady
+	OpcodeDAVEPrepareEpoch
+	OpcodeDAVEMLSExternalSenderPackage
+	OpcodeDAVEMLSKeyPackage
+	OpcodeDAVEMLSProposals
+	OpcodeDAVEMLSCommitWelcome
+	OpcodeDAVEMLSAnnounceCommitTransition
+	OpcodeDAVEMLSWelcome
+	OpcodeDAVEMLSInvalidCommitWelcome
+)
+
 type GatewayCloseEventCode struct {
 	Code        int
 	Description string
@@ -126,6 +141,13 @@ var (
 		Reconnect:   false,
 	}
 
+	GatewayCloseEventCodeE2EERequired = GatewayCloseEventCode{
+		Code:        4017,
+		Description: "E2EE/DAVE protocol required",
+		Explanation: "This channel requires a DAVE protocol capable client.",
+		Reconnect:   false,
+	}
+
 	GatewayCloseEventCodeUnknown = GatewayCloseEventCode{
 		Code:        0,
 		Description: "Unknown",
@@ -148,6 +170,7 @@ var (
 		GatewayCloseEventCodeDisconnected.Code:          GatewayCloseEventCodeDisconnected,
 		GatewayCloseEventCodeVoiceServerCrash.Code:      GatewayCloseEventCodeVoiceServerCrash,
 		GatewayCloseEventCodeUnknownEncryptionMode.Code: GatewayCloseEventCodeUnknownEncryptionMode,
+		GatewayCloseEventCodeE2EERequired.Code:          GatewayCloseEventCodeE2EERequired,
 	}
 )
 
@@ -0,0 +1,193 @@
+package voice
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// ErrMLSMessageInvalid is returned when an MLS message of the DAVE protocol can't be decoded.
+var ErrMLSMessageInvalid = errors.New("invalid mls message")
+
+// mlsWriter encodes structs of the TLS presentation language used by MLS (https://www.rfc-editor.org/rfc/rfc9420.html#section-2.1).
+type mlsWriter struct {
+	b []byte
+}
+
+func (w *mlsWriter) u8(v uint8) {
+	w.b = append(w.b, v)
+}
+
+func (w *mlsWriter) u16(v uint16) {
+	w.b = binary.BigEndian.AppendUint16(w.b, v)
+}
+
+func (w *mlsWriter) u32(v uint32) {
+	w.b = binary.BigEndian.AppendUint32(w.b, v)
+}
+
+func (w *mlsWriter) u64(v uint64) {
+	w.b = binary.BigEndian.AppendUint64(w.b, v)
+}
+
+func (w *mlsWriter) raw(b []byte) {
+	w.b = append(w.b, b...)
+}
+
+// opaque writes a variable length vector of bytes.
+func (w *mlsWriter) opaque(b []byte) {
+	w.b = appendMLSVarint(w.b, uint64(len(b)))
+	w.b = append(w.b, b...)
+}
+
+// vector writes a variable length vector whose elements are written by f.
+func (w *mlsWriter) vector(f func(w *mlsWriter)) {
+	inner := &mlsWriter{}
+	f(inner)
+	w.opaque(inner.b)
+}
+
+// optional writes the presence flag of an optional value & the value written by f if present.
+func (w *mlsWriter) optional(present bool, f func(w *mlsWriter)) {
+	if !present {
+		w.u8(0)
+		return
+	}
+	w.u8(1)
+	f(w)
+}
+
+// mlsReader decodes structs of the TLS presentation language used by MLS. The first error is kept & all further reads return zero values.
+type mlsReader struct {
+	b   []byte
+	err error
+}
+
+func newMLSReader(b []byte) *mlsReader {
+	return &mlsReader{b: b}
+}
+
+func (r *mlsReader) next(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if n < 0 || len(r.b) < n {
+		r.err = ErrMLSMessageInvalid
+		return nil
+	}
+	b := r.b[:n:n]
+	r.b = r.b[n:]
+	return b
+}
+
+func (r *mlsReader) u8() uint8 {
+	if b := r.next(1); b != nil {
+		return b[0]
+	}
+	return 0
+}
+
+func (r *mlsReader) u16() uint16 {
+	if b := r.next(2); b != nil {
+		return binary.BigEndian.Uint16(b)
+	}
+	return 0
+}
+
+func (r *mlsReader) u32() uint32 {
+	if b := r.next(4); b != nil {
+		return binary.BigEndian.Uint32(b)
+	}
+	return 0
+}
+
+func (r *mlsReader) u64() uint64 {
+	if b := r.next(8); b != nil {
+		return binary.BigEndian.Uint64(b)
+	}
+	return 0
+}
+
+// varint reads the variable length integer used as length prefix of vectors. Only the minimal encoding is accepted.
+func (r *mlsReader) varint() int {
+	if r.err != nil {
+		return 0
+	}
+	if len(r.b) == 0 {
+		r.err = ErrMLSMessageInvalid
+		return 0
+	}
+	var v uint64
+	switch r.b[0] >> 6 {
+	case 0:
+		v = uint64(r.u8())
+	case 1:
+		v = uint64(r.u16() & 0x3FFF)
+		if v < 1<<6 {
+			r.err = ErrMLSMessageInvalid
+		}
+	case 2:
+		v = uint64(r.u32() & 0x3FFFFFFF)
+		if v < 1<<14 {
+			r.err = ErrMLSMessageInvalid
+		}
+	default:
+		r.err = ErrMLSMessageInvalid
+	}
+	return int(v)
+}
+
+// opaque reads a variable length vector of bytes.
+func (r *mlsReader) opaque() []byte {
+	b := r.next(r.varint())
+	if b == nil {
+		return nil
+	}
+	return append([]byte{}, b...)
+}
+
+// vector reads a variable length vector & calls f until all of its elements were read.
+func (r *mlsReader) vector(f func(r *mlsReader)) {
+	inner := newMLSReader(r.next(r.varint()))
+	if r.err != nil {
+		return
+	}
+	for len(inner.b) > 0 && inner.err == nil {
+		f(inner)
+	}
+	if inner.err != nil {
+		r.err = inner.err
+	}
+}
+
+// optional reads the presence flag of an optional value & calls f if it's present.
+func (r *mlsReader) optional(f func(r *mlsReader)) {
+	switch r.u8() {
+	case 0:
+	case 1:
+		f(r)
+	default:
+		r.fail()
+	}
+}
+
+func (r *mlsReader) u16s() []uint16 {
+	var v []uint16
+	r.vector(func(r *mlsReader) {
+		v = append(v, r.u16())
+	})
+	return v
+}
+
+func (r *mlsReader) fail() {
+	if r.err == nil {
+		r.err = ErrMLSMessageInvalid
+	}
+}
+
+// end returns the error of the reader or ErrMLSMessageInvalid if there are trailing bytes.
+func (r *mlsReader) end() error {
+	if r.err == nil && len(r.b) > 0 {
+		return ErrMLSMessageInvalid
+	}
+	return r.err
+}
@@ -0,0 +1,281 @@
+package voice
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math/big"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+// The MLS cipher suite MLS_128_DHKEMP256_AES128GCM_SHA256_P256 used by DAVE.
+// HPKE uses DHKEM(P-256, HKDF-SHA256), HKDF-SHA256 & AES-128-GCM (https://www.rfc-editor.org/rfc/rfc9180.html) & signatures use ECDSA P-256 with SHA-256.
+const (
+	mlsCipherSuite  uint16 = 0x0002
+	mlsHashSize            = sha256.Size
+	mlsAEADKeySize         = 16
+	mlsAEADNonceLen        = 12
+
+	hpkeKEMID  uint16 = 0x0010
+	hpkeKDFID  uint16 = 0x0001
+	hpkeAEADID uint16 = 0x0001
+)
+
+var (
+	errMLSDecryptionFailed = errors.New("mls decryption failed")
+	errMLSInvalidSignature = errors.New("invalid mls signature")
+	errMLSInvalidPublicKey = errors.New("invalid mls public key")
+)
+
+func mlsHash(b []byte) []byte {
+	h := sha256.Sum256(b)
+	return h[:]
+}
+
+func mlsExtract(salt []byte, ikm []byte) []byte {
+	return hkdf.Extract(sha256.New, ikm, salt)
+}
+
+func mlsExpand(prk []byte, info []byte, length int) []byte {
+	out := make([]byte, length)
+	// HKDF-SHA256 only fails for more than 255 blocks, which are never requested
+	_, _ = io.ReadFull(hkdf.Expand(sha256.New, prk, info), out)
+	return out
+}
+
+func mlsMAC(key []byte, data []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+// mlsDeriveSecret implements DeriveSecret of RFC 9420.
+func mlsDeriveSecret(secret []byte, label string) []byte {
+	derived, _ := expandWithLabel(secret, label, nil, mlsHashSize)
+	return derived
+}
+
+// mlsRefHash implements RefHash of RFC 9420 (https://www.rfc-editor.org/rfc/rfc9420.html#section-5.2).
+func mlsRefHash(label string, value []byte) []byte {
+	w := &mlsWriter{}
+	w.opaque([]byte(label))
+	w.opaque(value)
+	return mlsHash(w.b)
+}
+
+func mlsLabeledContent(label string, content []byte) []byte {
+	w := &mlsWriter{}
+	w.opaque([]byte(daveExpandWithLabelVersion + label))
+	w.opaque(content)
+	return w.b
+}
+
+// mlsSignWithLabel implements SignWithLabel of RFC 9420 (https://www.rfc-editor.org/rfc/rfc9420.html#section-5.1.2) with DER encoded ECDSA signatures.
+func mlsSignWithLabel(key *ecdsa.PrivateKey, label string, content []byte) ([]byte, error) {
+	digest := sha256.Sum256(mlsLabeledContent(label, content))
+	return ecdsa.SignASN1(rand.Reader, key, digest[:])
+}
+
+// mlsVerifyWithLabel implements VerifyWithLabel of RFC 9420.
+func mlsVerifyWithLabel(publicKey []byte, label string, content []byte, signature []byte) error {
+	key, err := parseMLSSignaturePublicKey(publicKey)
+	if err != nil {
+		return err
+	}
+	digest := sha256.Sum256(mlsLabeledContent(label, content))
+	if !ecdsa.VerifyASN1(key, digest[:], signature) {
+		return errMLSInvalidSignature
+	}
+	return nil
+}
+
+func newMLSSignatureKey() (*ecdsa.PrivateKey, error) {
+	return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+}
+
+// mlsSignaturePublicKey returns the uncompressed point of the ECDSA public key.
+func mlsSignaturePublicKey(key *ecdsa.PrivateKey) []byte {
+	publicKey, err := key.PublicKey.ECDH()
+	if err != nil {
+		return nil
+	}
+	return publicKey.Bytes()
+}
+
+func parseMLSSignaturePublicKey(b []byte) (*ecdsa.PublicKey, error) {
+	// validates that the point is on the curve
+	if _, err := ecdh.P256().NewPublicKey(b); err != nil {
+		return nil, errMLSInvalidPublicKey
+	}
+	return &ecdsa.PublicKey{
+		Curve: elliptic.P256(),
+		X:     new(big.Int).SetBytes(b[1:33]),
+		Y:     new(big.Int).SetBytes(b[33:65]),
+	}, nil
+}
+
+// mlsEncryptWithLabel implements EncryptWithLabel of RFC 9420 (https://www.rfc-editor.org/rfc/rfc9420.html#section-5.1.3).
+func mlsEncryptWithLabel(publicKey []byte, label string, context []byte, plaintext []byte) (kemOutput []byte, ciphertext []byte, err error) {
+	ikm := make([]byte, mlsHashSize)
+	if _, err = rand.Read(ikm); err != nil {
+		return nil, nil, err
+	}
+	kemOutput, ctx, err := hpkeSetupBaseS(publicKey, mlsLabeledContent(label, context), ikm)
+	if err != nil {
+		return nil, nil, err
+	}
+	return kemOutput, ctx.seal(nil, plaintext), nil
+}
+
+// mlsDecryptWithLabel implements DecryptWithLabel of RFC 9420.
+func mlsDecryptWithLabel(privateKey *ecdh.PrivateKey, label string, context []byte, kemOutput []byte, ciphertext []byte) ([]byte, error) {
+	ctx, err := hpkeSetupBaseR(kemOutput, privateKey, mlsLabeledContent(label, context))
+	if err != nil {
+		return nil, err
+	}
+	return ctx.open(nil, ciphertext)
+}
+
+func hpkeKEMSuiteID() []byte {
+	return binary.BigEndian.AppendUint16([]byte("KEM"), hpkeKEMID)
+}
+
+func hpkeSuiteID() []byte {
+	suiteID := binary.BigEndian.AppendUint16([]byte("HPKE"), hpkeKEMID)
+	suiteID = binary.BigEndian.AppendUint16(suiteID, hpkeKDFID)
+	return binary.BigEndian.AppendUint16(suiteID, hpkeAEADID)
+}
+
+func hpkeLabeledExtract(suiteID []byte, salt []byte, label string, ikm []byte) []byte {
+	labeledIKM := append([]byte("HPKE-v1"), suiteID...)
+	labeledIKM = append(labeledIKM, label...)
+	labeledIKM = append(labeledIKM, ikm...)
+	return mlsExtract(salt, labeledIKM)
+}
+
+func hpkeLabeledExpand(suiteID []byte, prk []byte, label string, info []byte, length int) []byte {
+	labeledInfo := binary.BigEndian.AppendUint16(nil, uint16(length))
+	labeledInfo = append(labeledInfo, "HPKE-v1"...)
+	labeledInfo = append(labeledInfo, suiteID...)
+	labeledInfo = append(labeledInfo, label...)
+	labeledInfo = append(labeledInfo, info...)
+	return mlsExpand(prk, labeledInfo, length)
+}
+
+// hpkeDeriveKeyPair implements DeriveKeyPair of DHKEM(P-256, HKDF-SHA256) (https://www.rfc-editor.org/rfc/rfc9180.html#section-7.1.3).
+func hpkeDeriveKeyPair(ikm []byte) (*ecdh.PrivateKey, error) {
+	suiteID := hpkeKEMSuiteID()
+	prk := hpkeLabeledExtract(suiteID, nil, "dkp_prk", ikm)
+	for counter := 0; counter < 256; counter++ {
+		candidate := hpkeLabeledExpand(suiteID, prk, "candidate", []byte{byte(counter)}, 32)
+		// NewPrivateKey rejects zero & values not smaller than the order of the curve
+		if key, err := ecdh.P256().NewPrivateKey(candidate); err == nil {
+			return key, nil
+		}
+	}
+	return nil, errors.New("failed to derive hpke key pair")
+}
+
+func hpkeExtractAndExpand(dh []byte, kemContext []byte) []byte {
+	suiteID := hpkeKEMSuiteID()
+	prk := hpkeLabeledExtract(suiteID, nil, "eae_prk", dh)
+	return hpkeLabeledExpand(suiteID, prk, "shared_secret", kemContext, mlsHashSize)
+}
+
+// hpkeSetupBaseS sets up the base mode HPKE context of the sender. The ephemeral key pair is derived from ikmE.
+func hpkeSetupBaseS(publicKey []byte, info []byte, ikmE []byte) ([]byte, *hpkeContext, error) {
+	pkR, err := ecdh.P256().NewPublicKey(publicKey)
+	if err != nil {
+		return nil, nil, errMLSInvalidPublicKey
+	}
+	skE, err := hpkeDeriveKeyPair(ikmE)
+	if err != nil {
+		return nil, nil, err
+	}
+	dh, err := skE.ECDH(pkR)
+	if err != nil {
+		return nil, nil, err
+	}
+	enc := skE.PublicKey().Bytes()
+	sharedSecret := hpkeExtractAndExpand(dh, append(append([]byte{}, enc...), publicKey...))
+	ctx, err := newHPKEContext(sharedSecret, info)
+	return enc, ctx, err
+}
+
+// hpkeSetupBaseR sets up the base mode HPKE context of the recipient.
+func hpkeSetupBaseR(enc []byte, privateKey *ecdh.PrivateKey, info []byte) (*hpkeContext, error) {
+	pkE, err := ecdh.P256().NewPublicKey(enc)
+	if err != nil {
+		return nil, errMLSInvalidPublicKey
+	}
+	dh, err := privateKey.ECDH(pkE)
+	if err != nil {
+		return nil, err
+	}
+	sharedSecret := hpkeExtractAndExpand(dh, append(append([]byte{}, enc...), privateKey.PublicKey().Bytes()...))
+	return newHPKEContext(sharedSecret, info)
+}
+
+type hpkeContext struct {
+	aead      cipher.AEAD
+	baseNonce []byte
+	seq       uint64
+}
+
+func newHPKEContext(sharedSecret []byte, info []byte) (*hpkeContext, error) {
+	suiteID := hpkeSuiteID()
+	keyScheduleContext := []byte{0x00} // mode_base
+	keyScheduleContext = append(keyScheduleContext, hpkeLabeledExtract(suiteID, nil, "psk_id_hash", nil)...)
+	keyScheduleContext = append(keyScheduleContext, hpkeLabeledExtract(suiteID, nil, "info_hash", info)...)
+
+	secret := hpkeLabeledExtract(suiteID, sharedSecret, "secret", nil)
+	aead, err := newMLSAEAD(hpkeLabeledExpand(suiteID, secret, "key", keyScheduleContext, mlsAEADKeySize))
+	if err != nil {
+		return nil, err
+	}
+	return &hpkeContext{
+		aead:      aead,
+		baseNonce: hpkeLabeledExpand(suiteID, secret, "base_nonce", keyScheduleContext, mlsAEADNonceLen),
+	}, nil
+}
+
+func (c *hpkeContext) nonce() []byte {
+	nonce := make([]byte, mlsAEADNonceLen)
+	binary.BigEndian.PutUint64(nonce[mlsAEADNonceLen-8:], c.seq)
+	for i := range nonce {
+		nonce[i] ^= c.baseNonce[i]
+	}
+	return nonce
+}
+
+func (c *hpkeContext) seal(aad []byte, plaintext []byte) []byte {
+	ciphertext := c.aead.Seal(nil, c.nonce(), plaintext, aad)
+	c.seq++
+	return ciphertext
+}
+
+func (c *hpkeContext) open(aad []byte, ciphertext []byte) ([]byte, error) {
+	plaintext, err := c.aead.Open(nil, c.nonce(), ciphertext, aad)
+	if err != nil {
+		return nil, errMLSDecryptionFailed
+	}
+	c.seq++
+	return plaintext, nil
+}
+
+func newMLSAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
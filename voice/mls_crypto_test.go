@@ -0,0 +1,149 @@
+package voice
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/sha3"
+)
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+// TestHPKE_KnownAnswer checks the HPKE of the MLS cipher suite against the base mode DHKEM(P-256, HKDF-SHA256), HKDF-SHA256, AES-128-GCM
+// test vector of RFC 9180 (https://www.rfc-editor.org/rfc/rfc9180.html#appendix-A.3.1).
+// The 1000 encryptions are accumulated like in Go's crypto/hpke tests (src/crypto/hpke/testdata/rfc9180.json):
+// aad & plaintext are drawn from a SHAKE128 stream as length prefixed values & the ciphertexts are hashed with SHAKE128.
+func TestHPKE_KnownAnswer(t *testing.T) {
+	info := mustDecodeHex(t, "4f6465206f6e2061204772656369616e2055726e")
+	ikmE := mustDecodeHex(t, "4270e54ffd08d79d5928020af4686d8f6b7d35dbe470265f1f5aa22816ce860e")
+	ikmR := mustDecodeHex(t, "668b37171f1072f3cf12ea8a236a45df23fc13b82af3609ad1e354f6ef817550")
+	skRm := mustDecodeHex(t, "f3ce7fdae57e1a310d87f1ebbde6f328be0a99cdbcadf4d6589cf29de4b8ffd2")
+	pkRm := mustDecodeHex(t, "04fe8c19ce0905191ebc298a9245792531f26f0cece2460639e8bc39cb7f706a826a779b4cf969b8a0e539c7f62fb3d30ad6aa8f80e30f1d128aafd68a2ce72ea0")
+	enc := mustDecodeHex(t, "04a92719c6195d5085104f469a8b9814d5838ff72b60501e2c4466e5e67b325ac98536d7b61a1af4b78e5b7f951c0900be863c403ce65c9bfcb9382657222d18c4")
+	encryptionsAccumulated := mustDecodeHex(t, "fcb852ae6a1e19e874fbd18a199df3e4")
+
+	skR, err := hpkeDeriveKeyPair(ikmR)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, skRm, skR.Bytes())
+	assert.Equal(t, pkRm, skR.PublicKey().Bytes())
+
+	encap, sender, err := hpkeSetupBaseS(pkRm, info, ikmE)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, enc, encap)
+
+	recipientKey, err := ecdh.P256().NewPrivateKey(skRm)
+	if !assert.NoError(t, err) {
+		return
+	}
+	recipient, err := hpkeSetupBaseR(encap, recipientKey, info)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	source, sink := sha3.NewShake128(), sha3.NewShake128()
+	draw := func() []byte {
+		l := make([]byte, 1)
+		_, _ = source.Read(l)
+		b := make([]byte, l[0])
+		_, _ = source.Read(b)
+		return b
+	}
+	for i := 0; i < 1000; i++ {
+		aad, plaintext := draw(), draw()
+		ciphertext := sender.seal(aad, plaintext)
+		_, _ = sink.Write(ciphertext)
+
+		decrypted, err := recipient.open(aad, ciphertext)
+		if !assert.NoError(t, err) || !assert.True(t, bytes.Equal(plaintext, decrypted)) {
+			return
+		}
+	}
+	encryptions := make([]byte, 16)
+	_, _ = sink.Read(encryptions)
+	assert.Equal(t, encryptionsAccumulated, encryptions)
+}
+
+func TestMLSEncryptWithLabel(t *testing.T) {
+	key, err := hpkeDeriveKeyPair([]byte("ikm"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	kemOutput, ciphertext, err := mlsEncryptWithLabel(key.PublicKey().Bytes(), "Welcome", []byte("context"), []byte("secret"))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	plaintext, err := mlsDecryptWithLabel(key, "Welcome", []byte("context"), kemOutput, ciphertext)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("secret"), plaintext)
+
+	_, err = mlsDecryptWithLabel(key, "Welcome", []byte("other context"), kemOutput, ciphertext)
+	assert.ErrorIs(t, err, errMLSDecryptionFailed)
+}
+
+func TestMLSSignWithLabel(t *testing.T) {
+	key, err := newMLSSignatureKey()
+	if !assert.NoError(t, err) {
+		return
+	}
+	signature, err := mlsSignWithLabel(key, "LeafNodeTBS", []byte("content"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	publicKey := mlsSignaturePublicKey(key)
+	assert.Len(t, publicKey, 65)
+	assert.NoError(t, mlsVerifyWithLabel(publicKey, "LeafNodeTBS", []byte("content"), signature))
+	assert.ErrorIs(t, mlsVerifyWithLabel(publicKey, "KeyPackageTBS", []byte("content"), signature), errMLSInvalidSignature)
+	assert.ErrorIs(t, mlsVerifyWithLabel([]byte{0x04}, "LeafNodeTBS", []byte("content"), signature), errMLSInvalidPublicKey)
+}
+
+func TestMLSReader(t *testing.T) {
+	w := &mlsWriter{}
+	w.u16(1)
+	w.opaque(bytes.Repeat([]byte{1}, 100))
+	w.vector(func(w *mlsWriter) {
+		w.u16(2)
+		w.u16(3)
+	})
+	w.optional(true, func(w *mlsWriter) { w.u32(4) })
+	w.optional(false, nil)
+
+	r := newMLSReader(w.b)
+	assert.Equal(t, uint16(1), r.u16())
+	assert.Len(t, r.opaque(), 100)
+	assert.Equal(t, []uint16{2, 3}, r.u16s())
+	var v uint32
+	r.optional(func(r *mlsReader) { v = r.u32() })
+	assert.Equal(t, uint32(4), v)
+	r.optional(func(r *mlsReader) { t.Error("absent optional read") })
+	assert.NoError(t, r.end())
+
+	// non minimal varint
+	r = newMLSReader([]byte{0x40, 0x01, 0x00})
+	r.opaque()
+	assert.ErrorIs(t, r.end(), ErrMLSMessageInvalid)
+
+	// truncated vector
+	r = newMLSReader([]byte{0x05, 0x00})
+	r.opaque()
+	assert.ErrorIs(t, r.end(), ErrMLSMessageInvalid)
+
+	// trailing bytes
+	r = newMLSReader([]byte{0x00, 0x00})
+	r.u8()
+	assert.ErrorIs(t, r.end(), ErrMLSMessageInvalid)
+}
@@ -0,0 +1,512 @@
+package voice
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/rand"
+	"fmt"
+	"slices"
+)
+
+type mlsEpochSecrets struct {
+	init         []byte
+	exporter     []byte
+	confirmation []byte
+	membership   []byte
+}
+
+func newMLSEpochSecrets(epochSecret []byte) mlsEpochSecrets {
+	return mlsEpochSecrets{
+		init:         mlsDeriveSecret(epochSecret, "init"),
+		exporter:     mlsDeriveSecret(epochSecret, "exporter"),
+		confirmation: mlsDeriveSecret(epochSecret, "confirm"),
+		membership:   mlsDeriveSecret(epochSecret, "membership"),
+	}
+}
+
+func mlsExpandWithLabel(secret []byte, label string, context []byte) []byte {
+	expanded, _ := expandWithLabel(secret, label, context, mlsHashSize)
+	return expanded
+}
+
+// mlsKeySchedule derives the secrets of the next epoch (https://www.rfc-editor.org/rfc/rfc9420.html#section-8). Pre-shared keys are not used by DAVE.
+func mlsKeySchedule(initSecret []byte, commitSecret []byte, groupContext []byte) (joinerSecret []byte, welcomeSecret []byte, secrets mlsEpochSecrets) {
+	joinerSecret = mlsExpandWithLabel(mlsExtract(initSecret, commitSecret), "joiner", groupContext)
+	memberSecret := mlsExtract(joinerSecret, make([]byte, mlsHashSize))
+	welcomeSecret = mlsDeriveSecret(memberSecret, "welcome")
+	secrets = newMLSEpochSecrets(mlsExpandWithLabel(memberSecret, "epoch", groupContext))
+	return
+}
+
+func mlsInterimTranscriptHash(confirmedTranscriptHash []byte, confirmationTag []byte) []byte {
+	w := &mlsWriter{b: bytes.Clone(confirmedTranscriptHash)}
+	w.opaque(confirmationTag)
+	return mlsHash(w.b)
+}
+
+func mlsWelcomeAEAD(welcomeSecret []byte) ([]byte, func() ([]byte, error)) {
+	nonce, _ := expandWithLabel(welcomeSecret, "nonce", nil, mlsAEADNonceLen)
+	return nonce, func() ([]byte, error) {
+		return expandWithLabel(welcomeSecret, "key", nil, mlsAEADKeySize)
+	}
+}
+
+func sealMLSWelcome(welcomeSecret []byte, groupInfo []byte) ([]byte, error) {
+	nonce, key := mlsWelcomeAEAD(welcomeSecret)
+	k, err := key()
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newMLSAEAD(k)
+	if err != nil {
+		return nil, err
+	}
+	return aead.Seal(nil, nonce, groupInfo, nil), nil
+}
+
+func openMLSWelcome(welcomeSecret []byte, encryptedGroupInfo []byte) ([]byte, error) {
+	nonce, key := mlsWelcomeAEAD(welcomeSecret)
+	k, err := key()
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newMLSAEAD(k)
+	if err != nil {
+		return nil, err
+	}
+	groupInfo, err := aead.Open(nil, nonce, encryptedGroupInfo, nil)
+	if err != nil {
+		return nil, errMLSDecryptionFailed
+	}
+	return groupInfo, nil
+}
+
+// derivePathKeys derives the path secrets & key pairs of the path starting with the given path secret.
+// The public keys must match the given keys. The private keys are stored in privateKeys & the commit secret is returned.
+func derivePathKeys(pathSecret []byte, path []mlsPathNode, keys [][]byte, privateKeys map[uint32]*ecdh.PrivateKey) ([]byte, error) {
+	for i, node := range path {
+		if i > 0 {
+			pathSecret = mlsDeriveSecret(pathSecret, "path")
+		}
+		key, err := hpkeDeriveKeyPair(mlsDeriveSecret(pathSecret, "node"))
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(key.PublicKey().Bytes(), keys[i]) {
+			return nil, fmt.Errorf("%w: path secret does not match public key", errMLSInvalidCommit)
+		}
+		privateKeys[node.node] = key
+	}
+	return mlsDeriveSecret(pathSecret, "path"), nil
+}
+
+// mlsGroup is the state of a MLS group in an epoch.
+type mlsGroup struct {
+	groupID                 []byte
+	epoch                   uint64
+	tree                    *mlsTree
+	treeHash                []byte
+	ownLeaf                 uint32
+	extensions              []mlsExtension
+	confirmedTranscriptHash []byte
+	interimTranscriptHash   []byte
+	secrets                 mlsEpochSecrets
+	// privateKeys are the HPKE private keys of the own leaf & its direct path by node index
+	privateKeys  map[uint32]*ecdh.PrivateKey
+	signatureKey *ecdsa.PrivateKey
+}
+
+// newMLSGroup creates a group with only the own leaf (https://www.rfc-editor.org/rfc/rfc9420.html#section-11).
+func newMLSGroup(groupID []byte, signatureKey *ecdsa.PrivateKey, leaf *mlsLeafNode, leafKey *ecdh.PrivateKey, extensions []mlsExtension) (*mlsGroup, error) {
+	epochSecret := make([]byte, mlsHashSize)
+	if _, err := rand.Read(epochSecret); err != nil {
+		return nil, err
+	}
+	tree := &mlsTree{nodes: []mlsNode{{leaf: leaf}}}
+	secrets := newMLSEpochSecrets(epochSecret)
+	return &mlsGroup{
+		groupID:               groupID,
+		tree:                  tree,
+		treeHash:              tree.rootTreeHash(),
+		extensions:            extensions,
+		interimTranscriptHash: mlsInterimTranscriptHash(nil, mlsMAC(secrets.confirmation, nil)),
+		secrets:               secrets,
+		privateKeys:           map[uint32]*ecdh.PrivateKey{0: leafKey},
+		signatureKey:          signatureKey,
+	}, nil
+}
+
+func (g *mlsGroup) context() *mlsGroupContext {
+	return &mlsGroupContext{
+		groupID:                 g.groupID,
+		epoch:                   g.epoch,
+		treeHash:                g.treeHash,
+		confirmedTranscriptHash: g.confirmedTranscriptHash,
+		extensions:              g.extensions,
+	}
+}
+
+// export implements MLS-Exporter (https://www.rfc-editor.org/rfc/rfc9420.html#section-8.5).
+func (g *mlsGroup) export(label string, context []byte, length int) []byte {
+	exported, _ := expandWithLabel(mlsDeriveSecret(g.secrets.exporter, label), "exported", mlsHash(context), length)
+	return exported
+}
+
+// applyMLSProposals applies the remove & add proposals to the tree & returns the added leaves with their key packages.
+func applyMLSProposals(tree *mlsTree, proposals []*mlsProposal, ownLeaf uint32) (map[uint32]*mlsKeyPackage, error) {
+	for _, proposal := range proposals {
+		if proposal.proposalType != mlsProposalTypeRemove {
+			continue
+		}
+		if tree.leaf(proposal.removed) == nil {
+			return nil, fmt.Errorf("%w: remove of unknown leaf %d", errMLSInvalidCommit, proposal.removed)
+		}
+		if proposal.removed == ownLeaf {
+			return nil, ErrDAVERemoved
+		}
+		tree.removeLeaf(proposal.removed)
+	}
+	added := map[uint32]*mlsKeyPackage{}
+	for _, proposal := range proposals {
+		if proposal.proposalType != mlsProposalTypeAdd {
+			continue
+		}
+		added[tree.addLeaf(proposal.keyPackage.leafNode)] = proposal.keyPackage
+	}
+	return added, nil
+}
+
+// mlsPathRequired returns whether the commit of the proposals must contain a path (https://www.rfc-editor.org/rfc/rfc9420.html#section-12.4).
+func mlsPathRequired(proposals []*mlsProposal) bool {
+	return len(proposals) == 0 || slices.ContainsFunc(proposals, func(proposal *mlsProposal) bool {
+		return proposal.proposalType != mlsProposalTypeAdd
+	})
+}
+
+// createCommit commits the proposals by reference & returns the commit, the welcome for added members & the group state of the next epoch.
+// The commit always contains a path.
+func (g *mlsGroup) createCommit(daveProposals []daveProposal) ([]byte, []byte, *mlsGroup, error) {
+	proposals := make([]*mlsProposal, len(daveProposals))
+	refs := make([]mlsProposalOrRef, len(daveProposals))
+	for i, proposal := range daveProposals {
+		proposals[i] = proposal.proposal
+		refs[i] = mlsProposalOrRef{ref: proposal.ref}
+	}
+
+	tree := g.tree.clone()
+	added, err := applyMLSProposals(tree, proposals, g.ownLeaf)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	pathSecret := make([]byte, mlsHashSize)
+	if _, err = rand.Read(pathSecret); err != nil {
+		return nil, nil, nil, err
+	}
+	leafKey, err := hpkeDeriveKeyPair(mlsDeriveSecret(pathSecret, "node"))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	privateKeys := map[uint32]*ecdh.PrivateKey{2 * g.ownLeaf: leafKey}
+
+	path := tree.filteredDirectPath(2 * g.ownLeaf)
+	for _, x := range tree.directPath(2 * g.ownLeaf) {
+		tree.nodes[x] = mlsNode{}
+	}
+	pathSecrets := make([][]byte, len(path))
+	for i, node := range path {
+		pathSecret = mlsDeriveSecret(pathSecret, "path")
+		pathSecrets[i] = pathSecret
+		key, err := hpkeDeriveKeyPair(mlsDeriveSecret(pathSecret, "node"))
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		privateKeys[node.node] = key
+		tree.nodes[node.node] = mlsNode{parent: &mlsParentNode{encryptionKey: key.PublicKey().Bytes()}}
+	}
+	commitSecret := mlsDeriveSecret(pathSecret, "path")
+
+	ownLeaf := *g.tree.leaf(g.ownLeaf)
+	leaf := &ownLeaf
+	leaf.encryptionKey = leafKey.PublicKey().Bytes()
+	leaf.source = mlsLeafNodeSourceCommit
+	leaf.notBefore, leaf.notAfter = 0, 0
+	leaf.parentHash = tree.setParentHashes(g.ownLeaf)
+	if err = leaf.sign(g.signatureKey, g.groupID, g.ownLeaf); err != nil {
+		return nil, nil, nil, err
+	}
+	tree.nodes[2*g.ownLeaf] = mlsNode{leaf: leaf}
+	treeHash := tree.rootTreeHash()
+
+	provisionalContext := g.context()
+	provisionalContext.epoch++
+	provisionalContext.treeHash = treeHash
+	provisionalContextBytes := provisionalContext.bytes()
+
+	updatePath := &mlsUpdatePath{leafNode: leaf}
+	for i, node := range path {
+		pathNode := mlsUpdatePathNode{encryptionKey: tree.nodes[node.node].encryptionKey()}
+		for _, x := range tree.resolution(node.copath) {
+			if _, ok := added[x/2]; ok && x%2 == 0 {
+				// added members receive the path secret in the welcome
+				continue
+			}
+			kemOutput, ciphertext, err := mlsEncryptWithLabel(tree.nodes[x].encryptionKey(), "UpdatePathNode", provisionalContextBytes, pathSecrets[i])
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			pathNode.encryptedPathSecrets = append(pathNode.encryptedPathSecrets, mlsHPKECiphertext{kemOutput: kemOutput, ciphertext: ciphertext})
+		}
+		updatePath.nodes = append(updatePath.nodes, pathNode)
+	}
+
+	message := &mlsPublicMessage{
+		content: &mlsFramedContent{
+			groupID:     g.groupID,
+			epoch:       g.epoch,
+			senderType:  mlsSenderTypeMember,
+			senderIndex: g.ownLeaf,
+			contentType: mlsContentTypeCommit,
+			commit:      &mlsCommit{proposals: refs, path: updatePath},
+		},
+	}
+	contextBytes := g.context().bytes()
+	if message.signature, err = mlsSignWithLabel(g.signatureKey, "FramedContentTBS", message.content.tbs(contextBytes)); err != nil {
+		return nil, nil, nil, err
+	}
+
+	confirmedTranscriptHash := mlsHash(append(bytes.Clone(g.interimTranscriptHash), message.confirmedTranscriptHashInput()...))
+	groupContext := provisionalContext
+	groupContext.confirmedTranscriptHash = confirmedTranscriptHash
+	joinerSecret, welcomeSecret, secrets := mlsKeySchedule(g.secrets.init, commitSecret, groupContext.bytes())
+	message.confirmationTag = mlsMAC(secrets.confirmation, confirmedTranscriptHash)
+	message.membershipTag = mlsMAC(g.secrets.membership, message.tbm(contextBytes))
+
+	next := &mlsGroup{
+		groupID:                 g.groupID,
+		epoch:                   g.epoch + 1,
+		tree:                    tree,
+		treeHash:                treeHash,
+		ownLeaf:                 g.ownLeaf,
+		extensions:              g.extensions,
+		confirmedTranscriptHash: confirmedTranscriptHash,
+		interimTranscriptHash:   mlsInterimTranscriptHash(confirmedTranscriptHash, message.confirmationTag),
+		secrets:                 secrets,
+		privateKeys:             privateKeys,
+		signatureKey:            g.signatureKey,
+	}
+	if len(added) == 0 {
+		return message.bytes(), nil, next, nil
+	}
+
+	welcome, err := next.createWelcome(added, joinerSecret, welcomeSecret, path, pathSecrets, message.confirmationTag)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return message.bytes(), welcome, next, nil
+}
+
+// createWelcome returns the welcome for the added members (https://www.rfc-editor.org/rfc/rfc9420.html#section-12.4.3).
+func (g *mlsGroup) createWelcome(added map[uint32]*mlsKeyPackage, joinerSecret []byte, welcomeSecret []byte, path []mlsPathNode, pathSecrets [][]byte, confirmationTag []byte) ([]byte, error) {
+	groupInfo := &mlsGroupInfo{
+		groupContext:    g.context(),
+		extensions:      []mlsExtension{{extensionType: mlsExtensionTypeRatchetTree, data: g.tree.bytes()}},
+		confirmationTag: confirmationTag,
+		signer:          g.ownLeaf,
+	}
+	signature, err := mlsSignWithLabel(g.signatureKey, "GroupInfoTBS", groupInfo.tbs())
+	if err != nil {
+		return nil, err
+	}
+	groupInfo.signature = signature
+	encryptedGroupInfo, err := sealMLSWelcome(welcomeSecret, groupInfo.bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	leaves := make([]uint32, 0, len(added))
+	for leafIndex := range added {
+		leaves = append(leaves, leafIndex)
+	}
+	slices.Sort(leaves)
+
+	welcome := &mlsWelcome{encryptedGroupInfo: encryptedGroupInfo}
+	for _, leafIndex := range leaves {
+		keyPackage := added[leafIndex]
+		groupSecrets := &mlsGroupSecrets{joinerSecret: joinerSecret}
+		// the path secret of the lowest common ancestor of the new member & the own leaf
+		if i := slices.IndexFunc(path, func(node mlsPathNode) bool { return mlsInSubtree(node.node, 2*leafIndex) }); i != -1 {
+			groupSecrets.pathSecret = pathSecrets[i]
+		}
+		kemOutput, ciphertext, err := mlsEncryptWithLabel(keyPackage.initKey, "Welcome", encryptedGroupInfo, groupSecrets.bytes())
+		if err != nil {
+			return nil, err
+		}
+		welcome.secrets = append(welcome.secrets, mlsEncryptedGroupSecrets{
+			newMember: keyPackage.ref(),
+			secrets:   mlsHPKECiphertext{kemOutput: kemOutput, ciphertext: ciphertext},
+		})
+	}
+	return welcome.bytes(), nil
+}
+
+// processCommit processes the commit of another member & returns the group state of the next epoch (https://www.rfc-editor.org/rfc/rfc9420.html#section-12.4.2).
+// Proposals are resolved by reference from the proposals of the voice gateway.
+func (g *mlsGroup) processCommit(message *mlsPublicMessage, daveProposals []daveProposal) (*mlsGroup, error) {
+	content := message.content
+	if content.contentType != mlsContentTypeCommit || content.senderType != mlsSenderTypeMember {
+		return nil, fmt.Errorf("%w: not a commit of a member", errMLSInvalidCommit)
+	}
+	if !bytes.Equal(content.groupID, g.groupID) || content.epoch != g.epoch {
+		return nil, fmt.Errorf("%w: commit for group epoch %d, expected %d", errMLSInvalidCommit, content.epoch, g.epoch)
+	}
+	sender := g.tree.leaf(content.senderIndex)
+	if sender == nil || content.senderIndex == g.ownLeaf {
+		return nil, fmt.Errorf("%w: unexpected sender %d", errMLSInvalidCommit, content.senderIndex)
+	}
+
+	contextBytes := g.context().bytes()
+	if !hmac.Equal(mlsMAC(g.secrets.membership, message.tbm(contextBytes)), message.membershipTag) {
+		return nil, fmt.Errorf("%w: invalid membership tag", errMLSInvalidCommit)
+	}
+	if err := mlsVerifyWithLabel(sender.signatureKey, "FramedContentTBS", content.tbs(contextBytes), message.signature); err != nil {
+		return nil, err
+	}
+
+	proposals := make([]*mlsProposal, 0, len(content.commit.proposals))
+	for _, proposal := range content.commit.proposals {
+		if proposal.proposal != nil {
+			if proposal.proposal.proposalType == mlsProposalTypeAdd {
+				if err := proposal.proposal.keyPackage.verify(); err != nil {
+					return nil, err
+				}
+			}
+			proposals = append(proposals, proposal.proposal)
+			continue
+		}
+		i := slices.IndexFunc(daveProposals, func(daveProposal daveProposal) bool { return bytes.Equal(daveProposal.ref, proposal.ref) })
+		if i == -1 {
+			return nil, fmt.Errorf("%w: unknown proposal reference", errMLSInvalidCommit)
+		}
+		proposals = append(proposals, daveProposals[i].proposal)
+	}
+
+	tree := g.tree.clone()
+	added, err := applyMLSProposals(tree, proposals, g.ownLeaf)
+	if err != nil {
+		return nil, err
+	}
+
+	commitSecret := make([]byte, mlsHashSize)
+	privateKeys := map[uint32]*ecdh.PrivateKey{}
+	for x, key := range g.privateKeys {
+		privateKeys[x] = key
+	}
+	updatePath := content.commit.path
+	if updatePath == nil && mlsPathRequired(proposals) {
+		return nil, fmt.Errorf("%w: missing path", errMLSInvalidCommit)
+	}
+	if updatePath != nil {
+		if commitSecret, err = g.mergePath(tree, content.senderIndex, updatePath, added, privateKeys); err != nil {
+			return nil, err
+		}
+	}
+	treeHash := tree.rootTreeHash()
+
+	// keys of blanked or replaced nodes are not needed anymore
+	for x, key := range privateKeys {
+		if int(x) >= len(tree.nodes) || !bytes.Equal(tree.nodes[x].encryptionKey(), key.PublicKey().Bytes()) {
+			delete(privateKeys, x)
+		}
+	}
+
+	confirmedTranscriptHash := mlsHash(append(bytes.Clone(g.interimTranscriptHash), message.confirmedTranscriptHashInput()...))
+	groupContext := &mlsGroupContext{
+		groupID:                 g.groupID,
+		epoch:                   g.epoch + 1,
+		treeHash:                treeHash,
+		confirmedTranscriptHash: confirmedTranscriptHash,
+		extensions:              g.extensions,
+	}
+	_, _, secrets := mlsKeySchedule(g.secrets.init, commitSecret, groupContext.bytes())
+	if !hmac.Equal(mlsMAC(secrets.confirmation, confirmedTranscriptHash), message.confirmationTag) {
+		return nil, fmt.Errorf("%w: invalid confirmation tag", errMLSInvalidCommit)
+	}
+
+	return &mlsGroup{
+		groupID:                 g.groupID,
+		epoch:                   g.epoch + 1,
+		tree:                    tree,
+		treeHash:                treeHash,
+		ownLeaf:                 g.ownLeaf,
+		extensions:              g.extensions,
+		confirmedTranscriptHash: confirmedTranscriptHash,
+		interimTranscriptHash:   mlsInterimTranscriptHash(confirmedTranscriptHash, message.confirmationTag),
+		secrets:                 secrets,
+		privateKeys:             privateKeys,
+		signatureKey:            g.signatureKey,
+	}, nil
+}
+
+// mergePath merges the path of the sender into the tree, decrypts the path secret & returns the commit secret.
+func (g *mlsGroup) mergePath(tree *mlsTree, senderLeaf uint32, updatePath *mlsUpdatePath, added map[uint32]*mlsKeyPackage, privateKeys map[uint32]*ecdh.PrivateKey) ([]byte, error) {
+	leaf, sender := updatePath.leafNode, tree.leaf(senderLeaf)
+	if sender == nil || leaf.source != mlsLeafNodeSourceCommit || !bytes.Equal(leaf.identity, sender.identity) {
+		return nil, fmt.Errorf("%w: invalid leaf node", errMLSInvalidCommit)
+	}
+	path := tree.filteredDirectPath(2 * senderLeaf)
+	if len(path) != len(updatePath.nodes) {
+		return nil, fmt.Errorf("%w: path length %d, expected %d", errMLSInvalidCommit, len(updatePath.nodes), len(path))
+	}
+
+	for _, x := range tree.directPath(2 * senderLeaf) {
+		tree.nodes[x] = mlsNode{}
+	}
+	keys := make([][]byte, len(path))
+	for i, node := range path {
+		keys[i] = updatePath.nodes[i].encryptionKey
+		tree.nodes[node.node] = mlsNode{parent: &mlsParentNode{encryptionKey: keys[i]}}
+	}
+	if !bytes.Equal(tree.setParentHashes(senderLeaf), leaf.parentHash) {
+		return nil, fmt.Errorf("%w: invalid parent hash", errMLSInvalidCommit)
+	}
+	if err := leaf.verify(g.groupID, senderLeaf); err != nil {
+		return nil, err
+	}
+	tree.nodes[2*senderLeaf] = mlsNode{leaf: leaf}
+	if err := tree.verifyLeaves(g.groupID); err != nil {
+		return nil, err
+	}
+
+	provisionalContext := g.context()
+	provisionalContext.epoch++
+	provisionalContext.treeHash = tree.rootTreeHash()
+
+	// the path secret is encrypted to the node in the resolution of the copath child which is an ancestor of the own leaf
+	i := slices.IndexFunc(path, func(node mlsPathNode) bool { return mlsInSubtree(node.node, 2*g.ownLeaf) })
+	if i == -1 {
+		return nil, fmt.Errorf("%w: own leaf not in path", errMLSInvalidCommit)
+	}
+	resolution := slices.DeleteFunc(tree.resolution(path[i].copath), func(x uint32) bool {
+		_, ok := added[x/2]
+		return ok && x%2 == 0
+	})
+	if len(resolution) != len(updatePath.nodes[i].encryptedPathSecrets) {
+		return nil, fmt.Errorf("%w: unexpected amount of encrypted path secrets", errMLSInvalidCommit)
+	}
+	for j, x := range resolution {
+		key, ok := privateKeys[x]
+		if !ok || !bytes.Equal(key.PublicKey().Bytes(), tree.nodes[x].encryptionKey()) {
+			continue
+		}
+		ciphertext := updatePath.nodes[i].encryptedPathSecrets[j]
+		pathSecret, err := mlsDecryptWithLabel(key, "UpdatePathNode", provisionalContext.bytes(), ciphertext.kemOutput, ciphertext.ciphertext)
+		if err != nil {
+			return nil, err
+		}
+		return derivePathKeys(pathSecret, path[i:], keys[i:], privateKeys)
+	}
+	return nil, fmt.Errorf("%w: no private key to decrypt the path secret", errMLSInvalidCommit)
+}
@@ -0,0 +1,678 @@
+package voice
+
+import (
+	"crypto/ecdsa"
+)
+
+// MLS structs used by DAVE (https://www.rfc-editor.org/rfc/rfc9420.html). Only the parts of MLS used by DAVE are supported:
+// basic credentials, add & remove proposals, commits sent as public messages & welcome messages with an inlined ratchet tree.
+const (
+	mlsVersion uint16 = 1
+
+	mlsWireFormatPublicMessage uint16 = 1
+	mlsWireFormatWelcome       uint16 = 3
+	mlsWireFormatKeyPackage    uint16 = 5
+
+	mlsCredentialTypeBasic uint16 = 1
+
+	mlsExtensionTypeRatchetTree     uint16 = 2
+	mlsExtensionTypeExternalSenders uint16 = 5
+
+	mlsLeafNodeSourceKeyPackage uint8 = 1
+	mlsLeafNodeSourceUpdate     uint8 = 2
+	mlsLeafNodeSourceCommit     uint8 = 3
+
+	mlsSenderTypeMember   uint8 = 1
+	mlsSenderTypeExternal uint8 = 2
+
+	mlsContentTypeProposal uint8 = 2
+	mlsContentTypeCommit   uint8 = 3
+
+	mlsProposalTypeAdd    uint16 = 1
+	mlsProposalTypeRemove uint16 = 3
+
+	mlsProposalOrRefProposal  uint8 = 1
+	mlsProposalOrRefReference uint8 = 2
+
+	mlsNodeTypeLeaf   uint8 = 1
+	mlsNodeTypeParent uint8 = 2
+)
+
+type mlsExtension struct {
+	extensionType uint16
+	data          []byte
+}
+
+func writeMLSExtensions(w *mlsWriter, extensions []mlsExtension) {
+	w.vector(func(w *mlsWriter) {
+		for _, extension := range extensions {
+			w.u16(extension.extensionType)
+			w.opaque(extension.data)
+		}
+	})
+}
+
+func readMLSExtensions(r *mlsReader) []mlsExtension {
+	var extensions []mlsExtension
+	r.vector(func(r *mlsReader) {
+		extensions = append(extensions, mlsExtension{extensionType: r.u16(), data: r.opaque()})
+	})
+	return extensions
+}
+
+func findMLSExtension(extensions []mlsExtension, extensionType uint16) []byte {
+	for _, extension := range extensions {
+		if extension.extensionType == extensionType {
+			return extension.data
+		}
+	}
+	return nil
+}
+
+type mlsCapabilities struct {
+	versions     []uint16
+	cipherSuites []uint16
+	extensions   []uint16
+	proposals    []uint16
+	credentials  []uint16
+}
+
+func (c mlsCapabilities) write(w *mlsWriter) {
+	for _, values := range [][]uint16{c.versions, c.cipherSuites, c.extensions, c.proposals, c.credentials} {
+		w.vector(func(w *mlsWriter) {
+			for _, v := range values {
+				w.u16(v)
+			}
+		})
+	}
+}
+
+func readMLSCapabilities(r *mlsReader) mlsCapabilities {
+	return mlsCapabilities{
+		versions:     r.u16s(),
+		cipherSuites: r.u16s(),
+		extensions:   r.u16s(),
+		proposals:    r.u16s(),
+		credentials:  r.u16s(),
+	}
+}
+
+// mlsLeafNode is a LeafNode with a basic credential.
+type mlsLeafNode struct {
+	encryptionKey []byte
+	signatureKey  []byte
+	identity      []byte
+	capabilities  mlsCapabilities
+	source        uint8
+	notBefore     uint64
+	notAfter      uint64
+	parentHash    []byte
+	extensions    []mlsExtension
+	signature     []byte
+}
+
+func (n *mlsLeafNode) writeContent(w *mlsWriter) {
+	w.opaque(n.encryptionKey)
+	w.opaque(n.signatureKey)
+	w.u16(mlsCredentialTypeBasic)
+	w.opaque(n.identity)
+	n.capabilities.write(w)
+	w.u8(n.source)
+	switch n.source {
+	case mlsLeafNodeSourceKeyPackage:
+		w.u64(n.notBefore)
+		w.u64(n.notAfter)
+	case mlsLeafNodeSourceCommit:
+		w.opaque(n.parentHash)
+	}
+	writeMLSExtensions(w, n.extensions)
+}
+
+func (n *mlsLeafNode) write(w *mlsWriter) {
+	n.writeContent(w)
+	w.opaque(n.signature)
+}
+
+// tbs returns the LeafNodeTBS. The group ID & leaf index are only used by leaf nodes which are not from a key package.
+func (n *mlsLeafNode) tbs(groupID []byte, leafIndex uint32) []byte {
+	w := &mlsWriter{}
+	n.writeContent(w)
+	if n.source != mlsLeafNodeSourceKeyPackage {
+		w.opaque(groupID)
+		w.u32(leafIndex)
+	}
+	return w.b
+}
+
+func (n *mlsLeafNode) sign(key *ecdsa.PrivateKey, groupID []byte, leafIndex uint32) error {
+	signature, err := mlsSignWithLabel(key, "LeafNodeTBS", n.tbs(groupID, leafIndex))
+	if err != nil {
+		return err
+	}
+	n.signature = signature
+	return nil
+}
+
+func (n *mlsLeafNode) verify(groupID []byte, leafIndex uint32) error {
+	return mlsVerifyWithLabel(n.signatureKey, "LeafNodeTBS", n.tbs(groupID, leafIndex), n.signature)
+}
+
+func readMLSLeafNode(r *mlsReader) *mlsLeafNode {
+	n := &mlsLeafNode{
+		encryptionKey: r.opaque(),
+		signatureKey:  r.opaque(),
+	}
+	if r.u16() != mlsCredentialTypeBasic {
+		r.fail()
+		return n
+	}
+	n.identity = r.opaque()
+	n.capabilities = readMLSCapabilities(r)
+	n.source = r.u8()
+	switch n.source {
+	case mlsLeafNodeSourceKeyPackage:
+		n.notBefore = r.u64()
+		n.notAfter = r.u64()
+	case mlsLeafNodeSourceUpdate:
+	case mlsLeafNodeSourceCommit:
+		n.parentHash = r.opaque()
+	default:
+		r.fail()
+	}
+	n.extensions = readMLSExtensions(r)
+	n.signature = r.opaque()
+	return n
+}
+
+type mlsKeyPackage struct {
+	initKey    []byte
+	leafNode   *mlsLeafNode
+	extensions []mlsExtension
+	signature  []byte
+}
+
+func (p *mlsKeyPackage) writeContent(w *mlsWriter) {
+	w.u16(mlsVersion)
+	w.u16(mlsCipherSuite)
+	w.opaque(p.initKey)
+	p.leafNode.write(w)
+	writeMLSExtensions(w, p.extensions)
+}
+
+func (p *mlsKeyPackage) write(w *mlsWriter) {
+	p.writeContent(w)
+	w.opaque(p.signature)
+}
+
+func (p *mlsKeyPackage) bytes() []byte {
+	w := &mlsWriter{}
+	p.write(w)
+	return w.b
+}
+
+func (p *mlsKeyPackage) ref() []byte {
+	return mlsRefHash("MLS 1.0 KeyPackage Reference", p.bytes())
+}
+
+func (p *mlsKeyPackage) sign(key *ecdsa.PrivateKey) error {
+	w := &mlsWriter{}
+	p.writeContent(w)
+	signature, err := mlsSignWithLabel(key, "KeyPackageTBS", w.b)
+	if err != nil {
+		return err
+	}
+	p.signature = signature
+	return nil
+}
+
+// verify verifies the key package & its leaf node.
+func (p *mlsKeyPackage) verify() error {
+	if p.leafNode.source != mlsLeafNodeSourceKeyPackage {
+		return ErrMLSMessageInvalid
+	}
+	if err := p.leafNode.verify(nil, 0); err != nil {
+		return err
+	}
+	w := &mlsWriter{}
+	p.writeContent(w)
+	return mlsVerifyWithLabel(p.leafNode.signatureKey, "KeyPackageTBS", w.b, p.signature)
+}
+
+func readMLSKeyPackage(r *mlsReader) *mlsKeyPackage {
+	if r.u16() != mlsVersion || r.u16() != mlsCipherSuite {
+		r.fail()
+		return nil
+	}
+	return &mlsKeyPackage{
+		initKey:    r.opaque(),
+		leafNode:   readMLSLeafNode(r),
+		extensions: readMLSExtensions(r),
+		signature:  r.opaque(),
+	}
+}
+
+// mlsProposal is an add or remove proposal.
+type mlsProposal struct {
+	proposalType uint16
+	keyPackage   *mlsKeyPackage
+	removed      uint32
+}
+
+func (p *mlsProposal) write(w *mlsWriter) {
+	w.u16(p.proposalType)
+	switch p.proposalType {
+	case mlsProposalTypeAdd:
+		p.keyPackage.write(w)
+	case mlsProposalTypeRemove:
+		w.u32(p.removed)
+	}
+}
+
+func readMLSProposal(r *mlsReader) *mlsProposal {
+	p := &mlsProposal{proposalType: r.u16()}
+	switch p.proposalType {
+	case mlsProposalTypeAdd:
+		p.keyPackage = readMLSKeyPackage(r)
+	case mlsProposalTypeRemove:
+		p.removed = r.u32()
+	default:
+		r.fail()
+	}
+	return p
+}
+
+type mlsProposalOrRef struct {
+	proposal *mlsProposal
+	ref      []byte
+}
+
+type mlsHPKECiphertext struct {
+	kemOutput  []byte
+	ciphertext []byte
+}
+
+func (c mlsHPKECiphertext) write(w *mlsWriter) {
+	w.opaque(c.kemOutput)
+	w.opaque(c.ciphertext)
+}
+
+func readMLSHPKECiphertext(r *mlsReader) mlsHPKECiphertext {
+	return mlsHPKECiphertext{kemOutput: r.opaque(), ciphertext: r.opaque()}
+}
+
+type mlsUpdatePathNode struct {
+	encryptionKey        []byte
+	encryptedPathSecrets []mlsHPKECiphertext
+}
+
+type mlsUpdatePath struct {
+	leafNode *mlsLeafNode
+	nodes    []mlsUpdatePathNode
+}
+
+type mlsCommit struct {
+	proposals []mlsProposalOrRef
+	path      *mlsUpdatePath
+}
+
+func (c *mlsCommit) write(w *mlsWriter) {
+	w.vector(func(w *mlsWriter) {
+		for _, proposal := range c.proposals {
+			if proposal.proposal != nil {
+				w.u8(mlsProposalOrRefProposal)
+				proposal.proposal.write(w)
+				continue
+			}
+			w.u8(mlsProposalOrRefReference)
+			w.opaque(proposal.ref)
+		}
+	})
+	w.optional(c.path != nil, func(w *mlsWriter) {
+		c.path.leafNode.write(w)
+		w.vector(func(w *mlsWriter) {
+			for _, node := range c.path.nodes {
+				w.opaque(node.encryptionKey)
+				w.vector(func(w *mlsWriter) {
+					for _, ciphertext := range node.encryptedPathSecrets {
+						ciphertext.write(w)
+					}
+				})
+			}
+		})
+	})
+}
+
+func readMLSCommit(r *mlsReader) *mlsCommit {
+	c := &mlsCommit{}
+	r.vector(func(r *mlsReader) {
+		switch r.u8() {
+		case mlsProposalOrRefProposal:
+			c.proposals = append(c.proposals, mlsProposalOrRef{proposal: readMLSProposal(r)})
+		case mlsProposalOrRefReference:
+			c.proposals = append(c.proposals, mlsProposalOrRef{ref: r.opaque()})
+		default:
+			r.fail()
+		}
+	})
+	r.optional(func(r *mlsReader) {
+		c.path = &mlsUpdatePath{leafNode: readMLSLeafNode(r)}
+		r.vector(func(r *mlsReader) {
+			node := mlsUpdatePathNode{encryptionKey: r.opaque()}
+			r.vector(func(r *mlsReader) {
+				node.encryptedPathSecrets = append(node.encryptedPathSecrets, readMLSHPKECiphertext(r))
+			})
+			c.path.nodes = append(c.path.nodes, node)
+		})
+	})
+	return c
+}
+
+// mlsFramedContent is the FramedContent of a proposal or commit.
+type mlsFramedContent struct {
+	groupID           []byte
+	epoch             uint64
+	senderType        uint8
+	senderIndex       uint32
+	authenticatedData []byte
+	contentType       uint8
+	proposal          *mlsProposal
+	commit            *mlsCommit
+}
+
+func (c *mlsFramedContent) write(w *mlsWriter) {
+	w.opaque(c.groupID)
+	w.u64(c.epoch)
+	w.u8(c.senderType)
+	w.u32(c.senderIndex)
+	w.opaque(c.authenticatedData)
+	w.u8(c.contentType)
+	switch c.contentType {
+	case mlsContentTypeProposal:
+		c.proposal.write(w)
+	case mlsContentTypeCommit:
+		c.commit.write(w)
+	}
+}
+
+// tbs returns the FramedContentTBS of a public message. The group context is only used for messages sent by members.
+func (c *mlsFramedContent) tbs(groupContext []byte) []byte {
+	w := &mlsWriter{}
+	w.u16(mlsVersion)
+	w.u16(mlsWireFormatPublicMessage)
+	c.write(w)
+	if c.senderType == mlsSenderTypeMember {
+		w.raw(groupContext)
+	}
+	return w.b
+}
+
+func readMLSFramedContent(r *mlsReader) *mlsFramedContent {
+	c := &mlsFramedContent{
+		groupID: r.opaque(),
+		epoch:   r.u64(),
+	}
+	c.senderType = r.u8()
+	switch c.senderType {
+	case mlsSenderTypeMember, mlsSenderTypeExternal:
+		c.senderIndex = r.u32()
+	default:
+		r.fail()
+	}
+	c.authenticatedData = r.opaque()
+	c.contentType = r.u8()
+	switch c.contentType {
+	case mlsContentTypeProposal:
+		c.proposal = readMLSProposal(r)
+	case mlsContentTypeCommit:
+		c.commit = readMLSCommit(r)
+	default:
+		r.fail()
+	}
+	return c
+}
+
+// mlsPublicMessage is a PublicMessage with the FramedContentAuthData.
+type mlsPublicMessage struct {
+	content         *mlsFramedContent
+	signature       []byte
+	confirmationTag []byte
+	membershipTag   []byte
+}
+
+func (m *mlsPublicMessage) writeAuth(w *mlsWriter) {
+	w.opaque(m.signature)
+	if m.content.contentType == mlsContentTypeCommit {
+		w.opaque(m.confirmationTag)
+	}
+}
+
+// bytes returns the MLSMessage of the public message.
+func (m *mlsPublicMessage) bytes() []byte {
+	w := &mlsWriter{}
+	w.u16(mlsVersion)
+	w.u16(mlsWireFormatPublicMessage)
+	m.content.write(w)
+	m.writeAuth(w)
+	if m.content.senderType == mlsSenderTypeMember {
+		w.opaque(m.membershipTag)
+	}
+	return w.b
+}
+
+// authenticatedContent returns the AuthenticatedContent used to reference proposals.
+func (m *mlsPublicMessage) authenticatedContent() []byte {
+	w := &mlsWriter{}
+	w.u16(mlsWireFormatPublicMessage)
+	m.content.write(w)
+	m.writeAuth(w)
+	return w.b
+}
+
+// tbm returns the AuthenticatedContentTBM used for the membership tag.
+func (m *mlsPublicMessage) tbm(groupContext []byte) []byte {
+	w := &mlsWriter{b: m.content.tbs(groupContext)}
+	m.writeAuth(w)
+	return w.b
+}
+
+// confirmedTranscriptHashInput returns the ConfirmedTranscriptHashInput of a commit.
+func (m *mlsPublicMessage) confirmedTranscriptHashInput() []byte {
+	w := &mlsWriter{}
+	w.u16(mlsWireFormatPublicMessage)
+	m.content.write(w)
+	w.opaque(m.signature)
+	return w.b
+}
+
+func readMLSPublicMessage(r *mlsReader) *mlsPublicMessage {
+	if r.u16() != mlsVersion || r.u16() != mlsWireFormatPublicMessage {
+		r.fail()
+		return nil
+	}
+	m := &mlsPublicMessage{content: readMLSFramedContent(r)}
+	m.signature = r.opaque()
+	if m.content.contentType == mlsContentTypeCommit {
+		m.confirmationTag = r.opaque()
+	}
+	if m.content.senderType == mlsSenderTypeMember {
+		m.membershipTag = r.opaque()
+	}
+	return m
+}
+
+func parseMLSPublicMessage(b []byte) (*mlsPublicMessage, error) {
+	r := newMLSReader(b)
+	m := readMLSPublicMessage(r)
+	if err := r.end(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+type mlsGroupContext struct {
+	groupID                 []byte
+	epoch                   uint64
+	treeHash                []byte
+	confirmedTranscriptHash []byte
+	extensions              []mlsExtension
+}
+
+func (c *mlsGroupContext) bytes() []byte {
+	w := &mlsWriter{}
+	w.u16(mlsVersion)
+	w.u16(mlsCipherSuite)
+	w.opaque(c.groupID)
+	w.u64(c.epoch)
+	w.opaque(c.treeHash)
+	w.opaque(c.confirmedTranscriptHash)
+	writeMLSExtensions(w, c.extensions)
+	return w.b
+}
+
+func readMLSGroupContext(r *mlsReader) *mlsGroupContext {
+	if r.u16() != mlsVersion || r.u16() != mlsCipherSuite {
+		r.fail()
+		return nil
+	}
+	return &mlsGroupContext{
+		groupID:                 r.opaque(),
+		epoch:                   r.u64(),
+		treeHash:                r.opaque(),
+		confirmedTranscriptHash: r.opaque(),
+		extensions:              readMLSExtensions(r),
+	}
+}
+
+type mlsGroupInfo struct {
+	groupContext    *mlsGroupContext
+	extensions      []mlsExtension
+	confirmationTag []byte
+	signer          uint32
+	signature       []byte
+}
+
+func (i *mlsGroupInfo) tbs() []byte {
+	w := &mlsWriter{b: i.groupContext.bytes()}
+	writeMLSExtensions(w, i.extensions)
+	w.opaque(i.confirmationTag)
+	w.u32(i.signer)
+	return w.b
+}
+
+func (i *mlsGroupInfo) bytes() []byte {
+	w := &mlsWriter{b: i.tbs()}
+	w.opaque(i.signature)
+	return w.b
+}
+
+func parseMLSGroupInfo(b []byte) (*mlsGroupInfo, error) {
+	r := newMLSReader(b)
+	i := &mlsGroupInfo{
+		groupContext: readMLSGroupContext(r),
+	}
+	i.extensions = readMLSExtensions(r)
+	i.confirmationTag = r.opaque()
+	i.signer = r.u32()
+	i.signature = r.opaque()
+	if err := r.end(); err != nil {
+		return nil, err
+	}
+	return i, nil
+}
+
+// mlsGroupSecrets are the GroupSecrets without pre-shared keys.
+type mlsGroupSecrets struct {
+	joinerSecret []byte
+	pathSecret   []byte
+}
+
+func (s *mlsGroupSecrets) bytes() []byte {
+	w := &mlsWriter{}
+	w.opaque(s.joinerSecret)
+	w.optional(s.pathSecret != nil, func(w *mlsWriter) {
+		w.opaque(s.pathSecret)
+	})
+	// psks
+	w.opaque(nil)
+	return w.b
+}
+
+func parseMLSGroupSecrets(b []byte) (*mlsGroupSecrets, error) {
+	r := newMLSReader(b)
+	s := &mlsGroupSecrets{joinerSecret: r.opaque()}
+	r.optional(func(r *mlsReader) {
+		s.pathSecret = r.opaque()
+	})
+	// pre-shared keys are not used by DAVE
+	if psks := r.opaque(); len(psks) > 0 {
+		r.fail()
+	}
+	if err := r.end(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+type mlsEncryptedGroupSecrets struct {
+	newMember []byte
+	secrets   mlsHPKECiphertext
+}
+
+type mlsWelcome struct {
+	secrets            []mlsEncryptedGroupSecrets
+	encryptedGroupInfo []byte
+}
+
+func (m *mlsWelcome) bytes() []byte {
+	w := &mlsWriter{}
+	w.u16(mlsCipherSuite)
+	w.vector(func(w *mlsWriter) {
+		for _, secrets := range m.secrets {
+			w.opaque(secrets.newMember)
+			secrets.secrets.write(w)
+		}
+	})
+	w.opaque(m.encryptedGroupInfo)
+	return w.b
+}
+
+func readMLSWelcome(r *mlsReader) *mlsWelcome {
+	if r.u16() != mlsCipherSuite {
+		r.fail()
+		return nil
+	}
+	m := &mlsWelcome{}
+	r.vector(func(r *mlsReader) {
+		m.secrets = append(m.secrets, mlsEncryptedGroupSecrets{newMember: r.opaque(), secrets: readMLSHPKECiphertext(r)})
+	})
+	m.encryptedGroupInfo = r.opaque()
+	return m
+}
+
+// mlsExternalSender is the ExternalSender of the voice gateway.
+type mlsExternalSender struct {
+	signatureKey []byte
+	identity     []byte
+}
+
+func (s *mlsExternalSender) write(w *mlsWriter) {
+	w.opaque(s.signatureKey)
+	w.u16(mlsCredentialTypeBasic)
+	w.opaque(s.identity)
+}
+
+func parseMLSExternalSender(b []byte) (*mlsExternalSender, error) {
+	r := newMLSReader(b)
+	s := &mlsExternalSender{signatureKey: r.opaque()}
+	if r.u16() != mlsCredentialTypeBasic {
+		r.fail()
+	}
+	s.identity = r.opaque()
+	if err := r.end(); err != nil {
+		return nil, err
+	}
+	if _, err := parseMLSSignaturePublicKey(s.signatureKey); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
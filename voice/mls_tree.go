@@ -0,0 +1,369 @@
+package voice
+
+import (
+	"bytes"
+	"errors"
+	"math/bits"
+	"slices"
+)
+
+var errMLSInvalidTree = errors.New("invalid mls ratchet tree")
+
+type mlsParentNode struct {
+	encryptionKey  []byte
+	parentHash     []byte
+	unmergedLeaves []uint32
+}
+
+func (n *mlsParentNode) write(w *mlsWriter, excludedLeaves []uint32) {
+	w.opaque(n.encryptionKey)
+	w.opaque(n.parentHash)
+	w.vector(func(w *mlsWriter) {
+		for _, leaf := range n.unmergedLeaves {
+			if !slices.Contains(excludedLeaves, leaf) {
+				w.u32(leaf)
+			}
+		}
+	})
+}
+
+// mlsNode is a node of the mlsTree. Blank nodes have neither a leaf nor a parent.
+type mlsNode struct {
+	leaf   *mlsLeafNode
+	parent *mlsParentNode
+}
+
+func (n mlsNode) blank() bool {
+	return n.leaf == nil && n.parent == nil
+}
+
+func (n mlsNode) encryptionKey() []byte {
+	switch {
+	case n.leaf != nil:
+		return n.leaf.encryptionKey
+	case n.parent != nil:
+		return n.parent.encryptionKey
+	}
+	return nil
+}
+
+// mlsTree is the ratchet tree of a MLS group (https://www.rfc-editor.org/rfc/rfc9420.html#section-7) stored as array.
+// Leaf i is node 2*i & the amount of leaves is always a power of two.
+type mlsTree struct {
+	nodes []mlsNode
+}
+
+func (t *mlsTree) clone() *mlsTree {
+	nodes := make([]mlsNode, len(t.nodes))
+	for i, node := range t.nodes {
+		nodes[i] = node
+		if node.parent != nil {
+			parent := *node.parent
+			parent.unmergedLeaves = slices.Clone(parent.unmergedLeaves)
+			nodes[i].parent = &parent
+		}
+	}
+	return &mlsTree{nodes: nodes}
+}
+
+func (t *mlsTree) leafCount() uint32 {
+	return uint32(len(t.nodes)+1) / 2
+}
+
+func (t *mlsTree) root() uint32 {
+	return t.leafCount() - 1
+}
+
+func (t *mlsTree) leaf(leafIndex uint32) *mlsLeafNode {
+	if leafIndex >= t.leafCount() {
+		return nil
+	}
+	return t.nodes[2*leafIndex].leaf
+}
+
+func mlsLevel(x uint32) int {
+	return bits.TrailingZeros32(^x)
+}
+
+func mlsLeft(x uint32) uint32 {
+	return x ^ (1 << (mlsLevel(x) - 1))
+}
+
+func mlsRight(x uint32) uint32 {
+	return x ^ (3 << (mlsLevel(x) - 1))
+}
+
+func mlsParent(x uint32) uint32 {
+	k := mlsLevel(x)
+	b := (x >> (k + 1)) & 1
+	return (x | 1<<k) ^ (b << (k + 1))
+}
+
+func mlsSibling(x uint32) uint32 {
+	p := mlsParent(x)
+	if x < p {
+		return mlsRight(p)
+	}
+	return mlsLeft(p)
+}
+
+// mlsInSubtree returns whether node y is in the subtree of node x.
+func mlsInSubtree(x uint32, y uint32) bool {
+	span := uint32(1)<<mlsLevel(x) - 1
+	return y >= x-span && y <= x+span
+}
+
+// directPath returns the parents of the node up to the root.
+func (t *mlsTree) directPath(x uint32) []uint32 {
+	var path []uint32
+	for root := t.root(); x != root; {
+		x = mlsParent(x)
+		path = append(path, x)
+	}
+	return path
+}
+
+// resolution returns the resolution of the node (https://www.rfc-editor.org/rfc/rfc9420.html#section-4.1.2).
+func (t *mlsTree) resolution(x uint32) []uint32 {
+	node := t.nodes[x]
+	switch {
+	case node.leaf != nil:
+		return []uint32{x}
+	case node.parent != nil:
+		resolution := []uint32{x}
+		for _, leaf := range node.parent.unmergedLeaves {
+			resolution = append(resolution, 2*leaf)
+		}
+		return resolution
+	case mlsLevel(x) == 0:
+		return nil
+	}
+	return append(t.resolution(mlsLeft(x)), t.resolution(mlsRight(x))...)
+}
+
+type mlsPathNode struct {
+	node   uint32
+	copath uint32
+}
+
+// filteredDirectPath returns the nodes of the direct path whose child on the copath has a non-empty resolution & the child.
+func (t *mlsTree) filteredDirectPath(x uint32) []mlsPathNode {
+	var path []mlsPathNode
+	for root := t.root(); x != root; {
+		copath := mlsSibling(x)
+		x = mlsParent(x)
+		if len(t.resolution(copath)) > 0 {
+			path = append(path, mlsPathNode{node: x, copath: copath})
+		}
+	}
+	return path
+}
+
+// addLeaf adds the leaf at the leftmost blank leaf & extends the tree if there is none.
+func (t *mlsTree) addLeaf(leaf *mlsLeafNode) uint32 {
+	leafIndex := uint32(0)
+	for ; leafIndex < t.leafCount(); leafIndex++ {
+		if t.nodes[2*leafIndex].blank() {
+			break
+		}
+	}
+	if leafIndex == t.leafCount() {
+		t.nodes = append(t.nodes, make([]mlsNode, len(t.nodes)+1)...)
+	}
+	t.nodes[2*leafIndex] = mlsNode{leaf: leaf}
+	for _, x := range t.directPath(2 * leafIndex) {
+		if parent := t.nodes[x].parent; parent != nil {
+			i, _ := slices.BinarySearch(parent.unmergedLeaves, leafIndex)
+			parent.unmergedLeaves = slices.Insert(parent.unmergedLeaves, i, leafIndex)
+		}
+	}
+	return leafIndex
+}
+
+// removeLeaf blanks the leaf & its direct path & truncates the tree.
+func (t *mlsTree) removeLeaf(leafIndex uint32) {
+	t.nodes[2*leafIndex] = mlsNode{}
+	for _, x := range t.directPath(2 * leafIndex) {
+		t.nodes[x] = mlsNode{}
+	}
+	for t.leafCount() > 1 {
+		half := len(t.nodes) / 2
+		if slices.ContainsFunc(t.nodes[half+1:], func(node mlsNode) bool { return !node.blank() }) {
+			break
+		}
+		t.nodes = t.nodes[:half]
+	}
+}
+
+// treeHash returns the tree hash of the node (https://www.rfc-editor.org/rfc/rfc9420.html#section-7.8).
+// The excluded leaves are treated as blank & removed from the unmerged leaves.
+func (t *mlsTree) treeHash(x uint32, excludedLeaves []uint32) []byte {
+	w := &mlsWriter{}
+	node := t.nodes[x]
+	if mlsLevel(x) == 0 {
+		w.u8(mlsNodeTypeLeaf)
+		w.u32(x / 2)
+		w.optional(node.leaf != nil && !slices.Contains(excludedLeaves, x/2), func(w *mlsWriter) {
+			node.leaf.write(w)
+		})
+		return mlsHash(w.b)
+	}
+	w.u8(mlsNodeTypeParent)
+	w.optional(node.parent != nil, func(w *mlsWriter) {
+		node.parent.write(w, excludedLeaves)
+	})
+	w.opaque(t.treeHash(mlsLeft(x), excludedLeaves))
+	w.opaque(t.treeHash(mlsRight(x), excludedLeaves))
+	return mlsHash(w.b)
+}
+
+func (t *mlsTree) rootTreeHash() []byte {
+	return t.treeHash(t.root(), nil)
+}
+
+// parentHash returns the parent hash of the parent node p which is stored in its child on the other side than the sibling s.
+func (t *mlsTree) parentHash(p uint32, s uint32) []byte {
+	parent := t.nodes[p].parent
+	w := &mlsWriter{}
+	w.opaque(parent.encryptionKey)
+	w.opaque(parent.parentHash)
+	w.opaque(t.treeHash(s, parent.unmergedLeaves))
+	return mlsHash(w.b)
+}
+
+// setParentHashes sets the parent hashes of the filtered direct path of the leaf, which must have been set before, & returns the parent hash of the leaf.
+func (t *mlsTree) setParentHashes(leafIndex uint32) []byte {
+	path := t.filteredDirectPath(2 * leafIndex)
+	var parentHash []byte
+	for i := len(path) - 1; i >= 0; i-- {
+		t.nodes[path[i].node].parent.parentHash = parentHash
+		parentHash = t.parentHash(path[i].node, path[i].copath)
+	}
+	return parentHash
+}
+
+// verifyParentHashes verifies that every non-blank parent node is parent-hash valid (https://www.rfc-editor.org/rfc/rfc9420.html#section-7.9.2).
+func (t *mlsTree) verifyParentHashes() error {
+	for x := uint32(1); x < uint32(len(t.nodes)); x += 2 {
+		if t.nodes[x].parent == nil {
+			continue
+		}
+		left, right := mlsLeft(x), mlsRight(x)
+		if !t.hasParentHash(left, t.parentHash(x, right)) && !t.hasParentHash(right, t.parentHash(x, left)) {
+			return errMLSInvalidTree
+		}
+	}
+	return nil
+}
+
+func (t *mlsTree) hasParentHash(x uint32, parentHash []byte) bool {
+	for _, y := range t.resolution(x) {
+		var nodeParentHash []byte
+		if node := t.nodes[y]; node.leaf != nil {
+			nodeParentHash = node.leaf.parentHash
+		} else {
+			nodeParentHash = node.parent.parentHash
+		}
+		if bytes.Equal(nodeParentHash, parentHash) {
+			return true
+		}
+	}
+	return false
+}
+
+// verifyLeaves verifies the signatures of all leaves & that all encryption keys are unique.
+func (t *mlsTree) verifyLeaves(groupID []byte) error {
+	keys := map[string]struct{}{}
+	for i, node := range t.nodes {
+		if key := node.encryptionKey(); key != nil {
+			if _, ok := keys[string(key)]; ok {
+				return errMLSInvalidTree
+			}
+			keys[string(key)] = struct{}{}
+		}
+		if node.leaf == nil {
+			continue
+		}
+		if err := node.leaf.verify(groupID, uint32(i)/2); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// bytes returns the ratchet_tree extension. Trailing blank nodes are omitted.
+func (t *mlsTree) bytes() []byte {
+	end := len(t.nodes)
+	for end > 0 && t.nodes[end-1].blank() {
+		end--
+	}
+	w := &mlsWriter{}
+	w.vector(func(w *mlsWriter) {
+		for _, node := range t.nodes[:end] {
+			w.optional(!node.blank(), func(w *mlsWriter) {
+				if node.leaf != nil {
+					w.u8(mlsNodeTypeLeaf)
+					node.leaf.write(w)
+					return
+				}
+				w.u8(mlsNodeTypeParent)
+				node.parent.write(w, nil)
+			})
+		}
+	})
+	return w.b
+}
+
+func parseMLSTree(b []byte) (*mlsTree, error) {
+	r := newMLSReader(b)
+	var nodes []mlsNode
+	r.vector(func(r *mlsReader) {
+		var node mlsNode
+		r.optional(func(r *mlsReader) {
+			nodeType := r.u8()
+			switch {
+			case nodeType == mlsNodeTypeLeaf && len(nodes)%2 == 0:
+				node.leaf = readMLSLeafNode(r)
+			case nodeType == mlsNodeTypeParent && len(nodes)%2 == 1:
+				node.parent = &mlsParentNode{
+					encryptionKey: r.opaque(),
+					parentHash:    r.opaque(),
+				}
+				r.vector(func(r *mlsReader) {
+					node.parent.unmergedLeaves = append(node.parent.unmergedLeaves, r.u32())
+				})
+			default:
+				r.fail()
+			}
+		})
+		nodes = append(nodes, node)
+	})
+	if err := r.end(); err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 || nodes[len(nodes)-1].blank() {
+		return nil, errMLSInvalidTree
+	}
+
+	width := 1
+	for width < len(nodes) {
+		width = 2*width + 1
+	}
+	t := &mlsTree{nodes: append(nodes, make([]mlsNode, width-len(nodes))...)}
+
+	// unmerged leaves must be non-blank descendants of the node
+	for x, node := range t.nodes {
+		if node.parent == nil {
+			continue
+		}
+		if !slices.IsSorted(node.parent.unmergedLeaves) {
+			return nil, errMLSInvalidTree
+		}
+		for _, leaf := range node.parent.unmergedLeaves {
+			if leaf >= t.leafCount() || t.nodes[2*leaf].leaf == nil || !mlsInSubtree(uint32(x), 2*leaf) {
+				return nil, errMLSInvalidTree
+			}
+		}
+	}
+	return t, nil
+}
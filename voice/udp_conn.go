@@ -12,8 +12,6 @@ import (
 	"strings"
 	"sync"
 	"time"
-
-	"golang.org/x/crypto/nacl/secretbox"
 )
 
 const (
@@ -24,8 +22,12 @@ const (
 	UDPTimeout = 30 * time.Second
 )
 
-// ErrDecryptionFailed is returned when the packet decryption fails.
-var ErrDecryptionFailed = errors.New("decryption failed")
+var (
+	// ErrDecryptionFailed is returned when the packet decryption fails.
+	ErrDecryptionFailed = errors.New("decryption failed")
+	// ErrNoSecretKey is returned when packets are sent or received before the secret key was set.
+	ErrNoSecretKey = errors.New("no secret key set")
+)
 
 var (
 	_ io.Reader      = (UDPConn)(nil)
@@ -48,8 +50,9 @@ type (
 		// RemoteAddr returns the remote network address, if known.
 		RemoteAddr() net.Addr
 
-		// SetSecretKey sets the secret key used to encrypt packets.
-		SetSecretKey(secretKey [32]byte)
+		// SetSecretKey sets the EncryptionMode & secret key used to encrypt & decrypt packets.
+		// It returns ErrUnsupportedEncryptionMode if the EncryptionMode is not one of the SupportedEncryptionModes.
+		SetSecretKey(mode EncryptionMode, secretKey [32]byte) error
 
 		SetDeadline(t time.Time) error
 
@@ -106,14 +109,14 @@ type udpConnImpl struct {
 	conn   net.Conn
 	connMu sync.Mutex
 
-	packet    [12]byte
-	secretKey [32]byte
+	packet   [12]byte
+	cipher   packetCipher
+	cipherMu sync.Mutex
 
-	sequence  uint16
-	timestamp uint32
-	nonce     [24]byte
+	sequence   uint16
+	timestamp  uint32
+	sendBuffer []byte
 
-	receiveNonce  [24]byte
 	receiveBuffer []byte
 }
 
@@ -129,8 +132,21 @@ func (u *udpConnImpl) RemoteAddr() net.Addr {
 	return u.conn.RemoteAddr()
 }
 
-func (u *udpConnImpl) SetSecretKey(secretKey [32]byte) {
-	u.secretKey = secretKey
+func (u *udpConnImpl) SetSecretKey(mode EncryptionMode, secretKey [32]byte) error {
+	packetCipher, err := newPacketCipher(mode, secretKey)
+	if err != nil {
+		return err
+	}
+	u.cipherMu.Lock()
+	defer u.cipherMu.Unlock()
+	u.cipher = packetCipher
+	return nil
+}
+
+func (u *udpConnImpl) getCipher() packetCipher {
+	u.cipherMu.Lock()
+	defer u.cipherMu.Unlock()
+	return u.cipher
 }
 
 func (u *udpConnImpl) SetDeadline(t time.Time) error {
@@ -225,13 +241,16 @@ func (u *udpConnImpl) Write(p []byte) (int, error) {
 	binary.BigEndian.PutUint32(u.packet[4:8], u.timestamp)
 	u.timestamp += 960
 
-	// Copy the first 12 bytes from the packet into the nonce.
-	copy(u.nonce[:12], u.packet[:])
+	packetCipher := u.getCipher()
+	if packetCipher == nil {
+		return 0, ErrNoSecretKey
+	}
+	u.sendBuffer = packetCipher.seal(u.sendBuffer[:0], u.packet[:], p)
 
 	u.connMu.Lock()
 	conn := u.conn
 	u.connMu.Unlock()
-	if _, err := conn.Write(secretbox.Seal(u.packet[:], p, &u.nonce, &u.secretKey)); err != nil {
+	if _, err := conn.Write(u.sendBuffer); err != nil {
 		return 0, fmt.Errorf("failed to write packet: %w", err)
 	}
 	return len(p), nil
@@ -259,23 +278,13 @@ func (u *udpConnImpl) ReadPacket() (*Packet, error) {
 			continue
 		}
 
-		copy(u.receiveNonce[:], u.receiveBuffer[0:OpusPacketHeaderSize])
-
-		opus, ok := secretbox.Open(nil, u.receiveBuffer[OpusPacketHeaderSize:i], &u.receiveNonce, &u.secretKey)
-		if !ok {
-			return nil, ErrDecryptionFailed
+		packetCipher := u.getCipher()
+		if packetCipher == nil {
+			return nil, ErrNoSecretKey
 		}
-
-		isExtension := u.receiveBuffer[0]&0x10 == 0x10
-		isMarker := u.receiveBuffer[1]&0x80 != 0x0
-
-		if isExtension && !isMarker {
-			extLen := binary.BigEndian.Uint16(opus[2:4])
-			shift := 4 + 4*int(extLen)
-
-			if len(opus) > shift {
-				opus = opus[shift:]
-			}
+		opus, err := packetCipher.open(u.receiveBuffer[:i])
+		if err != nil {
+			return nil, err
 		}
 		return &Packet{
 			Sequence:  binary.BigEndian.Uint16(u.receiveBuffer[2:4]),
@@ -0,0 +1,402 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/rest"
+)
+
+var (
+	// ErrTargetDisabled is returned for targets whose webhooks were deleted
+	ErrTargetDisabled = errors.New("fan out target is disabled")
+	// ErrUnknownBroadcast is returned when a broadcast is not tracked by the FanOut
+	ErrUnknownBroadcast = errors.New("unknown broadcast")
+	// ErrUnknownTarget is returned for targets which were removed from the FanOut
+	ErrUnknownTarget = errors.New("unknown fan out target")
+)
+
+// FanOutTarget is a single destination of a FanOut.
+type FanOutTarget struct {
+	// Name identifies the target
+	Name string
+	// Client is the primary webhook of the target
+	Client Client
+	// Failover is an optional secondary webhook which is used when sending via the primary webhook fails because of a transport error, a server error (5xx) or because the primary webhook was deleted
+	Failover Client
+}
+
+// FanOutResult is the result of a single target of a broadcast.
+type FanOutResult struct {
+	Target string
+	// Message is the sent or updated message. It's nil for deletes & failed requests.
+	Message *discord.Message
+	// Failover is true when the Failover webhook was used
+	Failover bool
+	Err      error
+	// PrimaryErr is the error of the primary webhook when the Failover webhook was used
+	PrimaryErr error
+}
+
+// FanOutTargetStatus is the status of a FanOutTarget.
+type FanOutTargetStatus struct {
+	Name string
+	// PrimaryDisabled is true when the primary webhook was deleted
+	PrimaryDisabled bool
+	// Disabled is true when no webhook of the target can be used anymore
+	Disabled bool
+}
+
+// FanOut sends messages to many webhooks with bounded concurrency.
+// Webhooks which were deleted are disabled automatically & the message IDs of broadcasts are tracked, so updates & deletes are fanned out too.
+type FanOut interface {
+	// AddTarget adds or replaces the FanOutTarget with the same name.
+	AddTarget(target FanOutTarget)
+	// RemoveTarget removes the FanOutTarget with the given name.
+	RemoveTarget(name string)
+	// Targets returns the status of all FanOutTarget(s).
+	Targets() []FanOutTargetStatus
+
+	// CreateMessage sends the discord.WebhookMessageCreate to all enabled targets & returns the ID of the broadcast to update or delete it later.
+	CreateMessage(ctx context.Context, messageCreate discord.WebhookMessageCreate) (snowflake.ID, []FanOutResult)
+	// UpdateMessage updates the messages of the broadcast in all targets which received it.
+	UpdateMessage(ctx context.Context, broadcastID snowflake.ID, messageUpdate discord.WebhookMessageUpdate) ([]FanOutResult, error)
+	// DeleteMessage deletes the messages of the broadcast in all targets which received it & stops tracking it.
+	// Messages which were already deleted in a target are treated as deleted successfully.
+	DeleteMessage(ctx context.Context, broadcastID snowflake.ID) ([]FanOutResult, error)
+
+	// Close closes the Client(s) of all targets.
+	Close(ctx context.Context)
+}
+
+// NewFanOut returns a new FanOut with the given FanOutTarget(s) & FanOutConfigOpt(s).
+func NewFanOut(targets []FanOutTarget, opts ...FanOutConfigOpt) FanOut {
+	config := DefaultFanOutConfig()
+	config.Apply(opts)
+	config.Logger = config.Logger.With(slog.String("name", "webhook_fan_out"))
+
+	f := &fanOutImpl{
+		config:     *config,
+		targets:    map[string]*fanOutTarget{},
+		broadcasts: map[snowflake.ID]map[string]sentMessage{},
+	}
+	for _, target := range targets {
+		f.AddTarget(target)
+	}
+	return f
+}
+
+type fanOutTarget struct {
+	FanOutTarget
+	primaryDisabled  bool
+	failoverDisabled bool
+}
+
+type sentMessage struct {
+	messageID snowflake.ID
+	failover  bool
+}
+
+type fanOutImpl struct {
+	config   FanOutConfig
+	sequence atomic.Uint64
+
+	mu             sync.Mutex
+	targets        map[string]*fanOutTarget
+	broadcasts     map[snowflake.ID]map[string]sentMessage
+	broadcastOrder []snowflake.ID
+}
+
+func (f *fanOutImpl) AddTarget(target FanOutTarget) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.targets[target.Name] = &fanOutTarget{FanOutTarget: target}
+}
+
+func (f *fanOutImpl) RemoveTarget(name string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.targets, name)
+}
+
+func (f *fanOutImpl) Targets() []FanOutTargetStatus {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	statuses := make([]FanOutTargetStatus, 0, len(f.targets))
+	for _, target := range f.targets {
+		statuses = append(statuses, FanOutTargetStatus{
+			Name:            target.Name,
+			PrimaryDisabled: target.primaryDisabled,
+			Disabled:        target.primaryDisabled && (target.Failover == nil || target.failoverDisabled),
+		})
+	}
+	slices.SortFunc(statuses, func(a, b FanOutTargetStatus) int {
+		if a.Name < b.Name {
+			return -1
+		}
+		if a.Name > b.Name {
+			return 1
+		}
+		return 0
+	})
+	return statuses
+}
+
+func (f *fanOutImpl) CreateMessage(ctx context.Context, messageCreate discord.WebhookMessageCreate) (snowflake.ID, []FanOutResult) {
+	f.mu.Lock()
+	names := make([]string, 0, len(f.targets))
+	for name := range f.targets {
+		names = append(names, name)
+	}
+	f.mu.Unlock()
+	slices.Sort(names)
+
+	var (
+		broadcastID = snowflake.New(time.Now()) | snowflake.ID(f.sequence.Add(1)&0x3FFFFF)
+		sentMu      sync.Mutex
+		sent        = map[string]sentMessage{}
+	)
+	results := f.fanOut(ctx, names, func(target *fanOutTarget, client Client, failover bool) (*discord.Message, error) {
+		message, err := client.CreateMessage(messageCreate, rest.WithCtx(ctx))
+		if err == nil {
+			sentMu.Lock()
+			sent[target.Name] = sentMessage{messageID: message.ID, failover: failover}
+			sentMu.Unlock()
+		}
+		return message, err
+	}, nil)
+
+	f.track(broadcastID, sent)
+	return broadcastID, results
+}
+
+func (f *fanOutImpl) UpdateMessage(ctx context.Context, broadcastID snowflake.ID, messageUpdate discord.WebhookMessageUpdate) ([]FanOutResult, error) {
+	sent, err := f.broadcast(broadcastID)
+	if err != nil {
+		return nil, err
+	}
+	return f.fanOutSent(ctx, sent, func(client Client, messageID snowflake.ID) (*discord.Message, error) {
+		return client.UpdateMessage(messageID, messageUpdate, rest.WithCtx(ctx))
+	}), nil
+}
+
+func (f *fanOutImpl) DeleteMessage(ctx context.Context, broadcastID snowflake.ID) ([]FanOutResult, error) {
+	sent, err := f.broadcast(broadcastID)
+	if err != nil {
+		return nil, err
+	}
+	results := f.fanOutSent(ctx, sent, func(client Client, messageID snowflake.ID) (*discord.Message, error) {
+		if err := client.DeleteMessage(messageID, rest.WithCtx(ctx)); err != nil && !isRestErrorCode(err, rest.JSONErrorCodeUnknownMessage) {
+			return nil, err
+		}
+		return nil, nil
+	})
+
+	f.mu.Lock()
+	delete(f.broadcasts, broadcastID)
+	f.broadcastOrder = slices.DeleteFunc(f.broadcastOrder, func(id snowflake.ID) bool { return id == broadcastID })
+	f.mu.Unlock()
+	return results, nil
+}
+
+func (f *fanOutImpl) Close(ctx context.Context) {
+	// closing flushes the queues of the clients, so f.mu must not be held while in-flight broadcasts handle their errors
+	f.mu.Lock()
+	clients := make([]Client, 0, len(f.targets))
+	for _, target := range f.targets {
+		clients = append(clients, target.Client)
+		if target.Failover != nil {
+			clients = append(clients, target.Failover)
+		}
+	}
+	f.mu.Unlock()
+
+	for _, client := range clients {
+		client.Close(ctx)
+	}
+}
+
+// fanOutSent calls the function for all targets which received the broadcast with the webhook which sent the message.
+func (f *fanOutImpl) fanOutSent(ctx context.Context, sent map[string]sentMessage, do func(client Client, messageID snowflake.ID) (*discord.Message, error)) []FanOutResult {
+	names := make([]string, 0, len(sent))
+	for name := range sent {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	return f.fanOut(ctx, names, func(target *fanOutTarget, client Client, failover bool) (*discord.Message, error) {
+		return do(client, sent[target.Name].messageID)
+	}, sent)
+}
+
+// fanOut calls the function for all targets with at most MaxConcurrency concurrent calls.
+// If sent is nil, the Failover webhook is used when the primary webhook fails & shouldFailover returns true.
+// Otherwise, the webhook which sent the message of the target is used.
+func (f *fanOutImpl) fanOut(ctx context.Context, names []string, do func(target *fanOutTarget, client Client, failover bool) (*discord.Message, error), sent map[string]sentMessage) []FanOutResult {
+	var (
+		results   = make([]FanOutResult, len(names))
+		semaphore = make(chan struct{}, f.config.MaxConcurrency)
+		wg        sync.WaitGroup
+	)
+	for i, name := range names {
+		result := &results[i]
+		result.Target = name
+
+		f.mu.Lock()
+		target, ok := f.targets[name]
+		f.mu.Unlock()
+		if !ok {
+			result.Err = ErrUnknownTarget
+			continue
+		}
+
+		select {
+		case semaphore <- struct{}{}:
+		case <-ctx.Done():
+			result.Err = ctx.Err()
+			continue
+		}
+		wg.Add(1)
+		go func(name string) {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+
+			if sent != nil {
+				useFailover := sent[name].failover
+				client, err := f.client(target, useFailover)
+				if err != nil {
+					result.Err = err
+					return
+				}
+				result.Failover = useFailover
+				result.Message, result.Err = do(target, client, useFailover)
+				f.handleError(target, useFailover, result.Err)
+				return
+			}
+
+			if client, err := f.client(target, false); err == nil {
+				result.Message, result.Err = do(target, client, false)
+				f.handleError(target, false, result.Err)
+				if result.Err == nil || !shouldFailover(result.Err) {
+					return
+				}
+			} else {
+				result.Err = err
+			}
+
+			client, err := f.client(target, true)
+			if err != nil {
+				// keep the error of the primary webhook if there is no usable failover
+				return
+			}
+			result.Failover = true
+			result.PrimaryErr = result.Err
+			result.Message, result.Err = do(target, client, true)
+			f.handleError(target, true, result.Err)
+		}(name)
+	}
+	wg.Wait()
+	return results
+}
+
+// client returns the primary or failover Client of the target or ErrTargetDisabled.
+func (f *fanOutImpl) client(target *fanOutTarget, failover bool) (Client, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !failover {
+		if target.primaryDisabled {
+			return nil, ErrTargetDisabled
+		}
+		return target.Client, nil
+	}
+	if target.Failover == nil || target.failoverDisabled {
+		return nil, ErrTargetDisabled
+	}
+	return target.Failover, nil
+}
+
+// handleError disables the webhook of the target if it was deleted.
+func (f *fanOutImpl) handleError(target *fanOutTarget, failover bool, err error) {
+	if !isUnknownWebhook(err) {
+		return
+	}
+	f.config.Logger.Warn("disabling deleted webhook", slog.String("target", target.Name), slog.Bool("failover", failover))
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if failover {
+		target.failoverDisabled = true
+	} else {
+		target.primaryDisabled = true
+	}
+}
+
+func (f *fanOutImpl) broadcast(broadcastID snowflake.ID) (map[string]sentMessage, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	sent, ok := f.broadcasts[broadcastID]
+	if !ok {
+		return nil, ErrUnknownBroadcast
+	}
+	return sent, nil
+}
+
+func (f *fanOutImpl) track(broadcastID snowflake.ID, sent map[string]sentMessage) {
+	if f.config.MaxTrackedMessages <= 0 || len(sent) == 0 {
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.broadcasts[broadcastID] = sent
+	f.broadcastOrder = append(f.broadcastOrder, broadcastID)
+	for len(f.broadcastOrder) > f.config.MaxTrackedMessages {
+		delete(f.broadcasts, f.broadcastOrder[0])
+		f.broadcastOrder = f.broadcastOrder[1:]
+	}
+}
+
+// isRestErrorCode returns whether the error is a rest.Error with the given JSONErrorCode.
+func isRestErrorCode(err error, code rest.JSONErrorCode) bool {
+	var restErr rest.Error
+	return errors.As(err, &restErr) && restErr.Code == code
+}
+
+// shouldFailover returns whether the Failover webhook should be used after the primary webhook failed with the error.
+// This is the case for transport errors, server errors (5xx) & deleted webhooks. Invalid requests & done contexts would fail the same way with the Failover webhook.
+func shouldFailover(err error) bool {
+	if errors.Is(err, ErrTargetDisabled) || isUnknownWebhook(err) {
+		return true
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var restErr rest.Error
+	if errors.As(err, &restErr) {
+		return restErr.Response != nil && restErr.Response.StatusCode >= http.StatusInternalServerError
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
+
+// isUnknownWebhook returns whether the error means the webhook was deleted.
+func isUnknownWebhook(err error) bool {
+	var restErr rest.Error
+	if !errors.As(err, &restErr) {
+		return false
+	}
+	if restErr.Code != 0 {
+		return restErr.Code == rest.JSONErrorCodeUnknownWebhook
+	}
+	return restErr.Response != nil && restErr.Response.StatusCode == http.StatusNotFound
+}
@@ -0,0 +1,57 @@
+package webhook
+
+import (
+	"log/slog"
+)
+
+// DefaultFanOutConfig is the default configuration for the FanOut
+func DefaultFanOutConfig() *FanOutConfig {
+	return &FanOutConfig{
+		Logger:             slog.Default(),
+		MaxConcurrency:     10,
+		MaxTrackedMessages: 100,
+	}
+}
+
+// FanOutConfig is the configuration for the FanOut
+type FanOutConfig struct {
+	Logger *slog.Logger
+	// MaxConcurrency is the maximum amount of requests sent at the same time
+	MaxConcurrency int
+	// MaxTrackedMessages is the maximum amount of broadcasts whose message IDs are remembered for updates & deletes
+	MaxTrackedMessages int
+}
+
+// FanOutConfigOpt is used to provide optional parameters to the FanOut
+type FanOutConfigOpt func(config *FanOutConfig)
+
+// Apply applies all options to the config
+func (c *FanOutConfig) Apply(opts []FanOutConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.MaxConcurrency < 1 {
+		c.MaxConcurrency = 1
+	}
+}
+
+// WithFanOutLogger sets the logger for the FanOut
+func WithFanOutLogger(logger *slog.Logger) FanOutConfigOpt {
+	return func(config *FanOutConfig) {
+		config.Logger = logger
+	}
+}
+
+// WithMaxConcurrency sets the maximum amount of requests sent at the same time
+func WithMaxConcurrency(maxConcurrency int) FanOutConfigOpt {
+	return func(config *FanOutConfig) {
+		config.MaxConcurrency = maxConcurrency
+	}
+}
+
+// WithMaxTrackedMessages sets the maximum amount of broadcasts whose message IDs are remembered for updates & deletes
+func WithMaxTrackedMessages(maxTrackedMessages int) FanOutConfigOpt {
+	return func(config *FanOutConfig) {
+		config.MaxTrackedMessages = maxTrackedMessages
+	}
+}
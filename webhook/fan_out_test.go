@@ -0,0 +1,177 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/rest"
+)
+
+func newFanOutTestServer(t *testing.T, status int, body string) (*httptest.Server, *atomic.Int32) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.Method == http.MethodDelete && status == http.StatusOK {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func newFanOutTestClient(server *httptest.Server) Client {
+	return New(snowflake.ID(1), "token", WithRestClientConfigOpts(rest.WithURL(server.URL)))
+}
+
+func TestFanOut(t *testing.T) {
+	ok, okRequests := newFanOutTestServer(t, http.StatusOK, `{"id":"10","channel_id":"1"}`)
+	deleted, deletedRequests := newFanOutTestServer(t, http.StatusNotFound, `{"code":10015,"message":"Unknown Webhook"}`)
+	failover, failoverRequests := newFanOutTestServer(t, http.StatusOK, `{"id":"20","channel_id":"1"}`)
+
+	fanOut := NewFanOut([]FanOutTarget{
+		{Name: "a", Client: newFanOutTestClient(ok)},
+		{Name: "b", Client: newFanOutTestClient(deleted), Failover: newFanOutTestClient(failover)},
+		{Name: "c", Client: newFanOutTestClient(deleted)},
+	}, WithMaxConcurrency(2))
+
+	broadcastID, results := fanOut.CreateMessage(context.Background(), discord.WebhookMessageCreate{Content: "hello"})
+	if assert.Len(t, results, 3) {
+		assert.NoError(t, results[0].Err)
+		assert.Equal(t, snowflake.ID(10), results[0].Message.ID)
+
+		assert.NoError(t, results[1].Err)
+		assert.True(t, results[1].Failover)
+		assert.Equal(t, snowflake.ID(20), results[1].Message.ID)
+
+		assert.Error(t, results[2].Err)
+	}
+	assert.Equal(t, []FanOutTargetStatus{
+		{Name: "a"},
+		{Name: "b", PrimaryDisabled: true},
+		{Name: "c", PrimaryDisabled: true, Disabled: true},
+	}, fanOut.Targets())
+
+	// disabled webhooks are not called anymore
+	_, results = fanOut.CreateMessage(context.Background(), discord.WebhookMessageCreate{Content: "hello"})
+	if assert.Len(t, results, 3) {
+		assert.ErrorIs(t, results[2].Err, ErrTargetDisabled)
+	}
+	assert.Equal(t, int32(2), deletedRequests.Load())
+
+	// updates are only sent to targets which received the message via the webhook which sent it
+	results, err := fanOut.UpdateMessage(context.Background(), broadcastID, discord.NewWebhookMessageUpdateBuilder().SetContent("updated").Build())
+	assert.NoError(t, err)
+	if assert.Len(t, results, 2) {
+		assert.Equal(t, "a", results[0].Target)
+		assert.Equal(t, "b", results[1].Target)
+		assert.True(t, results[1].Failover)
+	}
+	assert.Equal(t, int32(3), okRequests.Load())
+	assert.Equal(t, int32(3), failoverRequests.Load())
+
+	results, err = fanOut.DeleteMessage(context.Background(), broadcastID)
+	assert.NoError(t, err)
+	for _, result := range results {
+		assert.NoError(t, result.Err)
+	}
+
+	_, err = fanOut.DeleteMessage(context.Background(), broadcastID)
+	assert.ErrorIs(t, err, ErrUnknownBroadcast)
+}
+
+func TestFanOut_MaxTrackedMessages(t *testing.T) {
+	ok, _ := newFanOutTestServer(t, http.StatusOK, `{"id":"10","channel_id":"1"}`)
+	fanOut := NewFanOut([]FanOutTarget{{Name: "a", Client: newFanOutTestClient(ok)}}, WithMaxTrackedMessages(1))
+
+	first, _ := fanOut.CreateMessage(context.Background(), discord.WebhookMessageCreate{Content: "first"})
+	second, _ := fanOut.CreateMessage(context.Background(), discord.WebhookMessageCreate{Content: "second"})
+
+	_, err := fanOut.UpdateMessage(context.Background(), first, discord.NewWebhookMessageUpdateBuilder().SetContent("updated").Build())
+	assert.ErrorIs(t, err, ErrUnknownBroadcast)
+	_, err = fanOut.UpdateMessage(context.Background(), second, discord.NewWebhookMessageUpdateBuilder().SetContent("updated").Build())
+	assert.NoError(t, err)
+}
+
+func TestFanOut_DeleteUnknownMessage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"code":10008,"message":"Unknown Message"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"10","channel_id":"1"}`))
+	}))
+	defer server.Close()
+	fanOut := NewFanOut([]FanOutTarget{{Name: "a", Client: newFanOutTestClient(server)}})
+
+	broadcastID, _ := fanOut.CreateMessage(context.Background(), discord.WebhookMessageCreate{Content: "hello"})
+	// the message was already deleted in discord
+	results, err := fanOut.DeleteMessage(context.Background(), broadcastID)
+	assert.NoError(t, err)
+	if assert.Len(t, results, 1) {
+		assert.NoError(t, results[0].Err)
+	}
+	assert.Equal(t, []FanOutTargetStatus{{Name: "a"}}, fanOut.Targets())
+}
+
+func TestFanOut_FailoverConditions(t *testing.T) {
+	invalid, _ := newFanOutTestServer(t, http.StatusBadRequest, `{"code":50006,"message":"Cannot send an empty message"}`)
+	serverError, _ := newFanOutTestServer(t, http.StatusBadGateway, `{"message":"Bad Gateway"}`)
+	unreachable, _ := newFanOutTestServer(t, http.StatusOK, `{}`)
+	unreachable.Close()
+	failover, failoverRequests := newFanOutTestServer(t, http.StatusOK, `{"id":"20","channel_id":"1"}`)
+
+	fanOut := NewFanOut([]FanOutTarget{
+		{Name: "invalid", Client: newFanOutTestClient(invalid), Failover: newFanOutTestClient(failover)},
+		{Name: "server-error", Client: newFanOutTestClient(serverError), Failover: newFanOutTestClient(failover)},
+		{Name: "unreachable", Client: newFanOutTestClient(unreachable), Failover: newFanOutTestClient(failover)},
+	})
+
+	_, results := fanOut.CreateMessage(context.Background(), discord.WebhookMessageCreate{Content: "hello"})
+	if assert.Len(t, results, 3) {
+		// invalid requests would fail the same way with the failover webhook
+		assert.False(t, results[0].Failover)
+		assert.True(t, isRestErrorCode(results[0].Err, 50006))
+		assert.NoError(t, results[0].PrimaryErr)
+
+		for _, result := range results[1:] {
+			assert.True(t, result.Failover, result.Target)
+			assert.NoError(t, result.Err, result.Target)
+			assert.Error(t, result.PrimaryErr, result.Target)
+		}
+	}
+	assert.Equal(t, int32(2), failoverRequests.Load())
+
+}
+
+func TestShouldFailover(t *testing.T) {
+	restError := func(status int, body string) error {
+		return rest.NewError(nil, nil, &http.Response{StatusCode: status}, []byte(body))
+	}
+	transportError := &url.Error{Op: "Post", URL: "https://discord.com", Err: errors.New("connection refused")}
+
+	assert.True(t, shouldFailover(ErrTargetDisabled))
+	assert.True(t, shouldFailover(restError(http.StatusNotFound, `{"code":10015,"message":"Unknown Webhook"}`)))
+	assert.True(t, shouldFailover(restError(http.StatusServiceUnavailable, "")))
+	assert.True(t, shouldFailover(fmt.Errorf("error doing request in rest client: %w", transportError)))
+
+	assert.False(t, shouldFailover(restError(http.StatusBadRequest, `{"code":50006,"message":"Cannot send an empty message"}`)))
+	assert.False(t, shouldFailover(restError(http.StatusTooManyRequests, "")))
+	assert.False(t, shouldFailover(fmt.Errorf("error locking bucket in rest client: %w", context.Canceled)))
+	assert.False(t, shouldFailover(&url.Error{Op: "Post", URL: "https://discord.com", Err: context.DeadlineExceeded}))
+}
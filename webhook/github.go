@@ -0,0 +1,321 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/disgoorg/json"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+// ErrUnsupportedGitHubEvent is returned by ParseGitHubEvent for GitHub events which can't be translated
+var ErrUnsupportedGitHubEvent = errors.New("unsupported github event")
+
+// Colors used for translated GitHub events
+const (
+	GitHubColorPush   = 0x7289DA
+	GitHubColorOpen   = 0x238636
+	GitHubColorClosed = 0xDA3633
+	GitHubColorMerged = 0x8957E5
+	GitHubColorOther  = 0x6E7681
+)
+
+// maxGitHubCommits is the maximum amount of commits listed for a push
+const maxGitHubCommits = 5
+
+// GitHubEvent is a GitHub webhook event which can be translated into a discord.WebhookMessageCreate
+type GitHubEvent interface {
+	ToMessageCreate() discord.WebhookMessageCreate
+}
+
+// ParseGitHubEvent parses a GitHub webhook payload & translates it into a discord.WebhookMessageCreate.
+// The event name is sent by GitHub in the X-GitHub-Event header. Supported events are push, pull_request, issues & release.
+func ParseGitHubEvent(event string, data []byte) (discord.WebhookMessageCreate, error) {
+	var v GitHubEvent
+	switch event {
+	case "push":
+		v = &GitHubPushEvent{}
+	case "pull_request":
+		v = &GitHubPullRequestEvent{}
+	case "issues":
+		v = &GitHubIssuesEvent{}
+	case "release":
+		v = &GitHubReleaseEvent{}
+	default:
+		return discord.WebhookMessageCreate{}, fmt.Errorf("%w: %s", ErrUnsupportedGitHubEvent, event)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return discord.WebhookMessageCreate{}, fmt.Errorf("failed to unmarshal github %s event: %w", event, err)
+	}
+	return v.ToMessageCreate(), nil
+}
+
+// GitHubUser is a GitHub user
+type GitHubUser struct {
+	Login     string `json:"login"`
+	HTMLURL   string `json:"html_url"`
+	AvatarURL string `json:"avatar_url"`
+}
+
+// GitHubRepository is a GitHub repository
+type GitHubRepository struct {
+	FullName string `json:"full_name"`
+	HTMLURL  string `json:"html_url"`
+}
+
+// GitHubLabel is a label of a GitHub issue or pull request
+type GitHubLabel struct {
+	Name string `json:"name"`
+}
+
+// GitHubCommit is a commit of a GitHubPushEvent
+type GitHubCommit struct {
+	ID      string `json:"id"`
+	Message string `json:"message"`
+	URL     string `json:"url"`
+	Author  struct {
+		Name     string `json:"name"`
+		Username string `json:"username"`
+	} `json:"author"`
+}
+
+// GitHubPushEvent is sent when commits were pushed to a branch or tag (https://docs.github.com/en/webhooks/webhook-events-and-payloads#push)
+type GitHubPushEvent struct {
+	Ref        string           `json:"ref"`
+	Compare    string           `json:"compare"`
+	Created    bool             `json:"created"`
+	Deleted    bool             `json:"deleted"`
+	Forced     bool             `json:"forced"`
+	Commits    []GitHubCommit   `json:"commits"`
+	Repository GitHubRepository `json:"repository"`
+	Sender     GitHubUser       `json:"sender"`
+}
+
+func (e GitHubPushEvent) ToMessageCreate() discord.WebhookMessageCreate {
+	ref := strings.TrimPrefix(strings.TrimPrefix(e.Ref, "refs/heads/"), "refs/tags/")
+	embed := githubEmbed(e.Sender, GitHubColorPush)
+
+	switch {
+	case e.Deleted:
+		embed.Title = fmt.Sprintf("[%s] Branch %s was deleted", e.Repository.FullName, ref)
+		embed.URL = e.Repository.HTMLURL
+		embed.Color = GitHubColorClosed
+	case e.Created && len(e.Commits) == 0:
+		embed.Title = fmt.Sprintf("[%s] New branch created: %s", e.Repository.FullName, ref)
+		embed.URL = e.Compare
+	default:
+		commits := "commit"
+		if len(e.Commits) != 1 {
+			commits += "s"
+		}
+		force := ""
+		if e.Forced {
+			force = " (force-pushed)"
+		}
+		embed.Title = fmt.Sprintf("[%s:%s] %d new %s%s", e.Repository.FullName, ref, len(e.Commits), commits, force)
+		embed.URL = e.Compare
+
+		lines := make([]string, 0, min(len(e.Commits), maxGitHubCommits)+1)
+		for i, commit := range e.Commits {
+			if i == maxGitHubCommits {
+				lines = append(lines, fmt.Sprintf("… and %d more", len(e.Commits)-maxGitHubCommits))
+				break
+			}
+			message, _, _ := strings.Cut(commit.Message, "\n")
+			author := commit.Author.Username
+			if author == "" {
+				author = commit.Author.Name
+			}
+			lines = append(lines, fmt.Sprintf("[`%s`](%s) %s - %s", shortSHA(commit.ID), commit.URL, truncate(message, 50), author))
+		}
+		embed.Description = truncate(strings.Join(lines, "\n"), maxEmbedDescriptionLength)
+	}
+	embed.Title = truncate(embed.Title, maxEmbedTitleLength)
+
+	return githubMessageCreate(embed, "View Changes", embed.URL)
+}
+
+// GitHubPullRequest is a GitHub pull request
+type GitHubPullRequest struct {
+	Number  int           `json:"number"`
+	HTMLURL string        `json:"html_url"`
+	Title   string        `json:"title"`
+	Body    string        `json:"body"`
+	State   string        `json:"state"`
+	Merged  bool          `json:"merged"`
+	Draft   bool          `json:"draft"`
+	User    GitHubUser    `json:"user"`
+	Labels  []GitHubLabel `json:"labels"`
+	Head    struct {
+		Ref string `json:"ref"`
+	} `json:"head"`
+	Base struct {
+		Ref string `json:"ref"`
+	} `json:"base"`
+}
+
+// GitHubPullRequestEvent is sent when a pull request was opened, closed or changed (https://docs.github.com/en/webhooks/webhook-events-and-payloads#pull_request)
+type GitHubPullRequestEvent struct {
+	Action      string            `json:"action"`
+	PullRequest GitHubPullRequest `json:"pull_request"`
+	Repository  GitHubRepository  `json:"repository"`
+	Sender      GitHubUser        `json:"sender"`
+}
+
+func (e GitHubPullRequestEvent) ToMessageCreate() discord.WebhookMessageCreate {
+	action := e.Action
+	color := GitHubColorOther
+	switch {
+	case e.Action == "closed" && e.PullRequest.Merged:
+		action = "merged"
+		color = GitHubColorMerged
+	case e.Action == "closed":
+		color = GitHubColorClosed
+	case e.Action == "opened" || e.Action == "reopened" || e.Action == "ready_for_review":
+		color = GitHubColorOpen
+	}
+
+	embed := githubEmbed(e.Sender, color)
+	embed.Title = truncate(fmt.Sprintf("[%s] Pull request %s: #%d %s", e.Repository.FullName, githubAction(action), e.PullRequest.Number, e.PullRequest.Title), maxEmbedTitleLength)
+	embed.URL = e.PullRequest.HTMLURL
+	if e.Action == "opened" {
+		embed.Description = truncate(e.PullRequest.Body, 500)
+	}
+	appendField(&embed, "Branch", fmt.Sprintf("`%s` → `%s`", e.PullRequest.Head.Ref, e.PullRequest.Base.Ref), true)
+	if labels := githubLabels(e.PullRequest.Labels); labels != "" {
+		appendField(&embed, "Labels", labels, true)
+	}
+
+	return githubMessageCreate(embed, "View Pull Request", embed.URL)
+}
+
+// GitHubIssue is a GitHub issue
+type GitHubIssue struct {
+	Number  int           `json:"number"`
+	HTMLURL string        `json:"html_url"`
+	Title   string        `json:"title"`
+	Body    string        `json:"body"`
+	State   string        `json:"state"`
+	User    GitHubUser    `json:"user"`
+	Labels  []GitHubLabel `json:"labels"`
+}
+
+// GitHubIssuesEvent is sent when an issue was opened, closed or changed (https://docs.github.com/en/webhooks/webhook-events-and-payloads#issues)
+type GitHubIssuesEvent struct {
+	Action     string           `json:"action"`
+	Issue      GitHubIssue      `json:"issue"`
+	Repository GitHubRepository `json:"repository"`
+	Sender     GitHubUser       `json:"sender"`
+}
+
+func (e GitHubIssuesEvent) ToMessageCreate() discord.WebhookMessageCreate {
+	color := GitHubColorOther
+	switch e.Action {
+	case "opened", "reopened":
+		color = GitHubColorOpen
+	case "closed", "deleted":
+		color = GitHubColorClosed
+	}
+
+	embed := githubEmbed(e.Sender, color)
+	embed.Title = truncate(fmt.Sprintf("[%s] Issue %s: #%d %s", e.Repository.FullName, githubAction(e.Action), e.Issue.Number, e.Issue.Title), maxEmbedTitleLength)
+	embed.URL = e.Issue.HTMLURL
+	if e.Action == "opened" {
+		embed.Description = truncate(e.Issue.Body, 500)
+	}
+	if labels := githubLabels(e.Issue.Labels); labels != "" {
+		appendField(&embed, "Labels", labels, true)
+	}
+
+	return githubMessageCreate(embed, "View Issue", embed.URL)
+}
+
+// GitHubRelease is a GitHub release
+type GitHubRelease struct {
+	HTMLURL    string     `json:"html_url"`
+	TagName    string     `json:"tag_name"`
+	Name       string     `json:"name"`
+	Body       string     `json:"body"`
+	Draft      bool       `json:"draft"`
+	Prerelease bool       `json:"prerelease"`
+	Author     GitHubUser `json:"author"`
+}
+
+// GitHubReleaseEvent is sent when a release was published or changed (https://docs.github.com/en/webhooks/webhook-events-and-payloads#release)
+type GitHubReleaseEvent struct {
+	Action     string           `json:"action"`
+	Release    GitHubRelease    `json:"release"`
+	Repository GitHubRepository `json:"repository"`
+	Sender     GitHubUser       `json:"sender"`
+}
+
+func (e GitHubReleaseEvent) ToMessageCreate() discord.WebhookMessageCreate {
+	color := GitHubColorOther
+	if e.Action == "published" || e.Action == "released" {
+		color = GitHubColorOpen
+	}
+
+	name := e.Release.Name
+	if name == "" {
+		name = e.Release.TagName
+	}
+	if e.Release.Prerelease {
+		name += " (pre-release)"
+	}
+
+	embed := githubEmbed(e.Sender, color)
+	embed.Title = truncate(fmt.Sprintf("[%s] Release %s: %s", e.Repository.FullName, githubAction(e.Action), name), maxEmbedTitleLength)
+	embed.URL = e.Release.HTMLURL
+	if e.Action == "published" || e.Action == "released" {
+		embed.Description = truncate(e.Release.Body, maxEmbedDescriptionLength)
+	}
+
+	return githubMessageCreate(embed, "View Release", embed.URL)
+}
+
+func githubEmbed(sender GitHubUser, color int) discord.Embed {
+	return discord.Embed{
+		Color: color,
+		Author: &discord.EmbedAuthor{
+			Name:    sender.Login,
+			URL:     sender.HTMLURL,
+			IconURL: sender.AvatarURL,
+		},
+	}
+}
+
+func githubMessageCreate(embed discord.Embed, label string, url string) discord.WebhookMessageCreate {
+	messageCreate := discord.WebhookMessageCreate{
+		Username:        "GitHub",
+		Embeds:          []discord.Embed{embed},
+		AllowedMentions: &discord.AllowedMentions{},
+	}
+	if url != "" {
+		messageCreate.Components = []discord.ContainerComponent{
+			discord.NewActionRow(discord.NewLinkButton(label, url)),
+		}
+	}
+	return messageCreate
+}
+
+// githubAction converts actions like ready_for_review to ready for review.
+func githubAction(action string) string {
+	return strings.ReplaceAll(action, "_", " ")
+}
+
+func githubLabels(labels []GitHubLabel) string {
+	names := make([]string, len(labels))
+	for i, label := range labels {
+		names[i] = "`" + label.Name + "`"
+	}
+	return strings.Join(names, ", ")
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
@@ -0,0 +1,92 @@
+package webhook
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+func TestParseGitHubEvent_Push(t *testing.T) {
+	messageCreate, err := ParseGitHubEvent("push", []byte(`{
+		"ref": "refs/heads/main",
+		"compare": "https://github.com/disgoorg/disgo/compare/a...b",
+		"commits": [
+			{"id": "0123456789abcdef", "message": "fix bug\n\nlong description", "url": "https://github.com/disgoorg/disgo/commit/0123456789abcdef", "author": {"name": "Test", "username": "test"}},
+			{"id": "fedcba9876543210", "message": "add feature", "url": "https://github.com/disgoorg/disgo/commit/fedcba9876543210", "author": {"name": "Other"}}
+		],
+		"repository": {"full_name": "disgoorg/disgo", "html_url": "https://github.com/disgoorg/disgo"},
+		"sender": {"login": "test", "html_url": "https://github.com/test", "avatar_url": "https://github.com/test.png"}
+	}`))
+	assert.NoError(t, err)
+
+	if assert.Len(t, messageCreate.Embeds, 1) {
+		embed := messageCreate.Embeds[0]
+		assert.Equal(t, "[disgoorg/disgo:main] 2 new commits", embed.Title)
+		assert.Equal(t, "https://github.com/disgoorg/disgo/compare/a...b", embed.URL)
+		assert.Equal(t, "[`0123456`](https://github.com/disgoorg/disgo/commit/0123456789abcdef) fix bug - test\n[`fedcba9`](https://github.com/disgoorg/disgo/commit/fedcba9876543210) add feature - Other", embed.Description)
+		assert.Equal(t, "test", embed.Author.Name)
+	}
+	assert.Equal(t, []discord.ContainerComponent{
+		discord.NewActionRow(discord.NewLinkButton("View Changes", "https://github.com/disgoorg/disgo/compare/a...b")),
+	}, messageCreate.Components)
+}
+
+func TestParseGitHubEvent_PullRequest(t *testing.T) {
+	messageCreate, err := ParseGitHubEvent("pull_request", []byte(`{
+		"action": "closed",
+		"pull_request": {"number": 1, "html_url": "https://github.com/disgoorg/disgo/pull/1", "title": "Add feature", "merged": true, "head": {"ref": "feature"}, "base": {"ref": "main"}, "labels": [{"name": "enhancement"}]},
+		"repository": {"full_name": "disgoorg/disgo"},
+		"sender": {"login": "test"}
+	}`))
+	assert.NoError(t, err)
+
+	if assert.Len(t, messageCreate.Embeds, 1) {
+		embed := messageCreate.Embeds[0]
+		assert.Equal(t, "[disgoorg/disgo] Pull request merged: #1 Add feature", embed.Title)
+		assert.Equal(t, GitHubColorMerged, embed.Color)
+		if assert.Len(t, embed.Fields, 2) {
+			assert.Equal(t, "`feature` → `main`", embed.Fields[0].Value)
+			assert.Equal(t, "`enhancement`", embed.Fields[1].Value)
+		}
+	}
+}
+
+func TestParseGitHubEvent_Issues(t *testing.T) {
+	messageCreate, err := ParseGitHubEvent("issues", []byte(`{
+		"action": "opened",
+		"issue": {"number": 2, "html_url": "https://github.com/disgoorg/disgo/issues/2", "title": "Bug", "body": "it broke"},
+		"repository": {"full_name": "disgoorg/disgo"},
+		"sender": {"login": "test"}
+	}`))
+	assert.NoError(t, err)
+
+	if assert.Len(t, messageCreate.Embeds, 1) {
+		embed := messageCreate.Embeds[0]
+		assert.Equal(t, "[disgoorg/disgo] Issue opened: #2 Bug", embed.Title)
+		assert.Equal(t, "it broke", embed.Description)
+		assert.Equal(t, GitHubColorOpen, embed.Color)
+	}
+}
+
+func TestParseGitHubEvent_Release(t *testing.T) {
+	messageCreate, err := ParseGitHubEvent("release", []byte(`{
+		"action": "published",
+		"release": {"html_url": "https://github.com/disgoorg/disgo/releases/tag/v1.0.0", "tag_name": "v1.0.0", "body": "changelog"},
+		"repository": {"full_name": "disgoorg/disgo"},
+		"sender": {"login": "test"}
+	}`))
+	assert.NoError(t, err)
+
+	if assert.Len(t, messageCreate.Embeds, 1) {
+		embed := messageCreate.Embeds[0]
+		assert.Equal(t, "[disgoorg/disgo] Release published: v1.0.0", embed.Title)
+		assert.Equal(t, "changelog", embed.Description)
+	}
+}
+
+func TestParseGitHubEvent_Unsupported(t *testing.T) {
+	_, err := ParseGitHubEvent("ping", []byte(`{}`))
+	assert.ErrorIs(t, err, ErrUnsupportedGitHubEvent)
+}
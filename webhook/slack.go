@@ -0,0 +1,370 @@
+package webhook
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/disgoorg/json"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+// maxLinkButtonsPerRow is the maximum amount of link buttons in a single discord.ActionRowComponent
+const maxLinkButtonsPerRow = 5
+
+// SlackMessage is a Slack incoming webhook payload (https://api.slack.com/messaging/webhooks)
+type SlackMessage struct {
+	Text        string            `json:"text"`
+	Username    string            `json:"username"`
+	IconURL     string            `json:"icon_url"`
+	Blocks      []SlackBlock      `json:"blocks"`
+	Attachments []SlackAttachment `json:"attachments"`
+}
+
+// SlackBlock is a Slack Block Kit block (https://api.slack.com/reference/block-kit/blocks).
+// Only header, section, divider, image, context & actions blocks are translated.
+type SlackBlock struct {
+	Type      string         `json:"type"`
+	Text      *SlackText     `json:"text"`
+	Fields    []SlackText    `json:"fields"`
+	Accessory *SlackElement  `json:"accessory"`
+	Elements  []SlackElement `json:"elements"`
+	ImageURL  string         `json:"image_url"`
+	AltText   string         `json:"alt_text"`
+	Title     *SlackText     `json:"title"`
+}
+
+// SlackText is a Slack text object (https://api.slack.com/reference/block-kit/composition-objects#text)
+type SlackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// UnmarshalJSON also accepts plain strings which are used by mrkdwn & plain_text elements.
+func (t *SlackText) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		return json.Unmarshal(data, &t.Text)
+	}
+	type slackText SlackText
+	return json.Unmarshal(data, (*slackText)(t))
+}
+
+// SlackElement is a Slack block element (https://api.slack.com/reference/block-kit/block-elements)
+type SlackElement struct {
+	Type     string     `json:"type"`
+	Text     *SlackText `json:"text"`
+	URL      string     `json:"url"`
+	ImageURL string     `json:"image_url"`
+	AltText  string     `json:"alt_text"`
+}
+
+// SlackAttachment is a legacy Slack attachment (https://api.slack.com/reference/messaging/attachments)
+type SlackAttachment struct {
+	Color      string                 `json:"color"`
+	Pretext    string                 `json:"pretext"`
+	AuthorName string                 `json:"author_name"`
+	AuthorLink string                 `json:"author_link"`
+	AuthorIcon string                 `json:"author_icon"`
+	Title      string                 `json:"title"`
+	TitleLink  string                 `json:"title_link"`
+	Text       string                 `json:"text"`
+	Fields     []SlackAttachmentField `json:"fields"`
+	ImageURL   string                 `json:"image_url"`
+	ThumbURL   string                 `json:"thumb_url"`
+	Footer     string                 `json:"footer"`
+	FooterIcon string                 `json:"footer_icon"`
+	TS         json.RawMessage        `json:"ts"`
+	Blocks     []SlackBlock           `json:"blocks"`
+}
+
+// SlackAttachmentField is a field of a SlackAttachment
+type SlackAttachmentField struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+	Short bool   `json:"short"`
+}
+
+// ParseSlackMessage parses a Slack incoming webhook payload & translates it into a discord.WebhookMessageCreate.
+func ParseSlackMessage(data []byte) (discord.WebhookMessageCreate, error) {
+	var message SlackMessage
+	if err := json.Unmarshal(data, &message); err != nil {
+		return discord.WebhookMessageCreate{}, fmt.Errorf("failed to unmarshal slack message: %w", err)
+	}
+	return message.ToMessageCreate(), nil
+}
+
+// ToMessageCreate translates the SlackMessage into a discord.WebhookMessageCreate.
+// Blocks are rendered as embeds which are split at dividers, link buttons become discord.ButtonComponent(s) & attachments become an embed each.
+// Trailing embeds which exceed MaxEmbedsPerMessage or MaxEmbedsLength are dropped.
+// Slack mrkdwn is converted to Discord markdown & mentions are never pinged.
+func (m SlackMessage) ToMessageCreate() discord.WebhookMessageCreate {
+	messageCreate := discord.WebhookMessageCreate{
+		Username:        m.Username,
+		AvatarURL:       m.IconURL,
+		AllowedMentions: &discord.AllowedMentions{},
+	}
+
+	var content []string
+	if m.Text != "" {
+		content = append(content, slackMarkdown(m.Text))
+	}
+
+	r := &slackRenderer{}
+	r.renderBlocks(m.Blocks, 0)
+	for _, attachment := range m.Attachments {
+		if attachment.Pretext != "" {
+			content = append(content, slackMarkdown(attachment.Pretext))
+		}
+		r.renderAttachment(attachment)
+	}
+
+	messageCreate.Content = truncate(strings.Join(content, "\n"), 2000)
+	embeds := r.embeds
+	if len(embeds) > MaxEmbedsPerMessage {
+		embeds = embeds[:MaxEmbedsPerMessage]
+	}
+	if len(embeds) > 0 {
+		embeds[0] = fitEmbed(embeds[0])
+	}
+	for embedsLength(embeds) > MaxEmbedsLength {
+		embeds = embeds[:len(embeds)-1]
+	}
+	messageCreate.Embeds = embeds
+	messageCreate.Components = r.components()
+	return messageCreate
+}
+
+type slackRenderer struct {
+	embeds  []discord.Embed
+	current *discord.Embed
+	buttons []discord.InteractiveComponent
+}
+
+// embed returns the embed blocks are currently rendered into.
+func (r *slackRenderer) embed(color int) *discord.Embed {
+	if r.current == nil {
+		r.embeds = append(r.embeds, discord.Embed{Color: color})
+		r.current = &r.embeds[len(r.embeds)-1]
+	}
+	return r.current
+}
+
+func (r *slackRenderer) endEmbed() {
+	r.current = nil
+}
+
+func (r *slackRenderer) renderBlocks(blocks []SlackBlock, color int) {
+	for _, block := range blocks {
+		switch block.Type {
+		case "header":
+			if block.Text == nil {
+				continue
+			}
+			embed := r.embed(color)
+			if embed.Title != "" || embed.Description != "" {
+				r.endEmbed()
+				embed = r.embed(color)
+			}
+			embed.Title = truncate(block.Text.Text, maxEmbedTitleLength)
+
+		case "section":
+			embed := r.embed(color)
+			if block.Text != nil {
+				appendDescription(embed, slackTextMarkdown(*block.Text))
+			}
+			for _, field := range block.Fields {
+				appendField(embed, "\u200b", slackTextMarkdown(field), true)
+			}
+			if block.Accessory != nil {
+				switch block.Accessory.Type {
+				case "image":
+					embed.Thumbnail = &discord.EmbedResource{URL: block.Accessory.ImageURL}
+				case "button":
+					r.addButton(*block.Accessory)
+				}
+			}
+
+		case "image":
+			embed := r.embed(color)
+			if embed.Image != nil {
+				r.endEmbed()
+				embed = r.embed(color)
+			}
+			embed.Image = &discord.EmbedResource{URL: block.ImageURL}
+			if block.Title != nil && embed.Title == "" {
+				embed.Title = truncate(block.Title.Text, maxEmbedTitleLength)
+			}
+
+		case "context":
+			var (
+				texts   []string
+				iconURL string
+			)
+			for _, element := range block.Elements {
+				if element.Type == "image" {
+					if iconURL == "" {
+						iconURL = element.ImageURL
+					}
+					continue
+				}
+				if element.Text != nil {
+					texts = append(texts, slackMarkdown(element.Text.Text))
+				}
+			}
+			embed := r.embed(color)
+			embed.Footer = &discord.EmbedFooter{
+				Text:    truncate(strings.Join(texts, " | "), maxEmbedFooterLength),
+				IconURL: iconURL,
+			}
+
+		case "actions":
+			for _, element := range block.Elements {
+				if element.Type == "button" {
+					r.addButton(element)
+				}
+			}
+
+		case "divider":
+			r.endEmbed()
+		}
+	}
+}
+
+func (r *slackRenderer) renderAttachment(attachment SlackAttachment) {
+	r.endEmbed()
+	color := slackColor(attachment.Color)
+	embed := r.embed(color)
+	embed.Title = truncate(attachment.Title, maxEmbedTitleLength)
+	embed.URL = attachment.TitleLink
+	appendDescription(embed, slackMarkdown(attachment.Text))
+	if attachment.AuthorName != "" {
+		embed.Author = &discord.EmbedAuthor{
+			Name:    attachment.AuthorName,
+			URL:     attachment.AuthorLink,
+			IconURL: attachment.AuthorIcon,
+		}
+	}
+	for _, field := range attachment.Fields {
+		appendField(embed, field.Title, slackMarkdown(field.Value), field.Short)
+	}
+	if attachment.ImageURL != "" {
+		embed.Image = &discord.EmbedResource{URL: attachment.ImageURL}
+	}
+	if attachment.ThumbURL != "" {
+		embed.Thumbnail = &discord.EmbedResource{URL: attachment.ThumbURL}
+	}
+	if attachment.Footer != "" {
+		embed.Footer = &discord.EmbedFooter{
+			Text:    truncate(slackMarkdown(attachment.Footer), maxEmbedFooterLength),
+			IconURL: attachment.FooterIcon,
+		}
+	}
+	if ts, ok := slackTimestamp(attachment.TS); ok {
+		embed.Timestamp = &ts
+	}
+	r.renderBlocks(attachment.Blocks, color)
+	r.endEmbed()
+}
+
+// addButton adds a link button. Buttons without URL can't be handled by webhooks & are skipped.
+func (r *slackRenderer) addButton(element SlackElement) {
+	if element.URL == "" || element.Text == nil {
+		return
+	}
+	r.buttons = append(r.buttons, discord.NewLinkButton(truncate(element.Text.Text, 80), element.URL))
+}
+
+func (r *slackRenderer) components() []discord.ContainerComponent {
+	var components []discord.ContainerComponent
+	for i := 0; i < len(r.buttons) && len(components) < 5; i += maxLinkButtonsPerRow {
+		components = append(components, discord.NewActionRow(r.buttons[i:min(i+maxLinkButtonsPerRow, len(r.buttons))]...))
+	}
+	return components
+}
+
+func appendDescription(embed *discord.Embed, text string) {
+	if text == "" {
+		return
+	}
+	if embed.Description != "" {
+		text = embed.Description + "\n\n" + text
+	}
+	embed.Description = truncate(text, maxEmbedDescriptionLength)
+}
+
+func appendField(embed *discord.Embed, name string, value string, inline bool) {
+	if len(embed.Fields) >= maxEmbedFields {
+		return
+	}
+	if name == "" {
+		name = "\u200b"
+	}
+	if value == "" {
+		value = "\u200b"
+	}
+	embed.Fields = append(embed.Fields, discord.EmbedField{
+		Name:   truncate(name, maxEmbedFieldNameLength),
+		Value:  truncate(value, maxEmbedFieldValueLength),
+		Inline: &inline,
+	})
+}
+
+func slackTextMarkdown(text SlackText) string {
+	if text.Type == "plain_text" {
+		return text.Text
+	}
+	return slackMarkdown(text.Text)
+}
+
+var (
+	slackLinkRegex    = regexp.MustCompile(`<([^<>|]+)\|([^<>]+)>`)
+	slackURLRegex     = regexp.MustCompile(`<((?:https?|mailto):[^<>]+)>`)
+	slackSpecialRegex = regexp.MustCompile(`<!(here|channel|everyone)(?:\|[^<>]*)?>`)
+	slackBoldRegex    = regexp.MustCompile(`\*([^*\n]+)\*`)
+	slackStrikeRegex  = regexp.MustCompile(`~([^~\n]+)~`)
+	slackEscaper      = strings.NewReplacer("&amp;", "&", "&lt;", "<", "&gt;", ">")
+)
+
+// slackMarkdown converts Slack mrkdwn (https://api.slack.com/reference/surfaces/formatting) to Discord markdown.
+func slackMarkdown(text string) string {
+	text = slackSpecialRegex.ReplaceAllString(text, "@$1")
+	text = slackLinkRegex.ReplaceAllString(text, "[$2]($1)")
+	text = slackURLRegex.ReplaceAllString(text, "$1")
+	text = slackBoldRegex.ReplaceAllString(text, "**$1**")
+	text = slackStrikeRegex.ReplaceAllString(text, "~~$1~~")
+	return slackEscaper.Replace(text)
+}
+
+// slackColor converts the color of a SlackAttachment which is either a hex color or good, warning or danger.
+func slackColor(color string) int {
+	switch color {
+	case "good":
+		return 0x2EB886
+	case "warning":
+		return 0xDAA038
+	case "danger":
+		return 0xA30200
+	}
+	c, err := strconv.ParseInt(strings.TrimPrefix(color, "#"), 16, 32)
+	if err != nil {
+		return 0
+	}
+	return int(c)
+}
+
+// slackTimestamp parses the ts of a SlackAttachment which is an unix timestamp sent as number or string.
+func slackTimestamp(ts json.RawMessage) (time.Time, bool) {
+	ts = bytes.Trim(ts, `"`)
+	if len(ts) == 0 || string(ts) == "null" {
+		return time.Time{}, false
+	}
+	seconds, err := strconv.ParseFloat(string(ts), 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(0, int64(seconds*float64(time.Second))).UTC(), true
+}
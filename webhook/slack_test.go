@@ -0,0 +1,78 @@
+package webhook
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+func TestParseSlackMessage(t *testing.T) {
+	messageCreate, err := ParseSlackMessage([]byte(`{
+		"text": "Deploy *finished* <!here>",
+		"username": "deploy-bot",
+		"blocks": [
+			{"type": "header", "text": {"type": "plain_text", "text": "Deployment"}},
+			{"type": "section", "text": {"type": "mrkdwn", "text": "See <https://example.com|the logs> ~now~"}, "fields": [{"type": "mrkdwn", "text": "*Env*\nprod"}]},
+			{"type": "context", "elements": [{"type": "image", "image_url": "https://example.com/icon.png", "alt_text": "icon"}, {"type": "mrkdwn", "text": "by ci"}]},
+			{"type": "actions", "elements": [{"type": "button", "text": {"type": "plain_text", "text": "Open"}, "url": "https://example.com"}, {"type": "button", "text": {"type": "plain_text", "text": "Approve"}, "value": "approve"}]},
+			{"type": "divider"},
+			{"type": "image", "image_url": "https://example.com/graph.png", "alt_text": "graph"}
+		],
+		"attachments": [
+			{"color": "danger", "pretext": "Alerts", "title": "CPU", "title_link": "https://example.com/cpu", "text": "&gt; 90%", "fields": [{"title": "Host", "value": "a", "short": true}], "ts": 1700000000}
+		]
+	}`))
+	assert.NoError(t, err)
+
+	assert.Equal(t, "Deploy **finished** @here\nAlerts", messageCreate.Content)
+	assert.Equal(t, "deploy-bot", messageCreate.Username)
+	assert.Equal(t, &discord.AllowedMentions{}, messageCreate.AllowedMentions)
+
+	if assert.Len(t, messageCreate.Embeds, 3) {
+		embed := messageCreate.Embeds[0]
+		assert.Equal(t, "Deployment", embed.Title)
+		assert.Equal(t, "See [the logs](https://example.com) ~~now~~", embed.Description)
+		if assert.Len(t, embed.Fields, 1) {
+			assert.Equal(t, "**Env**\nprod", embed.Fields[0].Value)
+		}
+		assert.Equal(t, &discord.EmbedFooter{Text: "by ci", IconURL: "https://example.com/icon.png"}, embed.Footer)
+
+		assert.Equal(t, &discord.EmbedResource{URL: "https://example.com/graph.png"}, messageCreate.Embeds[1].Image)
+
+		embed = messageCreate.Embeds[2]
+		assert.Equal(t, 0xA30200, embed.Color)
+		assert.Equal(t, "CPU", embed.Title)
+		assert.Equal(t, "https://example.com/cpu", embed.URL)
+		assert.Equal(t, "> 90%", embed.Description)
+		if assert.NotNil(t, embed.Timestamp) {
+			assert.True(t, time.Unix(1700000000, 0).Equal(*embed.Timestamp))
+		}
+	}
+
+	// only link buttons are kept
+	assert.Equal(t, []discord.ContainerComponent{
+		discord.NewActionRow(discord.NewLinkButton("Open", "https://example.com")),
+	}, messageCreate.Components)
+}
+
+func TestSlackColor(t *testing.T) {
+	assert.Equal(t, 0x2EB886, slackColor("good"))
+	assert.Equal(t, 0x36A64F, slackColor("#36a64f"))
+	assert.Equal(t, 0, slackColor("invalid"))
+}
+
+func TestSlackMessage_EmbedsLength(t *testing.T) {
+	text := strings.Repeat("a", 2500)
+	message := SlackMessage{
+		Attachments: []SlackAttachment{{Title: "1", Text: text}, {Title: "2", Text: text}, {Title: "3", Text: text}},
+	}
+	messageCreate := message.ToMessageCreate()
+	if assert.Len(t, messageCreate.Embeds, 2) {
+		assert.Equal(t, "2", messageCreate.Embeds[1].Title)
+	}
+	assert.LessOrEqual(t, embedsLength(messageCreate.Embeds), MaxEmbedsLength)
+}
@@ -0,0 +1,224 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"slices"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+// Discord's embed limits (https://discord.com/developers/docs/resources/message#embed-object-embed-limits)
+const (
+	maxEmbedTitleLength       = 256
+	maxEmbedDescriptionLength = 4096
+	maxEmbedFields            = 25
+	maxEmbedFieldNameLength   = 256
+	maxEmbedFieldValueLength  = 1024
+	maxEmbedFooterLength      = 2048
+)
+
+var _ slog.Handler = (*SlogHandler)(nil)
+
+// queueContextKey marks the context.Context of the queue, so the SlogHandler can drop records logged by the queue.
+type queueContextKey struct{}
+
+// queueContext is the context.Context used for requests & logs of the queue.
+var queueContext = context.WithValue(context.Background(), queueContextKey{}, true)
+
+func isQueueContext(ctx context.Context) bool {
+	return ctx != nil && ctx.Value(queueContextKey{}) != nil
+}
+
+// NewSlogHandler returns a new SlogHandler which sends records via the given Client.
+// Records are formatted as discord.Embed(s) & queued with Client.QueueMessageInThread, so they are batched & rate limited by the queue of the Client.
+//
+// Records logged by the queue itself, like the errors of the default QueueErrorHandlerFunc, are dropped to not feed them back into the queue.
+// The Client should still not log through the SlogHandler: when installing it as slog.Default, pass a different logger to the Client with WithLogger.
+// Otherwise logs of the rest.Client, for example about rate limits, are queued from the goroutine which sends the queue & can block it when the queue is full.
+func NewSlogHandler(client Client, opts ...SlogHandlerConfigOpt) *SlogHandler {
+	config := DefaultSlogHandlerConfig()
+	config.Apply(opts)
+
+	return &SlogHandler{
+		client: client,
+		config: *config,
+	}
+}
+
+// SlogHandler is a slog.Handler which sends records to a Discord webhook.
+type SlogHandler struct {
+	client Client
+	config SlogHandlerConfig
+
+	// fields are the pre-formatted attrs passed to WithAttrs
+	fields []discord.EmbedField
+	// groups are the groups passed to WithGroup
+	groups []string
+}
+
+func (h *SlogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.config.Level.Level() && !isQueueContext(ctx)
+}
+
+func (h *SlogHandler) Handle(ctx context.Context, record slog.Record) error {
+	if isQueueContext(ctx) {
+		return nil
+	}
+
+	fields := slices.Clone(h.fields)
+	record.Attrs(func(attr slog.Attr) bool {
+		fields = appendAttr(fields, h.groups, attr)
+		return true
+	})
+
+	embed := discord.Embed{
+		Title:       truncate(record.Level.String(), maxEmbedTitleLength),
+		Description: truncate(record.Message, maxEmbedDescriptionLength),
+		Color:       levelValue(h.config.LevelColors, record.Level),
+	}
+	if !record.Time.IsZero() {
+		t := record.Time
+		embed.Timestamp = &t
+	}
+	if h.config.AddSource && record.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{record.PC}).Next()
+		embed.Footer = &discord.EmbedFooter{
+			Text: truncateLeft(frame.File+":"+strconv.Itoa(frame.Line), maxEmbedFooterLength),
+		}
+	}
+	if len(fields) > maxEmbedFields {
+		fields = append(fields[:maxEmbedFields-1], discord.EmbedField{
+			Name:  "…",
+			Value: fmt.Sprintf("%d more attributes", len(fields)-maxEmbedFields+1),
+		})
+	}
+	embed.Fields = fields
+
+	return h.client.QueueMessageInThread(ctx, discord.WebhookMessageCreate{
+		Username:  h.config.Username,
+		AvatarURL: h.config.AvatarURL,
+		Embeds:    []discord.Embed{fitEmbed(embed)},
+	}, levelValue(h.config.ThreadIDs, record.Level))
+}
+
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	h2 := *h
+	h2.fields = slices.Clip(h.fields)
+	for _, attr := range attrs {
+		h2.fields = appendAttr(h2.fields, h.groups, attr)
+	}
+	return &h2
+}
+
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	h2 := *h
+	h2.groups = append(slices.Clip(h.groups), name)
+	return &h2
+}
+
+// appendAttr appends the attr as discord.EmbedField. Groups are flattened into dotted field names.
+func appendAttr(fields []discord.EmbedField, groups []string, attr slog.Attr) []discord.EmbedField {
+	attr.Value = attr.Value.Resolve()
+	if attr.Equal(slog.Attr{}) {
+		return fields
+	}
+
+	if attr.Value.Kind() == slog.KindGroup {
+		groupAttrs := attr.Value.Group()
+		if len(groupAttrs) == 0 {
+			return fields
+		}
+		if attr.Key != "" {
+			groups = append(slices.Clip(groups), attr.Key)
+		}
+		for _, groupAttr := range groupAttrs {
+			fields = appendAttr(fields, groups, groupAttr)
+		}
+		return fields
+	}
+
+	name := attr.Key
+	for i := len(groups) - 1; i >= 0; i-- {
+		name = groups[i] + "." + name
+	}
+	if name == "" {
+		// discord rejects empty field names
+		name = "\u200b"
+	}
+
+	var value string
+	switch attr.Value.Kind() {
+	case slog.KindTime:
+		value = attr.Value.Time().Format(time.RFC3339)
+	default:
+		value = attr.Value.String()
+	}
+	if value == "" {
+		// discord rejects empty field values
+		value = "\u200b"
+	}
+
+	inline := utf8.RuneCountInString(value) <= 32
+	return append(fields, discord.EmbedField{
+		Name:   truncate(name, maxEmbedFieldNameLength),
+		Value:  truncate(value, maxEmbedFieldValueLength),
+		Inline: &inline,
+	})
+}
+
+// fitEmbed drops fields & truncates the description until the embed fits into a message.
+// Title, description & footer alone can exceed MaxEmbedsLength even when they are within their own limits.
+func fitEmbed(embed discord.Embed) discord.Embed {
+	for embed.Length() > MaxEmbedsLength && len(embed.Fields) > 0 {
+		embed.Fields = embed.Fields[:len(embed.Fields)-1]
+	}
+	if excess := embed.Length() - MaxEmbedsLength; excess > 0 {
+		embed.Description = truncate(embed.Description, max(utf8.RuneCountInString(embed.Description)-excess, 1))
+	}
+	return embed
+}
+
+// levelValue returns the value of the highest level lower or equal to the given level.
+func levelValue[T ~int | ~uint64](values map[slog.Level]T, level slog.Level) T {
+	var (
+		value T
+		found bool
+		best  slog.Level
+	)
+	for l, v := range values {
+		if l <= level && (!found || l > best) {
+			value, best, found = v, l, true
+		}
+	}
+	return value
+}
+
+// truncate truncates the string to the given amount of characters & marks it with an ellipsis.
+func truncate(s string, maxLength int) string {
+	if utf8.RuneCountInString(s) <= maxLength {
+		return s
+	}
+	runes := []rune(s)
+	return string(runes[:maxLength-1]) + "…"
+}
+
+// truncateLeft truncates the beginning of the string to the given amount of characters & marks it with an ellipsis.
+func truncateLeft(s string, maxLength int) string {
+	if utf8.RuneCountInString(s) <= maxLength {
+		return s
+	}
+	runes := []rune(s)
+	return "…" + string(runes[len(runes)-maxLength+1:])
+}
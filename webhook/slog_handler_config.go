@@ -0,0 +1,83 @@
+package webhook
+
+import (
+	"log/slog"
+
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// DefaultSlogHandlerConfig is the default configuration for the SlogHandler
+func DefaultSlogHandlerConfig() *SlogHandlerConfig {
+	return &SlogHandlerConfig{
+		Level: slog.LevelInfo,
+		LevelColors: map[slog.Level]int{
+			slog.LevelDebug: 0x95A5A6,
+			slog.LevelInfo:  0x3498DB,
+			slog.LevelWarn:  0xF1C40F,
+			slog.LevelError: 0xE74C3C,
+		},
+	}
+}
+
+// SlogHandlerConfig is the configuration for the SlogHandler
+type SlogHandlerConfig struct {
+	// Level is the minimum level of records which are sent
+	Level slog.Leveler
+	// AddSource adds the source code position of the log statement to the footer
+	AddSource bool
+	// ThreadIDs maps levels to the thread the records are sent in. Records are sent in the thread of the highest configured level lower or equal to their level.
+	ThreadIDs map[slog.Level]snowflake.ID
+	// LevelColors maps levels to the embed color. Records use the color of the highest configured level lower or equal to their level.
+	LevelColors map[slog.Level]int
+	Username    string
+	AvatarURL   string
+}
+
+// SlogHandlerConfigOpt is used to provide optional parameters to the SlogHandler
+type SlogHandlerConfigOpt func(config *SlogHandlerConfig)
+
+// Apply applies all options to the config
+func (c *SlogHandlerConfig) Apply(opts []SlogHandlerConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithSlogLevel sets the minimum level of records which are sent
+func WithSlogLevel(level slog.Leveler) SlogHandlerConfigOpt {
+	return func(config *SlogHandlerConfig) {
+		config.Level = level
+	}
+}
+
+// WithSlogAddSource adds the source code position of the log statement to the footer
+func WithSlogAddSource(addSource bool) SlogHandlerConfigOpt {
+	return func(config *SlogHandlerConfig) {
+		config.AddSource = addSource
+	}
+}
+
+// WithSlogThreadID sends records of the given level & above in the given thread
+func WithSlogThreadID(level slog.Level, threadID snowflake.ID) SlogHandlerConfigOpt {
+	return func(config *SlogHandlerConfig) {
+		if config.ThreadIDs == nil {
+			config.ThreadIDs = map[slog.Level]snowflake.ID{}
+		}
+		config.ThreadIDs[level] = threadID
+	}
+}
+
+// WithSlogLevelColor sets the embed color of records of the given level & above
+func WithSlogLevelColor(level slog.Level, color int) SlogHandlerConfigOpt {
+	return func(config *SlogHandlerConfig) {
+		config.LevelColors[level] = color
+	}
+}
+
+// WithSlogUsername sets the username & avatar url of the sent messages
+func WithSlogUsername(username string, avatarURL string) SlogHandlerConfigOpt {
+	return func(config *SlogHandlerConfig) {
+		config.Username = username
+		config.AvatarURL = avatarURL
+	}
+}
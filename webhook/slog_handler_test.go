@@ -0,0 +1,135 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/rest"
+)
+
+func TestSlogHandler(t *testing.T) {
+	server, messages := newTestServer(t)
+	client := New(snowflake.ID(1), "token", WithRestClientConfigOpts(rest.WithURL(server.URL)))
+
+	logger := slog.New(NewSlogHandler(client, WithSlogLevel(slog.LevelWarn), WithSlogUsername("logger", "")))
+	logger.Info("not sent")
+	logger.With("service", "api").WithGroup("request").Error("request failed",
+		slog.String("method", "GET"),
+		slog.Group("user", slog.Int("id", 1)),
+		slog.Any("err", errors.New("boom")),
+		slog.String("body", strings.Repeat("a", 2000)),
+	)
+	assert.NoError(t, client.Flush(context.Background()))
+
+	sent := messages()
+	if !assert.Len(t, sent, 1) || !assert.Len(t, sent[0].Embeds, 1) {
+		return
+	}
+	assert.Equal(t, "logger", sent[0].Username)
+
+	embed := sent[0].Embeds[0]
+	assert.Equal(t, "ERROR", embed.Title)
+	assert.Equal(t, "request failed", embed.Description)
+	assert.Equal(t, 0xE74C3C, embed.Color)
+
+	fields := map[string]string{}
+	for _, field := range embed.Fields {
+		fields[field.Name] = field.Value
+	}
+	assert.Equal(t, "api", fields["service"])
+	assert.Equal(t, "GET", fields["request.method"])
+	assert.Equal(t, "1", fields["request.user.id"])
+	assert.Equal(t, "boom", fields["request.err"])
+	assert.Len(t, []rune(fields["request.body"]), maxEmbedFieldValueLength)
+}
+
+func TestLevelValue(t *testing.T) {
+	threadIDs := map[slog.Level]snowflake.ID{
+		slog.LevelWarn:  1,
+		slog.LevelError: 2,
+	}
+	assert.Equal(t, snowflake.ID(0), levelValue(threadIDs, slog.LevelInfo))
+	assert.Equal(t, snowflake.ID(1), levelValue(threadIDs, slog.LevelWarn))
+	assert.Equal(t, snowflake.ID(1), levelValue(threadIDs, slog.LevelWarn+2))
+	assert.Equal(t, snowflake.ID(2), levelValue(threadIDs, slog.LevelError+4))
+
+	assert.Equal(t, "a…", truncate("abc", 2))
+	assert.Equal(t, "…c", truncateLeft("abc", 2))
+}
+
+func TestFitEmbed(t *testing.T) {
+	field := discord.EmbedField{Name: "key", Value: strings.Repeat("b", maxEmbedFieldValueLength)}
+	embed := fitEmbed(discord.Embed{
+		Title:       strings.Repeat("t", maxEmbedTitleLength),
+		Description: strings.Repeat("d", maxEmbedDescriptionLength),
+		Footer:      &discord.EmbedFooter{Text: strings.Repeat("f", maxEmbedFooterLength)},
+		Fields:      []discord.EmbedField{field, field},
+	})
+	assert.Equal(t, MaxEmbedsLength, embed.Length())
+	assert.Empty(t, embed.Fields)
+	assert.Len(t, []rune(embed.Footer.Text), maxEmbedFooterLength)
+	assert.True(t, strings.HasSuffix(embed.Description, "…"))
+
+	embed = fitEmbed(discord.Embed{
+		Description: strings.Repeat("d", 3000),
+		Fields:      []discord.EmbedField{field, field, field, field},
+	})
+	assert.Len(t, embed.Fields, 2)
+	assert.Len(t, embed.Description, 3000)
+}
+
+// forwardHandler forwards records to a slog.Handler which is set after the logger was created.
+type forwardHandler struct {
+	handler *slog.Handler
+}
+
+func (h forwardHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return (*h.handler).Enabled(ctx, level)
+}
+
+func (h forwardHandler) Handle(ctx context.Context, record slog.Record) error {
+	return (*h.handler).Handle(ctx, record)
+}
+
+func (h forwardHandler) WithAttrs(_ []slog.Attr) slog.Handler { return h }
+
+func (h forwardHandler) WithGroup(_ string) slog.Handler { return h }
+
+func TestSlogHandler_QueueErrors(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	var handler slog.Handler
+	forward := forwardHandler{handler: &handler}
+	client := New(snowflake.ID(1), "token",
+		WithRestClientConfigOpts(rest.WithURL(server.URL), rest.WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil)))),
+		WithLogger(slog.New(forward)),
+		WithQueueSize(1),
+	)
+	handler = NewSlogHandler(client)
+
+	// the error of the failed message must not be queued again
+	assert.NoError(t, client.QueueMessage(context.Background(), discord.WebhookMessageCreate{Content: "fails"}))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	assert.NoError(t, client.Flush(ctx))
+	assert.Equal(t, int32(1), requests.Load())
+
+	assert.False(t, handler.Enabled(queueContext, slog.LevelError))
+}
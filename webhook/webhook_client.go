@@ -20,7 +20,7 @@ type Client interface {
 	Token() string
 	// URL returns the full Webhook URL
 	URL() string
-	// Close closes all connections the Webhook Client has open
+	// Close sends all queued messages & closes all connections the Webhook Client has open
 	Close(ctx context.Context)
 	// Rest returns the underlying rest.Webhooks
 	Rest() rest.Webhooks
@@ -41,6 +41,17 @@ type Client interface {
 	// CreateEmbeds creates a new Message from the provided discord.Embed(s)
 	CreateEmbeds(embeds []discord.Embed, opts ...rest.RequestOpt) (*discord.Message, error)
 
+	// QueueMessage queues the discord.WebhookMessageCreate to be sent by a background goroutine, which respects the rate limit of the Webhook.
+	// Queued messages only containing embeds are merged into a single message up to MaxEmbedsPerMessage & MaxEmbedsLength.
+	// Errors while sending are passed to the QueueErrorHandlerFunc.
+	QueueMessage(ctx context.Context, messageCreate discord.WebhookMessageCreate) error
+	// QueueMessageInThread queues the discord.WebhookMessageCreate to be sent in the provided thread. See QueueMessage for more information.
+	QueueMessageInThread(ctx context.Context, messageCreate discord.WebhookMessageCreate, threadID snowflake.ID) error
+	// QueueEmbeds queues the provided discord.Embed(s). See QueueMessage for more information.
+	QueueEmbeds(ctx context.Context, embeds ...discord.Embed) error
+	// Flush sends all queued messages & waits until they were sent or the context.Context is done
+	Flush(ctx context.Context) error
+
 	// UpdateMessage updates an already sent Webhook Message with the discord.WebhookMessageUpdate
 	UpdateMessage(messageID snowflake.ID, messageUpdate discord.WebhookMessageUpdate, opts ...rest.RequestOpt) (*discord.Message, error)
 	// UpdateMessageInThread updates an already sent Webhook Message with the discord.WebhookMessageUpdate in the provided thread
@@ -43,6 +43,7 @@ func New(id snowflake.ID, token string, opts ...ConfigOpt) Client {
 		id:     id,
 		token:  token,
 		config: *config,
+		queue:  newMessageQueue(config.Webhooks, id, token, *config),
 	}
 }
 
@@ -50,6 +51,7 @@ type clientImpl struct {
 	id     snowflake.ID
 	token  string
 	config Config
+	queue  *messageQueue
 }
 
 func (c *clientImpl) ID() snowflake.ID {
@@ -65,6 +67,9 @@ func (c *clientImpl) URL() string {
 }
 
 func (c *clientImpl) Close(ctx context.Context) {
+	if err := c.queue.close(ctx); err != nil {
+		c.config.Logger.Error("failed to flush webhook queue", slog.Any("err", err))
+	}
 	c.config.RestClient.Close(ctx)
 }
 
@@ -108,6 +113,22 @@ func (c *clientImpl) CreateEmbeds(embeds []discord.Embed, opts ...rest.RequestOp
 	return c.CreateMessage(discord.WebhookMessageCreate{Embeds: embeds}, opts...)
 }
 
+func (c *clientImpl) QueueMessage(ctx context.Context, messageCreate discord.WebhookMessageCreate) error {
+	return c.QueueMessageInThread(ctx, messageCreate, 0)
+}
+
+func (c *clientImpl) QueueMessageInThread(ctx context.Context, messageCreate discord.WebhookMessageCreate, threadID snowflake.ID) error {
+	return c.queue.enqueue(ctx, queueItem{messageCreate: messageCreate, threadID: threadID})
+}
+
+func (c *clientImpl) QueueEmbeds(ctx context.Context, embeds ...discord.Embed) error {
+	return c.QueueMessage(ctx, discord.WebhookMessageCreate{Embeds: embeds})
+}
+
+func (c *clientImpl) Flush(ctx context.Context) error {
+	return c.queue.flushQueue(ctx)
+}
+
 func (c *clientImpl) UpdateMessage(messageID snowflake.ID, messageUpdate discord.WebhookMessageUpdate, opts ...rest.RequestOpt) (*discord.Message, error) {
 	return c.UpdateMessageInThread(messageID, messageUpdate, 0, opts...)
 }
@@ -2,16 +2,25 @@ package webhook
 
 import (
 	"log/slog"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
 
 	"github.com/disgoorg/disgo/discord"
 	"github.com/disgoorg/disgo/rest"
 )
 
+// DefaultQueueSize is the default maximum amount of queued messages
+const DefaultQueueSize = 1000
+
 // DefaultConfig is the default configuration for the webhook client
 func DefaultConfig() *Config {
 	return &Config{
 		Logger:                 slog.Default(),
 		DefaultAllowedMentions: &discord.DefaultAllowedMentions,
+		QueueSize:              DefaultQueueSize,
+		QueueBatchWindow:       time.Second,
+		QueueFullPolicy:        QueueFullPolicyBlock,
 	}
 }
 
@@ -22,6 +31,15 @@ type Config struct {
 	RestClientConfigOpts   []rest.ConfigOpt
 	Webhooks               rest.Webhooks
 	DefaultAllowedMentions *discord.AllowedMentions
+
+	// QueueSize is the maximum amount of queued messages. Values <= 0 fall back to DefaultQueueSize
+	QueueSize int
+	// QueueBatchWindow is how long queued embeds are collected before they are sent. Negative values are treated as 0, which disables batching
+	QueueBatchWindow time.Duration
+	// QueueFullPolicy decides what happens when a message is queued while the queue is full
+	QueueFullPolicy QueueFullPolicy
+	// QueueErrorHandler is called when a queued message could not be sent or was dropped
+	QueueErrorHandler QueueErrorHandlerFunc
 }
 
 // ConfigOpt is used to provide optional parameters to the webhook client
@@ -38,6 +56,18 @@ func (c *Config) Apply(opts []ConfigOpt) {
 	if c.Webhooks == nil {
 		c.Webhooks = rest.NewWebhooks(c.RestClient)
 	}
+	if c.QueueSize <= 0 {
+		c.QueueSize = DefaultQueueSize
+	}
+	if c.QueueBatchWindow < 0 {
+		c.QueueBatchWindow = 0
+	}
+	if c.QueueErrorHandler == nil {
+		logger := c.Logger
+		c.QueueErrorHandler = func(_ discord.WebhookMessageCreate, _ snowflake.ID, err error) {
+			logger.ErrorContext(queueContext, "failed to send queued webhook message", slog.Any("err", err))
+		}
+	}
 }
 
 // WithLogger sets the logger for the webhook client
@@ -74,3 +104,31 @@ func WithDefaultAllowedMentions(allowedMentions discord.AllowedMentions) ConfigO
 		config.DefaultAllowedMentions = &allowedMentions
 	}
 }
+
+// WithQueueSize sets the maximum amount of queued messages. Values <= 0 fall back to DefaultQueueSize
+func WithQueueSize(queueSize int) ConfigOpt {
+	return func(config *Config) {
+		config.QueueSize = queueSize
+	}
+}
+
+// WithQueueBatchWindow sets how long queued embeds are collected before they are sent
+func WithQueueBatchWindow(batchWindow time.Duration) ConfigOpt {
+	return func(config *Config) {
+		config.QueueBatchWindow = batchWindow
+	}
+}
+
+// WithQueueFullPolicy sets what happens when a message is queued while the queue is full
+func WithQueueFullPolicy(policy QueueFullPolicy) ConfigOpt {
+	return func(config *Config) {
+		config.QueueFullPolicy = policy
+	}
+}
+
+// WithQueueErrorHandler sets the function which is called when a queued message could not be sent or was dropped
+func WithQueueErrorHandler(errorHandler QueueErrorHandlerFunc) ConfigOpt {
+	return func(config *Config) {
+		config.QueueErrorHandler = errorHandler
+	}
+}
@@ -0,0 +1,298 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/rest"
+)
+
+const (
+	// MaxEmbedsPerMessage is the maximum amount of discord.Embed(s) in a single message
+	MaxEmbedsPerMessage = 10
+	// MaxEmbedsLength is the maximum amount of characters of all discord.Embed(s) in a single message
+	MaxEmbedsLength = 6000
+)
+
+var (
+	// ErrQueueFull is returned when a message is queued while the queue is full & the QueueFullPolicy drops messages
+	ErrQueueFull = errors.New("webhook queue is full")
+	// ErrQueueClosed is returned when a message is queued after the Client was closed
+	ErrQueueClosed = errors.New("webhook queue is closed")
+)
+
+// QueueFullPolicy decides what happens when a message is queued while the queue is full.
+type QueueFullPolicy int
+
+const (
+	// QueueFullPolicyBlock blocks until there is space in the queue or the context.Context is done
+	QueueFullPolicyBlock QueueFullPolicy = iota
+	// QueueFullPolicyDropNewest rejects the new message with ErrQueueFull
+	QueueFullPolicyDropNewest
+	// QueueFullPolicyDropOldest drops the oldest queued message & passes it to the QueueErrorHandlerFunc with ErrQueueFull
+	QueueFullPolicyDropOldest
+)
+
+// QueueErrorHandlerFunc is called when a queued message could not be sent or was dropped.
+type QueueErrorHandlerFunc func(messageCreate discord.WebhookMessageCreate, threadID snowflake.ID, err error)
+
+type queueItem struct {
+	messageCreate discord.WebhookMessageCreate
+	threadID      snowflake.ID
+}
+
+// mergeable returns whether the message only consists of embeds & can be merged with other messages.
+func (i queueItem) mergeable() bool {
+	m := i.messageCreate
+	return len(m.Embeds) > 0 && m.Content == "" && !m.TTS && len(m.Components) == 0 && len(m.Attachments) == 0 &&
+		len(m.Files) == 0 && m.ThreadName == "" && len(m.AppliedTags) == 0 && m.Poll == nil
+}
+
+func embedsLength(embeds []discord.Embed) int {
+	var length int
+	for _, embed := range embeds {
+		length += embed.Length()
+	}
+	return length
+}
+
+func newMessageQueue(webhooks rest.Webhooks, id snowflake.ID, token string, config Config) *messageQueue {
+	ctx, cancel := context.WithCancel(queueContext)
+	return &messageQueue{
+		webhooks:     webhooks,
+		id:           id,
+		token:        token,
+		size:         config.QueueSize,
+		batchWindow:  config.QueueBatchWindow,
+		policy:       config.QueueFullPolicy,
+		errorHandler: config.QueueErrorHandler,
+		logger:       config.Logger,
+		ctx:          ctx,
+		cancel:       cancel,
+		notify:       make(chan struct{}, 1),
+		space:        make(chan struct{}),
+		flush:        make(chan chan struct{}),
+	}
+}
+
+// messageQueue sends queued messages one after another in a background goroutine, so the rate limit bucket of the webhook is respected.
+type messageQueue struct {
+	webhooks     rest.Webhooks
+	id           snowflake.ID
+	token        string
+	size         int
+	batchWindow  time.Duration
+	policy       QueueFullPolicy
+	errorHandler QueueErrorHandlerFunc
+	logger       *slog.Logger
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	once   sync.Once
+	notify chan struct{}
+	flush  chan chan struct{}
+
+	mu     sync.Mutex
+	items  []queueItem
+	space  chan struct{}
+	closed bool
+}
+
+func (q *messageQueue) enqueue(ctx context.Context, item queueItem) error {
+	q.once.Do(func() {
+		go q.run()
+	})
+
+	for {
+		q.mu.Lock()
+		if q.closed {
+			q.mu.Unlock()
+			return ErrQueueClosed
+		}
+		if len(q.items) < q.size {
+			q.items = append(q.items, item)
+			q.mu.Unlock()
+			q.signal()
+			return nil
+		}
+
+		switch q.policy {
+		case QueueFullPolicyDropNewest:
+			q.mu.Unlock()
+			return ErrQueueFull
+
+		case QueueFullPolicyDropOldest:
+			dropped := q.items[0]
+			q.items = append(q.items[1:], item)
+			q.mu.Unlock()
+			q.signal()
+			q.errorHandler(dropped.messageCreate, dropped.threadID, ErrQueueFull)
+			return nil
+		}
+
+		space := q.space
+		q.mu.Unlock()
+		select {
+		case <-space:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (q *messageQueue) signal() {
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+}
+
+// flushQueue sends all queued messages & waits until they were sent or the context.Context is done.
+func (q *messageQueue) flushQueue(ctx context.Context) error {
+	q.once.Do(func() {
+		go q.run()
+	})
+
+	done := make(chan struct{})
+	select {
+	case q.flush <- done:
+	case <-q.ctx.Done():
+		return ErrQueueClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// close rejects new messages, flushes the queue & stops the background goroutine.
+// Messages which could not be sent until the context.Context is done are dropped.
+func (q *messageQueue) close(ctx context.Context) error {
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		return nil
+	}
+	q.closed = true
+	q.mu.Unlock()
+
+	err := q.flushQueue(ctx)
+	q.cancel()
+	return err
+}
+
+func (q *messageQueue) run() {
+loop:
+	for {
+		select {
+		case <-q.notify:
+		case done := <-q.flush:
+			q.drain()
+			close(done)
+			continue
+		case <-q.ctx.Done():
+			return
+		}
+
+		// collect more messages to merge them
+		timer := time.NewTimer(q.batchWindow)
+	collect:
+		for !q.batchFull() {
+			select {
+			case <-timer.C:
+				break collect
+			case <-q.notify:
+			case done := <-q.flush:
+				timer.Stop()
+				q.drain()
+				close(done)
+				continue loop
+			case <-q.ctx.Done():
+				timer.Stop()
+				return
+			}
+		}
+		timer.Stop()
+		q.drain()
+	}
+}
+
+// batchFull returns whether enough embeds are queued to fill a message.
+func (q *messageQueue) batchFull() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	var embeds int
+	for _, item := range q.items {
+		if !item.mergeable() {
+			return true
+		}
+		embeds += len(item.messageCreate.Embeds)
+		if embeds >= MaxEmbedsPerMessage {
+			return true
+		}
+	}
+	return false
+}
+
+func (q *messageQueue) drain() {
+	for {
+		item, ok := q.pop()
+		if !ok {
+			return
+		}
+		if q.ctx.Err() != nil {
+			q.errorHandler(item.messageCreate, item.threadID, q.ctx.Err())
+			continue
+		}
+		if _, err := q.webhooks.CreateWebhookMessage(q.id, q.token, item.messageCreate, true, item.threadID, rest.WithCtx(q.ctx)); err != nil {
+			q.errorHandler(item.messageCreate, item.threadID, err)
+		}
+	}
+}
+
+// pop removes the next message from the queue & merges the embeds of following mergeable messages into it.
+func (q *messageQueue) pop() (queueItem, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.items) == 0 {
+		return queueItem{}, false
+	}
+
+	item := q.items[0]
+	q.items = q.items[1:]
+	if item.mergeable() {
+		length := embedsLength(item.messageCreate.Embeds)
+		for len(q.items) > 0 {
+			next := q.items[0]
+			nextLength := embedsLength(next.messageCreate.Embeds)
+			if !next.mergeable() ||
+				next.threadID != item.threadID ||
+				next.messageCreate.Username != item.messageCreate.Username ||
+				next.messageCreate.AvatarURL != item.messageCreate.AvatarURL ||
+				next.messageCreate.Flags != item.messageCreate.Flags ||
+				len(item.messageCreate.Embeds)+len(next.messageCreate.Embeds) > MaxEmbedsPerMessage ||
+				length+nextLength > MaxEmbedsLength {
+				break
+			}
+			item.messageCreate.Embeds = append(slices.Clip(item.messageCreate.Embeds), next.messageCreate.Embeds...)
+			length += nextLength
+			q.items = q.items[1:]
+		}
+	}
+
+	// wake up all blocked enqueue calls
+	close(q.space)
+	q.space = make(chan struct{})
+	return item, true
+}
@@ -0,0 +1,129 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/json"
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/rest"
+)
+
+func newTestServer(t *testing.T) (*httptest.Server, func() []discord.WebhookMessageCreate) {
+	var (
+		mu       sync.Mutex
+		messages []discord.WebhookMessageCreate
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var messageCreate discord.WebhookMessageCreate
+		assert.NoError(t, json.Unmarshal(body, &messageCreate))
+		mu.Lock()
+		messages = append(messages, messageCreate)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"1","channel_id":"1"}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, func() []discord.WebhookMessageCreate {
+		mu.Lock()
+		defer mu.Unlock()
+		return messages
+	}
+}
+
+func TestClient_QueueEmbeds(t *testing.T) {
+	server, messages := newTestServer(t)
+	client := New(snowflake.ID(1), "token", WithRestClientConfigOpts(rest.WithURL(server.URL)), WithQueueBatchWindow(50*time.Millisecond))
+
+	for i := 0; i < 15; i++ {
+		assert.NoError(t, client.QueueEmbeds(context.Background(), discord.Embed{Title: strconv.Itoa(i)}))
+	}
+	// embeds exceeding the length limit are not merged
+	assert.NoError(t, client.QueueEmbeds(context.Background(), discord.Embed{Description: strings.Repeat("a", MaxEmbedsLength)}))
+	assert.NoError(t, client.QueueMessage(context.Background(), discord.WebhookMessageCreate{Content: "not mergeable"}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	client.Close(ctx)
+
+	sent := messages()
+	if assert.Len(t, sent, 4) {
+		assert.Len(t, sent[0].Embeds, 10)
+		assert.Equal(t, "0", sent[0].Embeds[0].Title)
+		assert.Len(t, sent[1].Embeds, 5)
+		assert.Len(t, sent[2].Embeds, 1)
+		assert.Equal(t, "not mergeable", sent[3].Content)
+	}
+	assert.ErrorIs(t, client.QueueEmbeds(context.Background(), discord.Embed{Title: "closed"}), ErrQueueClosed)
+}
+
+func TestClient_QueueFullPolicy(t *testing.T) {
+	server, messages := newTestServer(t)
+
+	var dropped []discord.WebhookMessageCreate
+	client := New(snowflake.ID(1), "token",
+		WithRestClientConfigOpts(rest.WithURL(server.URL)),
+		WithQueueBatchWindow(time.Hour),
+		WithQueueSize(2),
+		WithQueueFullPolicy(QueueFullPolicyDropOldest),
+		WithQueueErrorHandler(func(messageCreate discord.WebhookMessageCreate, _ snowflake.ID, err error) {
+			assert.ErrorIs(t, err, ErrQueueFull)
+			dropped = append(dropped, messageCreate)
+		}),
+	)
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, client.QueueEmbeds(context.Background(), discord.Embed{Title: strconv.Itoa(i)}))
+	}
+	assert.NoError(t, client.Flush(context.Background()))
+	if assert.Len(t, dropped, 1) {
+		assert.Equal(t, "0", dropped[0].Embeds[0].Title)
+	}
+	if assert.Len(t, messages(), 1) {
+		assert.Len(t, messages()[0].Embeds, 2)
+	}
+
+	client = New(snowflake.ID(1), "token",
+		WithRestClientConfigOpts(rest.WithURL(server.URL)),
+		WithQueueBatchWindow(time.Hour),
+		WithQueueSize(1),
+	)
+	assert.NoError(t, client.QueueEmbeds(context.Background(), discord.Embed{Title: "first"}))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	// the default policy blocks until there is space in the queue
+	assert.ErrorIs(t, client.QueueEmbeds(ctx, discord.Embed{Title: "second"}), context.DeadlineExceeded)
+	client.Close(context.Background())
+}
+
+func TestConfig_QueueClamp(t *testing.T) {
+	config := DefaultConfig()
+	config.Apply([]ConfigOpt{WithQueueSize(0), WithQueueBatchWindow(-time.Second)})
+	assert.Equal(t, DefaultQueueSize, config.QueueSize)
+	assert.Equal(t, time.Duration(0), config.QueueBatchWindow)
+
+	server, messages := newTestServer(t)
+	for _, policy := range []QueueFullPolicy{QueueFullPolicyBlock, QueueFullPolicyDropOldest, QueueFullPolicyDropNewest} {
+		client := New(snowflake.ID(1), "token",
+			WithRestClientConfigOpts(rest.WithURL(server.URL)),
+			WithQueueSize(-1),
+			WithQueueFullPolicy(policy),
+		)
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		assert.NoError(t, client.QueueEmbeds(ctx, discord.Embed{Title: "queued"}))
+		assert.NoError(t, client.Flush(ctx))
+		cancel()
+		client.Close(context.Background())
+	}
+	assert.Len(t, messages(), 3)
+}
@@ -1,10 +1,16 @@
 package webhook
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/disgoorg/snowflake/v2"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/rest"
 )
 
 func TestParseURL(t *testing.T) {
@@ -52,3 +58,25 @@ func TestParseURL(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_WithComponents(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query().Get("with_components"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"1","channel_id":"1"}`))
+	}))
+	defer server.Close()
+	client := New(snowflake.ID(1), "token", WithRestClientConfigOpts(rest.WithURL(server.URL)))
+	defer client.Close(context.Background())
+
+	components := []discord.ContainerComponent{discord.NewActionRow(discord.NewLinkButton("Open", "https://example.com"))}
+	_, err := client.CreateMessage(discord.WebhookMessageCreate{Content: "test"})
+	assert.NoError(t, err)
+	_, err = client.CreateMessage(discord.WebhookMessageCreate{Components: components})
+	assert.NoError(t, err)
+	_, err = client.UpdateMessage(1, discord.WebhookMessageUpdate{Components: &components})
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"", "true", "true"}, queries)
+}